@@ -94,6 +94,7 @@ func ClusterVariableFuzzer(in *clusterv1.ClusterVariable, c fuzz.Continue) {
 func ClusterClassJSONFuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 	return []interface{}{
 		JSONPatchFuzzer,
+		MergePatchFuzzer,
 		JSONSchemaPropsFuzzer,
 	}
 }
@@ -105,6 +106,13 @@ func JSONPatchFuzzer(in *clusterv1.JSONPatch, c fuzz.Continue) {
 	in.Value = &apiextensionsv1.JSON{Raw: []byte("5")}
 }
 
+func MergePatchFuzzer(in *clusterv1.MergePatch, c fuzz.Continue) {
+	c.FuzzNoCustom(in)
+
+	// Not every random byte array is valid JSON, e.g. a string without `""`,so we're setting a valid value.
+	in.Value = &apiextensionsv1.JSON{Raw: []byte(`{"spec":{}}`)}
+}
+
 func JSONSchemaPropsFuzzer(in *clusterv1.JSONSchemaProps, c fuzz.Continue) {
 	// NOTE: We have to fuzz the individual fields manually,
 	// because we cannot call `FuzzNoCustom` as it would lead
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -75,6 +76,13 @@ type Topology struct {
 	// The name of the ClusterClass object to create the topology.
 	Class string `json:"class"`
 
+	// ClassNamespace is the namespace of the ClusterClass object to create the topology.
+	// If empty or not set, the ClusterClass is expected to be in the same namespace as the Cluster.
+	// A ClusterClass in a different namespace can be used only if it allows the namespace
+	// of the Cluster in its spec.allowedNamespaces.
+	// +optional
+	ClassNamespace string `json:"classNamespace,omitempty"`
+
 	// The Kubernetes version of the cluster.
 	Version string `json:"version"`
 
@@ -157,21 +165,79 @@ type MachineDeploymentTopology struct {
 	// Replicas is the number of worker nodes belonging to this set.
 	// If the value is nil, the MachineDeployment is created without the number of Replicas (defaulting to zero)
 	// and it's assumed that an external entity (like cluster autoscaler) is responsible for the management
-	// of this value.
+	// of this value; the topology controller preserves the current number of replicas of the MachineDeployment.
+	// NOTE: Replicas and Autoscaling are mutually exclusive.
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Autoscaling enables management of the number of replicas by cluster-autoscaler.
+	// If set, the topology controller never changes the number of replicas of an existing MachineDeployment,
+	// creates new MachineDeployments with MinSize replicas and sets the cluster-autoscaler
+	// min and max size annotations on the MachineDeployment.
+	// NOTE: Replicas and Autoscaling are mutually exclusive.
+	// +optional
+	Autoscaling *MachineDeploymentAutoscaling `json:"autoscaling,omitempty"`
+
 	// NodeDrainTimeout is the total amount of time that the controller will spend on draining a node.
 	// The default value is 0, meaning that the node can be drained without any time limitations.
 	// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
 	// +optional
 	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
 
+	// MinReadySeconds is the minimum number of seconds for which a newly created machine should
+	// be ready.
+	// Defaults to 0 (machine will be considered available as soon as it
+	// is ready)
+	// If not set, the value from the MachineDeploymentClass is used.
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// Strategy is the deployment strategy to use to replace existing machines with
+	// new ones, including the DeletePolicy used when scaling down.
+	// If not set, the value from the MachineDeploymentClass is used.
+	// +optional
+	Strategy *MachineDeploymentStrategy `json:"strategy,omitempty"`
+
+	// MachineHealthCheck allows to enable, disable and override
+	// the MachineHealthCheck configuration in the ClusterClass for this MachineDeployment.
+	// +optional
+	MachineHealthCheck *MachineHealthCheckTopology `json:"machineHealthCheck,omitempty"`
+
 	// Variables can be used to customize the MachineDeployment through patches.
 	// +optional
 	Variables *MachineDeploymentVariables `json:"variables,omitempty"`
 }
 
+// MachineDeploymentAutoscaling defines the boundaries within which cluster-autoscaler can scale a MachineDeployment.
+type MachineDeploymentAutoscaling struct {
+	// MinSize is the minimum number of replicas cluster-autoscaler can scale the MachineDeployment down to.
+	// +kubebuilder:validation:Minimum=0
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize is the maximum number of replicas cluster-autoscaler can scale the MachineDeployment up to.
+	// +kubebuilder:validation:Minimum=1
+	MaxSize int32 `json:"maxSize"`
+}
+
+// MachineHealthCheckTopology defines a MachineHealthCheck for a group of machines.
+type MachineHealthCheckTopology struct {
+	// Enable controls if a MachineHealthCheck should be created for the target machines.
+	//
+	// If false: No MachineHealthCheck will be created.
+	//
+	// If not set(default): A MachineHealthCheck will be created if it is defined here or
+	// in the associated ClusterClass. If no MachineHealthCheck is defined then none will be created.
+	//
+	// If true: A MachineHealthCheck is guaranteed to be created. Cluster validation will
+	// block if `enable` is true and no MachineHealthCheck definition is available.
+	// +optional
+	Enable *bool `json:"enable,omitempty"`
+
+	// MachineHealthCheckClass defines a MachineHealthCheck for a group of machines.
+	// If specified (any field is set), it entirely overrides the MachineHealthCheckClass defined in ClusterClass.
+	MachineHealthCheckClass `json:",inline"`
+}
+
 // ClusterVariable can be used to customize the Cluster through
 // patches. It must comply to the corresponding
 // ClusterClassVariable defined in the ClusterClass.
@@ -186,7 +252,30 @@ type ClusterVariable struct {
 	// hard-coded schema for apiextensionsv1.JSON which cannot be produced by another type via controller-tools,
 	// i.e. it is not possible to have no type field.
 	// Ref: https://github.com/kubernetes-sigs/controller-tools/blob/d0e03a142d0ecdd5491593e941ee1d6b5d91dba6/pkg/crd/known_types.go#L106-L111
+	// Note: Exactly one of value or valueFrom must be set.
+	// +optional
 	Value apiextensionsv1.JSON `json:"value"`
+
+	// ValueFrom is the source for the value of the variable.
+	// Note: the value will be resolved by the topology controller and validated against the schema
+	// of the corresponding ClusterClassVariable from the ClusterClass; resolved values are never written
+	// back to the Cluster object.
+	// +optional
+	ValueFrom *ClusterVariableSource `json:"valueFrom,omitempty"`
+}
+
+// ClusterVariableSource represents a source for the value of a ClusterVariable.
+// Exactly one of its fields must be set.
+type ClusterVariableSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the Cluster namespace.
+	// Note: the content of the key is parsed as YAML, unless the variable is of type string.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret in the Cluster namespace.
+	// Note: the content of the key is parsed as YAML, unless the variable is of type string.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
 }
 
 // MachineDeploymentVariables can be used to provide variables for a specific MachineDeployment.
@@ -278,10 +367,44 @@ type ClusterStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Addons is the inventory of the add-ons defined in the ClusterClass which have been applied
+	// to the workload cluster; it is used to delete the objects which are no longer part of an add-on.
+	// +optional
+	Addons []ClusterAddonStatus `json:"addons,omitempty"`
 }
 
 // ANCHOR_END: ClusterStatus
 
+// ClusterAddonStatus defines the observed state of an add-on applied to the workload cluster.
+type ClusterAddonStatus struct {
+	// Name of the add-on.
+	Name string `json:"name"`
+
+	// Version of the add-on which has been applied.
+	Version string `json:"version"`
+
+	// Objects is the list of objects which have been applied for this version of the add-on.
+	// +optional
+	Objects []AddonObjectReference `json:"objects,omitempty"`
+}
+
+// AddonObjectReference references an object applied to the workload cluster for an add-on.
+type AddonObjectReference struct {
+	// APIVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object; empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
+}
+
 // SetTypedPhase sets the Phase field to the string representation of ClusterPhase.
 func (c *ClusterStatus) SetTypedPhase(p ClusterPhase) {
 	c.Phase = string(p)
@@ -358,6 +481,20 @@ func (c *Cluster) SetConditions(conditions Conditions) {
 	c.Status.Conditions = conditions
 }
 
+// GetClassKey returns the namespaced name of the ClusterClass used by the Cluster.
+// NOTE: The ClusterClass is expected to be in the same namespace as the Cluster if
+// spec.topology.classNamespace is not set.
+func (c *Cluster) GetClassKey() types.NamespacedName {
+	if c.Spec.Topology == nil {
+		return types.NamespacedName{}
+	}
+	namespace := c.Spec.Topology.ClassNamespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: c.Spec.Topology.Class}
+}
+
 // GetIPFamily returns a ClusterIPFamily from the configuration provided.
 func (c *Cluster) GetIPFamily() (ClusterIPFamily, error) {
 	var podCIDRs, serviceCIDRs []string
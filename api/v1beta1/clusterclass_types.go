@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"reflect"
+
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +28,7 @@ import (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusterclasses,shortName=cc,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of ClusterClass"
 
 // ClusterClass is a template which can be used to create managed topologies.
@@ -33,7 +36,8 @@ type ClusterClass struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ClusterClassSpec `json:"spec,omitempty"`
+	Spec   ClusterClassSpec   `json:"spec,omitempty"`
+	Status ClusterClassStatus `json:"status,omitempty"`
 }
 
 // ClusterClassSpec describes the desired state of the ClusterClass.
@@ -67,6 +71,68 @@ type ClusterClassSpec struct {
 	// Note: Patches will be applied in the order of the array.
 	// +optional
 	Patches []ClusterClassPatch `json:"patches,omitempty"`
+
+	// Addons defines the add-ons, e.g. CNI, CSI or cloud-provider, which are installed
+	// in the workload cluster once the control plane is initialized.
+	// Note: Add-ons are installed in the order of the array; an add-on is installed only
+	// after the previous ones are ready.
+	// +optional
+	Addons []ClusterClassAddon `json:"addons,omitempty"`
+
+	// AllowedNamespaces defines the namespaces, other than the namespace of the ClusterClass,
+	// from which Clusters are allowed to use the ClusterClass.
+	// If not set, the ClusterClass can be used only by Clusters in its own namespace.
+	// +optional
+	AllowedNamespaces *ClusterClassAllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// ClusterClassAllowedNamespaces defines the namespaces from which Clusters are allowed to use a ClusterClass.
+// A namespace is allowed if it is included in Names or if it matches the Selector.
+type ClusterClassAllowedNamespaces struct {
+	// Names is a list of namespaces allowed to use the ClusterClass.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// Selector is a label selector for the namespaces allowed to use the ClusterClass.
+	// An empty selector allows all the namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ClusterClassAddon defines an add-on installed in the Clusters using a ClusterClass.
+type ClusterClassAddon struct {
+	// Name of the add-on.
+	Name string `json:"name"`
+
+	// Versions of the add-on.
+	// The first version whose KubernetesVersions range matches the Kubernetes version of the control
+	// plane is installed; when the control plane is upgraded, the add-on is upgraded accordingly.
+	Versions []ClusterClassAddonVersion `json:"versions"`
+}
+
+// ClusterClassAddonVersion defines a version of an add-on and the Kubernetes versions it supports.
+type ClusterClassAddonVersion struct {
+	// Version of the add-on, e.g. v1.12.0.
+	Version string `json:"version"`
+
+	// KubernetesVersions is the range of Kubernetes versions supported by this version of the add-on,
+	// e.g. ">=1.23.0 <1.25.0".
+	KubernetesVersions string `json:"kubernetesVersions"`
+
+	// Resources is the list of ConfigMaps and Secrets in the namespace of the ClusterClass
+	// containing the manifests of this version of the add-on.
+	Resources []AddonResourceRef `json:"resources"`
+}
+
+// AddonResourceRef references a ConfigMap or a Secret containing add-on manifests.
+type AddonResourceRef struct {
+	// Name of the resource.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Kind of the resource. Supported kinds are: Secret and ConfigMap.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind string `json:"kind"`
 }
 
 // ControlPlaneClass defines the class for the control plane.
@@ -121,6 +187,20 @@ type MachineDeploymentClass struct {
 	// MachineHealthCheck defines a MachineHealthCheck for this MachineDeploymentClass.
 	// +optional
 	MachineHealthCheck *MachineHealthCheckClass `json:"machineHealthCheck,omitempty"`
+
+	// MinReadySeconds is the minimum number of seconds for which a newly created machine should
+	// be ready.
+	// Defaults to 0 (machine will be considered available as soon as it
+	// is ready)
+	// NOTE: This value can be overridden while defining a Cluster.Topology using this MachineDeploymentClass.
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// Strategy is the deployment strategy to use to replace existing machines with
+	// new ones, including the DeletePolicy used when scaling down.
+	// NOTE: This value can be overridden while defining a Cluster.Topology using this MachineDeploymentClass.
+	// +optional
+	Strategy *MachineDeploymentStrategy `json:"strategy,omitempty"`
 }
 
 // MachineDeploymentClassTemplate defines how a MachineDeployment generated from a MachineDeploymentClass
@@ -176,6 +256,11 @@ type MachineHealthCheckClass struct {
 	RemediationTemplate *corev1.ObjectReference `json:"remediationTemplate,omitempty"`
 }
 
+// IsZero returns true if none of the values of MachineHealthCheckClass are defined.
+func (m MachineHealthCheckClass) IsZero() bool {
+	return reflect.DeepEqual(m, MachineHealthCheckClass{})
+}
+
 // ClusterClassVariable defines a variable which can
 // be configured in the Cluster topology and used in patches.
 type ClusterClassVariable struct {
@@ -318,6 +403,27 @@ type JSONSchemaProps struct {
 	// NOTE: Can be set for all types.
 	// +optional
 	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+
+	// XValidations describes a list of validation rules written in the CEL expression language.
+	// Rules are evaluated against the value of the variable, which is available as `self`, and they can
+	// be used to express validations across fields, e.g. `!self.bastion.enabled || has(self.bastion.instanceType)`.
+	// NOTE: Can be set for all types.
+	// +optional
+	XValidations []ValidationRule `json:"x-kubernetes-validations,omitempty"`
+}
+
+// ValidationRule describes a validation rule written in the CEL expression language.
+type ValidationRule struct {
+	// Rule represents the expression which will be evaluated by CEL.
+	// The `self` variable in the CEL expression is bound to the scoped value.
+	// For a list of supported CEL functions please see: (of the k8s.io/apiextensions-apiserver version we're currently using)
+	// https://kubernetes.io/docs/tasks/extend-kubernetes/custom-resources/custom-resource-definitions/#validation-rules
+	Rule string `json:"rule"`
+
+	// Message represents the message displayed when validation fails.
+	// If not set, the message is "failed rule: {Rule}".
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // ClusterClassPatch defines a patch which is applied to customize the referenced templates.
@@ -356,7 +462,42 @@ type PatchDefinition struct {
 	// JSONPatches defines the patches which should be applied on the templates
 	// matching the selector.
 	// Note: Patches will be applied in the order of the array.
-	JSONPatches []JSONPatch `json:"jsonPatches"`
+	// Note: At least one of JSONPatches, MergePatch or StrategicMergePatch must be set.
+	// +optional
+	JSONPatches []JSONPatch `json:"jsonPatches,omitempty"`
+
+	// MergePatch defines a JSON merge patch (RFC 7386) which should be applied on the templates
+	// matching the selector, after JSONPatches.
+	// +optional
+	MergePatch *MergePatch `json:"mergePatch,omitempty"`
+
+	// StrategicMergePatch defines a strategic merge patch which should be applied on the templates
+	// matching the selector, after JSONPatches and MergePatch.
+	// Note: Given that templates do not define patch strategies, lists of objects are merged using
+	// the first of the following keys which is set on all the list items: name, path, key, mountPath,
+	// devicePath, ip, type, topologyKey, containerPort; all the other lists are replaced.
+	// The $patch: delete and $patch: replace directives are supported.
+	// +optional
+	StrategicMergePatch *MergePatch `json:"strategicMergePatch,omitempty"`
+}
+
+// MergePatch defines a merge patch.
+// Note: Only the spec of a template can be patched, thus the patch must only contain the spec field.
+// Note: Exactly one of Value or Template must be set.
+type MergePatch struct {
+	// Value defines the patch.
+	// Note: We have to use apiextensionsv1.JSON instead of our JSON type,
+	// because controller-tools has a hard-coded schema for apiextensionsv1.JSON
+	// which cannot be produced by another type (unset type field).
+	// Ref: https://github.com/kubernetes-sigs/controller-tools/blob/d0e03a142d0ecdd5491593e941ee1d6b5d91dba6/pkg/crd/known_types.go#L106-L111
+	// +optional
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+
+	// Template is the Go template to be used to calculate the patch.
+	// A template can reference variables defined in .spec.variables and builtin variables.
+	// Note: The template must evaluate to a valid YAML or JSON object.
+	// +optional
+	Template *string `json:"template,omitempty"`
 }
 
 // PatchSelector defines on which templates the patch should be applied.
@@ -467,6 +608,32 @@ type LocalObjectTemplate struct {
 	Ref *corev1.ObjectReference `json:"ref"`
 }
 
+// ClusterClassStatus defines the observed state of the ClusterClass.
+type ClusterClassStatus struct {
+	// TemplateRevisions lists the revisions of the templates referenced by the ClusterClass.
+	// A revision is an immutable copy of a template, created by the ClusterClass controller every time the content
+	// of a referenced template changes; managed topologies are created from revisions, and revisions no longer
+	// referenced by the ClusterClass are deleted once no Cluster is using them.
+	// +optional
+	TemplateRevisions []ClusterClassTemplateRevision `json:"templateRevisions,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ClusterClassTemplateRevision is an immutable copy of a template referenced by a ClusterClass.
+type ClusterClassTemplateRevision struct {
+	// TemplateRef is a reference to the template the revision has been created from.
+	TemplateRef corev1.ObjectReference `json:"templateRef"`
+
+	// RevisionRef is a reference to the immutable copy of the template.
+	RevisionRef corev1.ObjectReference `json:"revisionRef"`
+
+	// Hash is the hash of the content of the template the revision has been created from.
+	Hash string `json:"hash"`
+}
+
 // +kubebuilder:object:root=true
 
 // ClusterClassList contains a list of Cluster.
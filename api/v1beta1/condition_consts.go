@@ -282,3 +282,26 @@ const (
 	// not yet completed because at least one of the lifecycle hooks is blocking.
 	TopologyReconciledHookBlockingReason = "LifecycleHookBlocking"
 )
+
+// Conditions and condition reasons for the add-ons of Clusters with a managed Topology.
+const (
+	// TopologyAddonsReadyCondition documents the status of the add-ons defined in the ClusterClass
+	// of a Cluster with a managed topology.
+	TopologyAddonsReadyCondition ConditionType = "TopologyAddonsReady"
+
+	// TopologyAddonsWaitingForControlPlaneReason (Severity=Info) documents add-ons not yet installed
+	// or upgraded because the control plane is not yet initialized or it is upgrading.
+	TopologyAddonsWaitingForControlPlaneReason = "WaitingForControlPlane"
+
+	// TopologyAddonNotReadyReason (Severity=Info) documents an add-on which is not yet ready; the add-ons
+	// following it are not yet installed or upgraded.
+	TopologyAddonNotReadyReason = "AddonNotReady"
+
+	// TopologyAddonApplyFailedReason (Severity=Warning) documents an add-on which failed to be applied
+	// to the workload cluster.
+	TopologyAddonApplyFailedReason = "AddonApplyFailed"
+
+	// TopologyAddonDeleteFailedReason (Severity=Warning) documents an add-on whose objects which are
+	// no longer part of it failed to be deleted from the workload cluster.
+	TopologyAddonDeleteFailedReason = "AddonDeleteFailed"
+)
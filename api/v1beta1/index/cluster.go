@@ -30,6 +30,11 @@ import (
 const (
 	// ClusterClassNameField is used by the Cluster controller to index Clusters by ClusterClass name.
 	ClusterClassNameField = "spec.topology.class"
+
+	// ClusterClassRefPath is used by the Cluster controller to index Clusters by the namespaced name of their ClusterClass,
+	// e.g. "default/my-cluster-class".
+	// NOTE: Differently from ClusterClassNameField, this index also includes Clusters using a ClusterClass in another namespace.
+	ClusterClassRefPath = "spec.topology.classRef"
 )
 
 // ByClusterClassName adds the cluster class name  index to the
@@ -54,3 +59,27 @@ func clusterByClassName(o client.Object) []string {
 	}
 	return nil
 }
+
+// ByClusterClassRef adds the ClusterClass namespaced name index to the
+// managers cache.
+func ByClusterClassRef(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetCache().IndexField(ctx, &clusterv1.Cluster{},
+		ClusterClassRefPath,
+		ClusterByClusterClassRef,
+	); err != nil {
+		return errors.Wrap(err, "error setting index field")
+	}
+	return nil
+}
+
+// ClusterByClusterClassRef contains the logic to index Clusters by the namespaced name of their ClusterClass.
+func ClusterByClusterClassRef(o client.Object) []string {
+	cluster, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected Cluster but got a %T", o))
+	}
+	if cluster.Spec.Topology != nil {
+		return []string{cluster.GetClassKey().String()}
+	}
+	return nil
+}
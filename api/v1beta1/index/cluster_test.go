@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -57,3 +58,50 @@ func TestClusterByClassName(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterByClusterClassRef(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   client.Object
+		expected []string
+	}{
+		{
+			name:     "when cluster has no Topology",
+			object:   &clusterv1.Cluster{},
+			expected: nil,
+		},
+		{
+			name: "when cluster uses a ClusterClass in the same namespace",
+			object: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1"},
+				Spec: clusterv1.ClusterSpec{
+					Topology: &clusterv1.Topology{
+						Class: "class1",
+					},
+				},
+			},
+			expected: []string{"ns1/class1"},
+		},
+		{
+			name: "when cluster uses a ClusterClass in another namespace",
+			object: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1"},
+				Spec: clusterv1.ClusterSpec{
+					Topology: &clusterv1.Topology{
+						Class:          "class1",
+						ClassNamespace: "ns2",
+					},
+				},
+			},
+			expected: []string{"ns2/class1"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := ClusterByClusterClassRef(test.object)
+			g.Expect(got).To(Equal(test.expected))
+		})
+	}
+}
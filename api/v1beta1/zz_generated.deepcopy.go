@@ -45,6 +45,36 @@ func (in *APIEndpoint) DeepCopy() *APIEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonObjectReference) DeepCopyInto(out *AddonObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonObjectReference.
+func (in *AddonObjectReference) DeepCopy() *AddonObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonResourceRef) DeepCopyInto(out *AddonResourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonResourceRef.
+func (in *AddonResourceRef) DeepCopy() *AddonResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootstrap) DeepCopyInto(out *Bootstrap) {
 	*out = *in
@@ -97,12 +127,33 @@ func (in *Cluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAddonStatus) DeepCopyInto(out *ClusterAddonStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AddonObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAddonStatus.
+func (in *ClusterAddonStatus) DeepCopy() *ClusterAddonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAddonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClass) DeepCopyInto(out *ClusterClass) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClass.
@@ -123,6 +174,73 @@ func (in *ClusterClass) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassAddon) DeepCopyInto(out *ClusterClassAddon) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ClusterClassAddonVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassAddon.
+func (in *ClusterClassAddon) DeepCopy() *ClusterClassAddon {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassAddon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassAddonVersion) DeepCopyInto(out *ClusterClassAddonVersion) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]AddonResourceRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassAddonVersion.
+func (in *ClusterClassAddonVersion) DeepCopy() *ClusterClassAddonVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassAddonVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassAllowedNamespaces) DeepCopyInto(out *ClusterClassAllowedNamespaces) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassAllowedNamespaces.
+func (in *ClusterClassAllowedNamespaces) DeepCopy() *ClusterClassAllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassAllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClassList) DeepCopyInto(out *ClusterClassList) {
 	*out = *in
@@ -207,6 +325,18 @@ func (in *ClusterClassSpec) DeepCopyInto(out *ClusterClassSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]ClusterClassAddon, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(ClusterClassAllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassSpec.
@@ -219,6 +349,43 @@ func (in *ClusterClassSpec) DeepCopy() *ClusterClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassStatus) DeepCopyInto(out *ClusterClassStatus) {
+	*out = *in
+	if in.TemplateRevisions != nil {
+		in, out := &in.TemplateRevisions, &out.TemplateRevisions
+		*out = make([]ClusterClassTemplateRevision, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassStatus.
+func (in *ClusterClassStatus) DeepCopy() *ClusterClassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassTemplateRevision) DeepCopyInto(out *ClusterClassTemplateRevision) {
+	*out = *in
+	out.TemplateRef = in.TemplateRef
+	out.RevisionRef = in.RevisionRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassTemplateRevision.
+func (in *ClusterClassTemplateRevision) DeepCopy() *ClusterClassTemplateRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassTemplateRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClassVariable) DeepCopyInto(out *ClusterClassVariable) {
 	*out = *in
@@ -360,6 +527,13 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]ClusterAddonStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -376,6 +550,11 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 func (in *ClusterVariable) DeepCopyInto(out *ClusterVariable) {
 	*out = *in
 	in.Value.DeepCopyInto(&out.Value)
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ClusterVariableSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVariable.
@@ -388,6 +567,31 @@ func (in *ClusterVariable) DeepCopy() *ClusterVariable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVariableSource) DeepCopyInto(out *ClusterVariableSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVariableSource.
+func (in *ClusterVariableSource) DeepCopy() *ClusterVariableSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVariableSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -668,6 +872,11 @@ func (in *JSONSchemaProps) DeepCopyInto(out *JSONSchemaProps) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.XValidations != nil {
+		in, out := &in.XValidations, &out.XValidations
+		*out = make([]ValidationRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONSchemaProps.
@@ -788,6 +997,21 @@ func (in *MachineDeployment) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentAutoscaling) DeepCopyInto(out *MachineDeploymentAutoscaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentAutoscaling.
+func (in *MachineDeploymentAutoscaling) DeepCopy() *MachineDeploymentAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentClass) DeepCopyInto(out *MachineDeploymentClass) {
 	*out = *in
@@ -797,6 +1021,16 @@ func (in *MachineDeploymentClass) DeepCopyInto(out *MachineDeploymentClass) {
 		*out = new(MachineHealthCheckClass)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(MachineDeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentClass.
@@ -957,11 +1191,31 @@ func (in *MachineDeploymentTopology) DeepCopyInto(out *MachineDeploymentTopology
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(MachineDeploymentAutoscaling)
+		**out = **in
+	}
 	if in.NodeDrainTimeout != nil {
 		in, out := &in.NodeDrainTimeout, &out.NodeDrainTimeout
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(MachineDeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineHealthCheck != nil {
+		in, out := &in.MachineHealthCheck, &out.MachineHealthCheck
+		*out = new(MachineHealthCheckTopology)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Variables != nil {
 		in, out := &in.Variables, &out.Variables
 		*out = new(MachineDeploymentVariables)
@@ -1168,6 +1422,27 @@ func (in *MachineHealthCheckStatus) DeepCopy() *MachineHealthCheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineHealthCheckTopology) DeepCopyInto(out *MachineHealthCheckTopology) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	in.MachineHealthCheckClass.DeepCopyInto(&out.MachineHealthCheckClass)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineHealthCheckTopology.
+func (in *MachineHealthCheckTopology) DeepCopy() *MachineHealthCheckTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineHealthCheckTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineList) DeepCopyInto(out *MachineList) {
 	*out = *in
@@ -1402,6 +1677,10 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 		in, out := &in.LastUpdated, &out.LastUpdated
 		*out = (*in).DeepCopy()
 	}
+	if in.CertificatesExpiryDate != nil {
+		in, out := &in.CertificatesExpiryDate, &out.CertificatesExpiryDate
+		*out = (*in).DeepCopy()
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -1453,6 +1732,31 @@ func (in *MachineTemplateSpec) DeepCopy() *MachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergePatch) DeepCopyInto(out *MergePatch) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergePatch.
+func (in *MergePatch) DeepCopy() *MergePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(MergePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkRanges) DeepCopyInto(out *NetworkRanges) {
 	*out = *in
@@ -1513,6 +1817,16 @@ func (in *PatchDefinition) DeepCopyInto(out *PatchDefinition) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MergePatch != nil {
+		in, out := &in.MergePatch, &out.MergePatch
+		*out = new(MergePatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StrategicMergePatch != nil {
+		in, out := &in.StrategicMergePatch, &out.StrategicMergePatch
+		*out = new(MergePatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchDefinition.
@@ -1629,6 +1943,21 @@ func (in *UnhealthyCondition) DeepCopy() *UnhealthyCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationRule.
+func (in *ValidationRule) DeepCopy() *ValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VariableSchema) DeepCopyInto(out *VariableSchema) {
 	*out = *in
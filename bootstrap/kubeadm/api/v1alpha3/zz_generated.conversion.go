@@ -59,11 +59,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.FileSource)(nil), (*FileSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_FileSource_To_v1alpha3_FileSource(a.(*v1beta1.FileSource), b.(*FileSource), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Filesystem)(nil), (*v1beta1.Filesystem)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Filesystem_To_v1beta1_Filesystem(a.(*Filesystem), b.(*v1beta1.Filesystem), scope)
 	}); err != nil {
@@ -99,11 +94,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.KubeadmConfigStatus)(nil), (*KubeadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_KubeadmConfigStatus_To_v1alpha3_KubeadmConfigStatus(a.(*v1beta1.KubeadmConfigStatus), b.(*KubeadmConfigStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*KubeadmConfigTemplate)(nil), (*v1beta1.KubeadmConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_KubeadmConfigTemplate_To_v1beta1_KubeadmConfigTemplate(a.(*KubeadmConfigTemplate), b.(*v1beta1.KubeadmConfigTemplate), scope)
 	}); err != nil {
@@ -209,6 +199,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.FileSource)(nil), (*FileSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FileSource_To_v1alpha3_FileSource(a.(*v1beta1.FileSource), b.(*FileSource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.InitConfiguration)(nil), (*upstreamv1beta1.InitConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_InitConfiguration_To_upstreamv1beta1_InitConfiguration(a.(*v1beta1.InitConfiguration), b.(*upstreamv1beta1.InitConfiguration), scope)
 	}); err != nil {
@@ -224,6 +219,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.KubeadmConfigStatus)(nil), (*KubeadmConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeadmConfigStatus_To_v1alpha3_KubeadmConfigStatus(a.(*v1beta1.KubeadmConfigStatus), b.(*KubeadmConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.User)(nil), (*User)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_User_To_v1alpha3_User(a.(*v1beta1.User), b.(*User), scope)
 	}); err != nil {
@@ -260,7 +260,15 @@ func autoConvert_v1alpha3_File_To_v1beta1_File(in *File, out *v1beta1.File, s co
 	out.Permissions = in.Permissions
 	out.Encoding = v1beta1.Encoding(in.Encoding)
 	out.Content = in.Content
-	out.ContentFrom = (*v1beta1.FileSource)(unsafe.Pointer(in.ContentFrom))
+	if in.ContentFrom != nil {
+		in, out := &in.ContentFrom, &out.ContentFrom
+		*out = new(v1beta1.FileSource)
+		if err := Convert_v1alpha3_FileSource_To_v1beta1_FileSource(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ContentFrom = nil
+	}
 	return nil
 }
 
@@ -276,7 +284,16 @@ func autoConvert_v1beta1_File_To_v1alpha3_File(in *v1beta1.File, out *File, s co
 	out.Encoding = Encoding(in.Encoding)
 	// WARNING: in.Append requires manual conversion: does not exist in peer-type
 	out.Content = in.Content
-	out.ContentFrom = (*FileSource)(unsafe.Pointer(in.ContentFrom))
+	if in.ContentFrom != nil {
+		in, out := &in.ContentFrom, &out.ContentFrom
+		*out = new(FileSource)
+		if err := Convert_v1beta1_FileSource_To_v1alpha3_FileSource(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ContentFrom = nil
+	}
+	// WARNING: in.Template requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -296,14 +313,10 @@ func autoConvert_v1beta1_FileSource_To_v1alpha3_FileSource(in *v1beta1.FileSourc
 	if err := Convert_v1beta1_SecretFileSource_To_v1alpha3_SecretFileSource(&in.Secret, &out.Secret, s); err != nil {
 		return err
 	}
+	// WARNING: in.ConfigMap requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_FileSource_To_v1alpha3_FileSource is an autogenerated conversion function.
-func Convert_v1beta1_FileSource_To_v1alpha3_FileSource(in *v1beta1.FileSource, out *FileSource, s conversion.Scope) error {
-	return autoConvert_v1beta1_FileSource_To_v1alpha3_FileSource(in, out, s)
-}
-
 func autoConvert_v1alpha3_Filesystem_To_v1beta1_Filesystem(in *Filesystem, out *v1beta1.Filesystem, s conversion.Scope) error {
 	out.Device = in.Device
 	out.Filesystem = in.Filesystem
@@ -535,6 +548,11 @@ func autoConvert_v1beta1_KubeadmConfigSpec_To_v1alpha3_KubeadmConfigSpec(in *v1b
 	out.Verbosity = (*int32)(unsafe.Pointer(in.Verbosity))
 	out.UseExperimentalRetryJoin = in.UseExperimentalRetryJoin
 	// WARNING: in.Ignition requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapData requires manual conversion: does not exist in peer-type
+	// WARNING: in.Containerd requires manual conversion: does not exist in peer-type
+	// WARNING: in.SystemdUnits requires manual conversion: does not exist in peer-type
+	// WARNING: in.TokenRotation requires manual conversion: does not exist in peer-type
+	// WARNING: in.External requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -576,14 +594,10 @@ func autoConvert_v1beta1_KubeadmConfigStatus_To_v1alpha3_KubeadmConfigStatus(in
 	} else {
 		out.Conditions = nil
 	}
+	// WARNING: in.LastTokenRotationTime requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_KubeadmConfigStatus_To_v1alpha3_KubeadmConfigStatus is an autogenerated conversion function.
-func Convert_v1beta1_KubeadmConfigStatus_To_v1alpha3_KubeadmConfigStatus(in *v1beta1.KubeadmConfigStatus, out *KubeadmConfigStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_KubeadmConfigStatus_To_v1alpha3_KubeadmConfigStatus(in, out, s)
-}
-
 func autoConvert_v1alpha3_KubeadmConfigTemplate_To_v1beta1_KubeadmConfigTemplate(in *KubeadmConfigTemplate, out *v1beta1.KubeadmConfigTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_KubeadmConfigTemplateSpec_To_v1beta1_KubeadmConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
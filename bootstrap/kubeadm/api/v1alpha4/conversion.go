@@ -49,6 +49,12 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Ignition = restored.Spec.Ignition
+	dst.Spec.BootstrapData = restored.Spec.BootstrapData
+	dst.Spec.Containerd = restored.Spec.Containerd
+	dst.Spec.SystemdUnits = restored.Spec.SystemdUnits
+	dst.Spec.TokenRotation = restored.Spec.TokenRotation
+	dst.Spec.External = restored.Spec.External
+	dst.Status.LastTokenRotationTime = restored.Status.LastTokenRotationTime
 	if restored.Spec.InitConfiguration != nil {
 		if dst.Spec.InitConfiguration == nil {
 			dst.Spec.InitConfiguration = &bootstrapv1.InitConfiguration{}
@@ -114,6 +120,11 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Template.Spec.Ignition = restored.Spec.Template.Spec.Ignition
+	dst.Spec.Template.Spec.BootstrapData = restored.Spec.Template.Spec.BootstrapData
+	dst.Spec.Template.Spec.Containerd = restored.Spec.Template.Spec.Containerd
+	dst.Spec.Template.Spec.SystemdUnits = restored.Spec.Template.Spec.SystemdUnits
+	dst.Spec.Template.Spec.TokenRotation = restored.Spec.Template.Spec.TokenRotation
+	dst.Spec.Template.Spec.External = restored.Spec.Template.Spec.External
 	if restored.Spec.Template.Spec.InitConfiguration != nil {
 		if dst.Spec.Template.Spec.InitConfiguration == nil {
 			dst.Spec.Template.Spec.InitConfiguration = &bootstrapv1.InitConfiguration{}
@@ -156,10 +167,16 @@ func (dst *KubeadmConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 
 // Convert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec is an autogenerated conversion function.
 func Convert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec(in *bootstrapv1.KubeadmConfigSpec, out *KubeadmConfigSpec, s apiconversion.Scope) error {
-	// KubeadmConfigSpec.Ignition does not exist in kubeadm v1alpha4 API.
+	// KubeadmConfigSpec.Ignition, KubeadmConfigSpec.BootstrapData, KubeadmConfigSpec.Containerd,
+	// KubeadmConfigSpec.SystemdUnits, KubeadmConfigSpec.TokenRotation and KubeadmConfigSpec.External do not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec(in, out, s)
 }
 
+func Convert_v1beta1_KubeadmConfigStatus_To_v1alpha4_KubeadmConfigStatus(in *bootstrapv1.KubeadmConfigStatus, out *KubeadmConfigStatus, s apiconversion.Scope) error {
+	// KubeadmConfigStatus.LastTokenRotationTime does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_KubeadmConfigStatus_To_v1alpha4_KubeadmConfigStatus(in, out, s)
+}
+
 func Convert_v1beta1_InitConfiguration_To_v1alpha4_InitConfiguration(in *bootstrapv1.InitConfiguration, out *InitConfiguration, s apiconversion.Scope) error {
 	// InitConfiguration.Patches does not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_InitConfiguration_To_v1alpha4_InitConfiguration(in, out, s)
@@ -171,10 +188,15 @@ func Convert_v1beta1_JoinConfiguration_To_v1alpha4_JoinConfiguration(in *bootstr
 }
 
 func Convert_v1beta1_File_To_v1alpha4_File(in *bootstrapv1.File, out *File, s apiconversion.Scope) error {
-	// File.Append does not exist in kubeadm v1alpha4 API.
+	// File.Append and File.Template do not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_File_To_v1alpha4_File(in, out, s)
 }
 
+func Convert_v1beta1_FileSource_To_v1alpha4_FileSource(in *bootstrapv1.FileSource, out *FileSource, s apiconversion.Scope) error {
+	// FileSource.ConfigMap does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_FileSource_To_v1alpha4_FileSource(in, out, s)
+}
+
 func Convert_v1beta1_User_To_v1alpha4_User(in *bootstrapv1.User, out *User, s apiconversion.Scope) error {
 	// User.PasswdFrom does not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_User_To_v1alpha4_User(in, out, s)
@@ -39,6 +39,11 @@ const (
 	// an error while generating a data secret; those kind of errors are usually due to misconfigurations
 	// and user intervention is required to get them fixed.
 	DataSecretGenerationFailedReason = "DataSecretGenerationFailed"
+
+	// DataSecretSizeLimitExceededReason (Severity=Warning) documents a KubeadmConfig controller detecting
+	// that the generated bootstrap data exceeds the configured size limit; user intervention is required
+	// to reduce the size of the bootstrap data, e.g. by enabling compression or removing files.
+	DataSecretSizeLimitExceededReason = "DataSecretSizeLimitExceeded"
 )
 
 const (
@@ -17,13 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // Format specifies the output format of the bootstrap data
-// +kubebuilder:validation:Enum=cloud-config;ignition
+// +kubebuilder:validation:Enum=cloud-config;ignition;windows;external
 type Format string
 
 const (
@@ -32,8 +34,21 @@ const (
 
 	// Ignition make the bootstrap data to be of Ignition format.
 	Ignition Format = "ignition"
+
+	// Windows make the bootstrap data to be a PowerShell script for Windows worker nodes,
+	// compatible with cloudbase-init.
+	Windows Format = "windows"
+
+	// External make the bootstrap data to be generated by a Runtime Extension implementing
+	// the GenerateBootstrapData hook.
+	External Format = "external"
 )
 
+// DataSecretGenerationAnnotation is set on the bootstrap data secret and is incremented every time
+// the bootstrap data is updated after its creation, e.g. when the bootstrap token of a MachinePool
+// is rotated. Infrastructure providers can use it to detect that new bootstrap data is available.
+const DataSecretGenerationAnnotation = "bootstrap.cluster.x-k8s.io/data-secret-generation"
+
 // KubeadmConfigSpec defines the desired state of KubeadmConfig.
 // Either ClusterConfiguration and InitConfiguration should be defined or the JoinConfiguration should be defined.
 type KubeadmConfigSpec struct {
@@ -103,15 +118,194 @@ type KubeadmConfigSpec struct {
 	// Ignition contains Ignition specific configuration.
 	// +optional
 	Ignition *IgnitionSpec `json:"ignition,omitempty"`
+
+	// BootstrapData contains options for the bootstrap data generated by the controller.
+	// +optional
+	BootstrapData *BootstrapDataSpec `json:"bootstrapData,omitempty"`
+
+	// Containerd contains options for configuring containerd.
+	// +optional
+	Containerd *ContainerdConfig `json:"containerd,omitempty"`
+
+	// SystemdUnits specifies extra systemd units, or drop-ins for existing units, to set up on the machine.
+	// +optional
+	SystemdUnits []SystemdUnit `json:"systemdUnits,omitempty"`
+
+	// TokenRotation contains options for keeping the bootstrap token embedded in the bootstrap data valid.
+	// It only applies to KubeadmConfigs owned by a MachinePool.
+	// +optional
+	TokenRotation *TokenRotationSpec `json:"tokenRotation,omitempty"`
+
+	// External contains configuration for the external format.
+	// +optional
+	External *ExternalSpec `json:"external,omitempty"`
 }
 
+// ExternalSpec contains configuration for generating the bootstrap data with a Runtime Extension.
+type ExternalSpec struct {
+	// GenerateExtension is the name of the extension handler implementing the GenerateBootstrapData hook,
+	// which generates the bootstrap data, e.g. "generate-bootstrap-data.my-extension".
+	// +kubebuilder:validation:MinLength=1
+	GenerateExtension string `json:"generateExtension"`
+}
+
+// TokenRotationSpec contains options for keeping the bootstrap token embedded in the bootstrap data
+// of a MachinePool valid, so instances created by the infrastructure provider at any time can join the cluster.
+type TokenRotationSpec struct {
+	// Mode defines how the bootstrap token is kept valid.
+	// With Refresh, the expiration of the token is extended until the first node joins, and afterwards
+	// the token is rotated before it expires.
+	// With Rotate, the token is always rotated before it expires, and the bootstrap data secret is updated
+	// with the new token; the token thus stays valid even if the controller is not able to refresh it.
+	// Defaults to Refresh.
+	// +optional
+	Mode TokenRotationMode `json:"mode,omitempty"`
+}
+
+// TokenRotationMode defines how the bootstrap token of a MachinePool is kept valid.
+// +kubebuilder:validation:Enum=Refresh;Rotate
+type TokenRotationMode string
+
+const (
+	// RefreshTokenRotationMode extends the expiration of the bootstrap token until the first node joins,
+	// and rotates it afterwards.
+	RefreshTokenRotationMode TokenRotationMode = "Refresh"
+
+	// RotateTokenRotationMode always rotates the bootstrap token before it expires.
+	RotateTokenRotationMode TokenRotationMode = "Rotate"
+)
+
+// ContainerdConfig contains options for configuring containerd.
+//
+// The configuration is written to /etc/containerd/conf.d/cluster-api.toml, which must be imported
+// by the main containerd configuration. Given that containerd replaces entire plugin sections when
+// importing configuration files, the generated file contains the complete configuration of the
+// CRI plugin, using runc with the systemd cgroup driver.
+type ContainerdConfig struct {
+	// SandboxImage is the image used for the pod sandbox (pause) containers.
+	// +optional
+	SandboxImage string `json:"sandboxImage,omitempty"`
+
+	// RegistryMirrors specifies mirrors for container registries.
+	// +optional
+	RegistryMirrors []ContainerdRegistryMirror `json:"registryMirrors,omitempty"`
+
+	// RegistryAuths specifies the credentials to use when pulling from container registries.
+	// +optional
+	RegistryAuths []ContainerdRegistryAuth `json:"registryAuths,omitempty"`
+}
+
+// ContainerdRegistryMirror defines mirrors for a container registry.
+type ContainerdRegistryMirror struct {
+	// Registry is the host name of the registry to mirror, e.g. "docker.io".
+	Registry string `json:"registry"`
+
+	// Endpoints are the mirror endpoints, tried in order, e.g. "https://mirror.example.com".
+	Endpoints []string `json:"endpoints"`
+}
+
+// ContainerdRegistryAuth defines the credentials for a container registry.
+type ContainerdRegistryAuth struct {
+	// Registry is the host name of the registry, e.g. "registry.example.com".
+	Registry string `json:"registry"`
+
+	// SecretName is the name of a secret in the KubeadmConfig's namespace of type kubernetes.io/basic-auth
+	// holding the username and password used to authenticate against the registry.
+	SecretName string `json:"secretName"`
+}
+
+// SystemdUnit defines a systemd unit, or drop-ins for an existing unit.
+type SystemdUnit struct {
+	// Name is the name of the unit, including its type suffix, e.g. "my-service.service".
+	Name string `json:"name"`
+
+	// Enabled specifies whether the unit should be enabled or disabled; the unit is left
+	// as it is if not specified.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Content is the content of the unit. If empty, the unit is expected to already exist
+	// on the machine, e.g. to add drop-ins or enable it.
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// DropIns specifies drop-ins for the unit.
+	// +optional
+	DropIns []SystemdUnitDropIn `json:"dropIns,omitempty"`
+}
+
+// SystemdUnitDropIn defines a drop-in for a systemd unit.
+type SystemdUnitDropIn struct {
+	// Name is the name of the drop-in, e.g. "10-proxy.conf".
+	Name string `json:"name"`
+
+	// Content is the content of the drop-in.
+	Content string `json:"content"`
+}
+
+// BootstrapDataSpec contains options for the bootstrap data generated by the controller.
+type BootstrapDataSpec struct {
+	// Compression defines how the bootstrap data should be compressed.
+	// When set to gzip, the cloud-config is gzip-compressed and wrapped into a MIME multi-part
+	// document that cloud-init extracts natively, while Ignition configurations use
+	// Ignition's native compression for the contents of the files.
+	// +optional
+	Compression BootstrapDataCompression `json:"compression,omitempty"`
+
+	// MaxSize is the maximum size, in bytes, of the generated bootstrap data after compression.
+	// When the bootstrap data exceeds this size the data secret is not created, and the
+	// DataSecretAvailable condition reports the error.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSize *int32 `json:"maxSize,omitempty"`
+}
+
+// BootstrapDataCompression defines how the bootstrap data should be compressed.
+// +kubebuilder:validation:Enum=gzip
+type BootstrapDataCompression string
+
+const (
+	// GzipBootstrapDataCompression compresses the bootstrap data using gzip.
+	GzipBootstrapDataCompression BootstrapDataCompression = "gzip"
+)
+
 // IgnitionSpec contains Ignition specific configuration.
 type IgnitionSpec struct {
+	// Version is the Ignition spec version of the generated configuration. Version 2.3 configurations
+	// are generated using Container Linux Config, while version 3.x configurations are generated natively,
+	// e.g. for Fedora CoreOS, RHCOS or recent Flatcar releases. Defaults to 2.3.
+	// +optional
+	Version IgnitionVersion `json:"version,omitempty"`
+
 	// ContainerLinuxConfig contains CLC specific configuration.
+	// ContainerLinuxConfig is only supported with Ignition version 2.3.
 	// +optional
 	ContainerLinuxConfig *ContainerLinuxConfig `json:"containerLinuxConfig,omitempty"`
 }
 
+// IgnitionVersion defines the Ignition spec version of the generated configuration.
+// +kubebuilder:validation:Enum="2.3";"3.1";"3.2";"3.3"
+type IgnitionVersion string
+
+const (
+	// IgnitionVersion2_3 generates Ignition spec 2.3 configurations using Container Linux Config.
+	IgnitionVersion2_3 IgnitionVersion = "2.3"
+
+	// IgnitionVersion3_1 generates Ignition spec 3.1 configurations.
+	IgnitionVersion3_1 IgnitionVersion = "3.1"
+
+	// IgnitionVersion3_2 generates Ignition spec 3.2 configurations.
+	IgnitionVersion3_2 IgnitionVersion = "3.2"
+
+	// IgnitionVersion3_3 generates Ignition spec 3.3 configurations.
+	IgnitionVersion3_3 IgnitionVersion = "3.3"
+)
+
+// IsV3 returns true if the version is an Ignition spec 3.x version.
+func (v IgnitionVersion) IsV3() bool {
+	return strings.HasPrefix(string(v), "3.")
+}
+
 // ContainerLinuxConfig contains CLC-specific configuration.
 //
 // We use a structured type here to allow adding additional fields, for example 'version'.
@@ -153,6 +347,11 @@ type KubeadmConfigStatus struct {
 	// Conditions defines current service state of the KubeadmConfig.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// LastTokenRotationTime is the time the bootstrap token embedded in the bootstrap data was last rotated.
+	// It is only set for KubeadmConfigs owned by a MachinePool.
+	// +optional
+	LastTokenRotationTime *metav1.Time `json:"lastTokenRotationTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -235,6 +434,12 @@ type File struct {
 	// ContentFrom is a referenced source of content to populate the file.
 	// +optional
 	ContentFrom *FileSource `json:"contentFrom,omitempty"`
+
+	// Template specifies whether the content of the file should be rendered as a Go template.
+	// The following variables are available: .ClusterName, .ClusterNamespace, .MachineName,
+	// .ControlPlaneEndpoint.Host, .ControlPlaneEndpoint.Port and .KubernetesVersion.
+	// +optional
+	Template bool `json:"template,omitempty"`
 }
 
 // FileSource is a union of all possible external source types for file data.
@@ -242,7 +447,12 @@ type File struct {
 // sources of data for target systems should add them here.
 type FileSource struct {
 	// Secret represents a secret that should populate this file.
-	Secret SecretFileSource `json:"secret"`
+	// +optional
+	Secret SecretFileSource `json:"secret,omitempty"`
+
+	// ConfigMap represents a config map that should populate this file.
+	// +optional
+	ConfigMap *ConfigMapFileSource `json:"configMap,omitempty"`
 }
 
 // SecretFileSource adapts a Secret into a FileSource.
@@ -257,6 +467,18 @@ type SecretFileSource struct {
 	Key string `json:"key"`
 }
 
+// ConfigMapFileSource adapts a ConfigMap into a FileSource.
+//
+// The contents of the target ConfigMap's Data or BinaryData field will be presented
+// as files using the keys in the Data or BinaryData field as the file names.
+type ConfigMapFileSource struct {
+	// Name of the config map in the KubeadmBootstrapConfig's namespace to use.
+	Name string `json:"name"`
+
+	// Key is the key in the config map's data or binary data map for this value.
+	Key string `json:"key"`
+}
+
 // PasswdSource is a union of all possible external source types for passwd data.
 // Only one field may be populated in any given instance. Developers adding new
 // sources of data for target systems should add them here.
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,13 +30,31 @@ import (
 )
 
 var (
+	cannotUseWithExternal                            = fmt.Sprintf("not supported when spec.format is set to %q", External)
 	cannotUseWithIgnition                            = fmt.Sprintf("not supported when spec.format is set to %q", Ignition)
+	cannotUseWithWindows                             = fmt.Sprintf("not supported when spec.format is set to %q", Windows)
+	conflictingContentFromMsg                        = "only one of secret or configMap may be specified for a single file source"
 	conflictingFileSourceMsg                         = "only one of content or contentFrom may be specified for a single file"
 	conflictingUserSourceMsg                         = "only one of passwd or passwdFrom may be specified for a single user"
+	containerLinuxConfigWithIgnitionV3Msg            = fmt.Sprintf("only supported when spec.ignition.version is %q", IgnitionVersion2_3)
+	duplicateDropInNameMsg                           = "name must be unique among all drop-ins of a systemd unit"
+	duplicateRegistryMsg                             = "registry must be unique"
+	duplicateSystemdUnitNameMsg                      = "name must be unique among all systemd units"
+	invalidSystemdFileNameMsg                        = "must be a file name without path separators"
+	kubeadmBootstrapFormatExternalFeatureDisabledMsg = "can be set to external only if the RuntimeSDK feature gate is enabled"
 	kubeadmBootstrapFormatIgnitionFeatureDisabledMsg = "can be set only if the KubeadmBootstrapFormatIgnition feature gate is enabled"
+	kubeadmBootstrapFormatWindowsFeatureDisabledMsg  = "can be set to windows only if the KubeadmBootstrapFormatWindows feature gate is enabled"
+	missingConfigMapNameMsg                          = "config map file source must specify non-empty config map name"
+	missingConfigMapKeyMsg                           = "config map file source must specify non-empty config map key"
+	missingDropInNameMsg                             = "drop-in must specify non-empty name"
+	missingRegistryEndpointsMsg                      = "registry mirror must specify at least one endpoint"
+	missingRegistryMsg                               = "registry must be non-empty"
+	missingRegistrySecretNameMsg                     = "registry auth must specify non-empty secret name"
 	missingSecretNameMsg                             = "secret file source must specify non-empty secret name"
 	missingSecretKeyMsg                              = "secret file source must specify non-empty secret key"
+	missingSystemdUnitNameMsg                        = "systemd unit must specify non-empty name"
 	pathConflictMsg                                  = "path property must be unique among all files"
+	templateWithEncodingMsg                          = "template is not supported for encoded content"
 )
 
 func (c *KubeadmConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -95,7 +114,11 @@ func (c *KubeadmConfigSpec) Validate(pathPrefix *field.Path) field.ErrorList {
 
 	allErrs = append(allErrs, c.validateFiles(pathPrefix)...)
 	allErrs = append(allErrs, c.validateUsers(pathPrefix)...)
+	allErrs = append(allErrs, c.validateContainerd(pathPrefix)...)
+	allErrs = append(allErrs, c.validateSystemdUnits(pathPrefix)...)
 	allErrs = append(allErrs, c.validateIgnition(pathPrefix)...)
+	allErrs = append(allErrs, c.validateWindows(pathPrefix)...)
+	allErrs = append(allErrs, c.validateExternal(pathPrefix)...)
 
 	return allErrs
 }
@@ -117,28 +140,18 @@ func (c *KubeadmConfigSpec) validateFiles(pathPrefix *field.Path) field.ErrorLis
 				),
 			)
 		}
-		// n.b.: if we ever add types besides Secret as a ContentFrom
-		// Source, we must add webhook validation here for one of the
-		// sources being non-nil.
 		if file.ContentFrom != nil {
-			if file.ContentFrom.Secret.Name == "" {
-				allErrs = append(
-					allErrs,
-					field.Required(
-						pathPrefix.Child("files").Index(i).Child("contentFrom", "secret", "name"),
-						missingSecretNameMsg,
-					),
-				)
-			}
-			if file.ContentFrom.Secret.Key == "" {
-				allErrs = append(
-					allErrs,
-					field.Required(
-						pathPrefix.Child("files").Index(i).Child("contentFrom", "secret", "key"),
-						missingSecretKeyMsg,
-					),
-				)
-			}
+			allErrs = append(allErrs, file.ContentFrom.validate(pathPrefix.Child("files").Index(i).Child("contentFrom"))...)
+		}
+		if file.Template && file.Encoding != "" {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("files").Index(i).Child("template"),
+					file.Template,
+					templateWithEncodingMsg,
+				),
+			)
 		}
 		_, conflict := knownPaths[file.Path]
 		if conflict {
@@ -157,6 +170,65 @@ func (c *KubeadmConfigSpec) validateFiles(pathPrefix *field.Path) field.ErrorLis
 	return allErrs
 }
 
+// validate validates a FileSource; Secret is used as a file source unless ConfigMap is set, so
+// ConfigMap can only be used if Secret is empty.
+func (s *FileSource) validate(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.ConfigMap != nil && s.Secret != (SecretFileSource{}) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix,
+				s,
+				conflictingContentFromMsg,
+			),
+		)
+	}
+	if s.ConfigMap == nil {
+		if s.Secret.Name == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("secret", "name"),
+					missingSecretNameMsg,
+				),
+			)
+		}
+		if s.Secret.Key == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("secret", "key"),
+					missingSecretKeyMsg,
+				),
+			)
+		}
+	}
+	if s.ConfigMap != nil {
+		if s.ConfigMap.Name == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("configMap", "name"),
+					missingConfigMapNameMsg,
+				),
+			)
+		}
+		if s.ConfigMap.Key == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("configMap", "key"),
+					missingConfigMapKeyMsg,
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
 func (c *KubeadmConfigSpec) validateUsers(pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -200,6 +272,99 @@ func (c *KubeadmConfigSpec) validateUsers(pathPrefix *field.Path) field.ErrorLis
 	return allErrs
 }
 
+func (c *KubeadmConfigSpec) validateContainerd(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.Containerd == nil {
+		return allErrs
+	}
+
+	knownMirrors := map[string]struct{}{}
+	for i := range c.Containerd.RegistryMirrors {
+		mirror := c.Containerd.RegistryMirrors[i]
+		mirrorPath := pathPrefix.Child("containerd", "registryMirrors").Index(i)
+		allErrs = append(allErrs, validateRegistry(mirrorPath.Child("registry"), mirror.Registry, knownMirrors)...)
+		if len(mirror.Endpoints) == 0 {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					mirrorPath.Child("endpoints"),
+					missingRegistryEndpointsMsg,
+				),
+			)
+		}
+	}
+
+	knownAuths := map[string]struct{}{}
+	for i := range c.Containerd.RegistryAuths {
+		auth := c.Containerd.RegistryAuths[i]
+		authPath := pathPrefix.Child("containerd", "registryAuths").Index(i)
+		allErrs = append(allErrs, validateRegistry(authPath.Child("registry"), auth.Registry, knownAuths)...)
+		if auth.SecretName == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					authPath.Child("secretName"),
+					missingRegistrySecretNameMsg,
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+func validateRegistry(fldPath *field.Path, registry string, known map[string]struct{}) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if registry == "" {
+		return append(allErrs, field.Required(fldPath, missingRegistryMsg))
+	}
+	if _, conflict := known[registry]; conflict {
+		allErrs = append(allErrs, field.Invalid(fldPath, registry, duplicateRegistryMsg))
+	}
+	known[registry] = struct{}{}
+
+	return allErrs
+}
+
+func (c *KubeadmConfigSpec) validateSystemdUnits(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	knownUnits := map[string]struct{}{}
+	for i := range c.SystemdUnits {
+		unit := c.SystemdUnits[i]
+		unitPath := pathPrefix.Child("systemdUnits").Index(i)
+		allErrs = append(allErrs, validateSystemdFileName(unitPath.Child("name"), unit.Name, knownUnits, missingSystemdUnitNameMsg, duplicateSystemdUnitNameMsg)...)
+
+		knownDropIns := map[string]struct{}{}
+		for j := range unit.DropIns {
+			dropIn := unit.DropIns[j]
+			allErrs = append(allErrs, validateSystemdFileName(unitPath.Child("dropIns").Index(j).Child("name"), dropIn.Name, knownDropIns, missingDropInNameMsg, duplicateDropInNameMsg)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateSystemdFileName validates the name of a systemd unit or drop-in, which is used as a file name on the machine.
+func validateSystemdFileName(fldPath *field.Path, name string, known map[string]struct{}, missingMsg, duplicateMsg string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if name == "" {
+		return append(allErrs, field.Required(fldPath, missingMsg))
+	}
+	if strings.Contains(name, "/") || name == "." || name == ".." {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, invalidSystemdFileNameMsg))
+	}
+	if _, conflict := known[name]; conflict {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, duplicateMsg))
+	}
+	known[name] = struct{}{}
+
+	return allErrs
+}
+
 func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -232,6 +397,16 @@ func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.Error
 		return allErrs
 	}
 
+	if c.Ignition != nil && c.Ignition.Version.IsV3() && c.Ignition.ContainerLinuxConfig != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("ignition", "containerLinuxConfig"),
+				containerLinuxConfigWithIgnitionV3Msg,
+			),
+		)
+	}
+
 	for i, user := range c.Users {
 		if user.Inactive != nil && *user.Inactive {
 			allErrs = append(
@@ -311,3 +486,119 @@ func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.Error
 
 	return allErrs
 }
+
+func (c *KubeadmConfigSpec) validateWindows(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.Format != Windows {
+		return allErrs
+	}
+
+	if !feature.Gates.Enabled(feature.KubeadmBootstrapFormatWindows) {
+		allErrs = append(allErrs, field.Forbidden(
+			pathPrefix.Child("format"), kubeadmBootstrapFormatWindowsFeatureDisabledMsg))
+
+		return allErrs
+	}
+
+	// Windows machines can only join the cluster as worker nodes, and the options
+	// configuring Linux specific services are not supported.
+	unsupported := []struct {
+		path *field.Path
+		set  bool
+	}{
+		{pathPrefix.Child("initConfiguration"), c.InitConfiguration != nil},
+		{pathPrefix.Child("joinConfiguration", "controlPlane"), c.JoinConfiguration != nil && c.JoinConfiguration.ControlPlane != nil},
+		{pathPrefix.Child("diskSetup"), c.DiskSetup != nil},
+		{pathPrefix.Child("mounts"), len(c.Mounts) > 0},
+		{pathPrefix.Child("ntp"), c.NTP != nil},
+		{pathPrefix.Child("containerd"), c.Containerd != nil},
+		{pathPrefix.Child("systemdUnits"), len(c.SystemdUnits) > 0},
+		{pathPrefix.Child("useExperimentalRetryJoin"), c.UseExperimentalRetryJoin},
+	}
+	for _, u := range unsupported {
+		if u.set {
+			allErrs = append(allErrs, field.Forbidden(u.path, cannotUseWithWindows))
+		}
+	}
+
+	// Local users on Windows are created without password, and can only log in using SSH authorized keys.
+	for i, user := range c.Users {
+		unsupported := []struct {
+			name string
+			set  bool
+		}{
+			{"passwd", user.Passwd != nil},
+			{"passwdFrom", user.PasswdFrom != nil},
+			{"sudo", user.Sudo != nil},
+			{"shell", user.Shell != nil},
+			{"homeDir", user.HomeDir != nil},
+			{"primaryGroup", user.PrimaryGroup != nil},
+		}
+		for _, u := range unsupported {
+			if u.set {
+				allErrs = append(allErrs, field.Forbidden(pathPrefix.Child("users").Index(i).Child(u.name), cannotUseWithWindows))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (c *KubeadmConfigSpec) validateExternal(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.Format != External {
+		if c.External != nil {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("format"),
+					c.Format,
+					fmt.Sprintf("must be set to %q if spec.external is set", External),
+				),
+			)
+		}
+
+		return allErrs
+	}
+
+	if !feature.Gates.Enabled(feature.RuntimeSDK) {
+		allErrs = append(allErrs, field.Forbidden(
+			pathPrefix.Child("format"), kubeadmBootstrapFormatExternalFeatureDisabledMsg))
+
+		return allErrs
+	}
+
+	if c.External == nil {
+		allErrs = append(
+			allErrs,
+			field.Required(
+				pathPrefix.Child("external"),
+				fmt.Sprintf("must be set if spec.format is set to %q", External),
+			),
+		)
+	}
+
+	// The Runtime Extension generating the bootstrap data only gets the kubeadm configuration,
+	// files, users and commands, and the bootstrap data it returns is stored as it is.
+	unsupported := []struct {
+		path *field.Path
+		set  bool
+	}{
+		{pathPrefix.Child("diskSetup"), c.DiskSetup != nil},
+		{pathPrefix.Child("mounts"), len(c.Mounts) > 0},
+		{pathPrefix.Child("ntp"), c.NTP != nil},
+		{pathPrefix.Child("containerd"), c.Containerd != nil},
+		{pathPrefix.Child("systemdUnits"), len(c.SystemdUnits) > 0},
+		{pathPrefix.Child("useExperimentalRetryJoin"), c.UseExperimentalRetryJoin},
+		{pathPrefix.Child("bootstrapData", "compression"), c.BootstrapData != nil && c.BootstrapData.Compression != ""},
+	}
+	for _, u := range unsupported {
+		if u.set {
+			allErrs = append(allErrs, field.Forbidden(u.path, cannotUseWithExternal))
+		}
+	}
+
+	return allErrs
+}
@@ -63,6 +63,8 @@ func TestKubeadmConfigValidate(t *testing.T) {
 	cases := map[string]struct {
 		in                    *KubeadmConfig
 		enableIgnitionFeature bool
+		enableWindowsFeature  bool
+		enableRuntimeSDK      bool
 		expectErr             bool
 	}{
 		"valid content": {
@@ -100,6 +102,121 @@ func TestKubeadmConfigValidate(t *testing.T) {
 				},
 			},
 		},
+		"valid contentFrom config map": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Files: []File{
+						{
+							ContentFrom: &FileSource{
+								ConfigMap: &ConfigMapFileSource{
+									Name: "foo",
+									Key:  "bar",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"valid template": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Files: []File{
+						{
+							Content:  "{{ .ClusterName }}",
+							Template: true,
+						},
+					},
+				},
+			},
+		},
+		"invalid template with encoding": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Files: []File{
+						{
+							Content:  "Zm9v",
+							Encoding: Base64,
+							Template: true,
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid contentFrom without sources": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Files: []File{
+						{
+							ContentFrom: &FileSource{},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid contentFrom with both secret and config map": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Files: []File{
+						{
+							ContentFrom: &FileSource{
+								Secret: SecretFileSource{
+									Name: "foo",
+									Key:  "bar",
+								},
+								ConfigMap: &ConfigMapFileSource{
+									Name: "foo",
+									Key:  "bar",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid contentFrom config map without key": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Files: []File{
+						{
+							ContentFrom: &FileSource{
+								ConfigMap: &ConfigMapFileSource{
+									Name: "foo",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
 		"invalid content and contentFrom": {
 			in: &KubeadmConfig{
 				ObjectMeta: metav1.ObjectMeta{
@@ -272,6 +389,134 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		"valid containerd and systemd units": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Containerd: &ContainerdConfig{
+						SandboxImage: "registry.k8s.io/pause:3.6",
+						RegistryMirrors: []ContainerdRegistryMirror{
+							{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}},
+						},
+						RegistryAuths: []ContainerdRegistryAuth{
+							{Registry: "docker.io", SecretName: "registry"},
+						},
+					},
+					SystemdUnits: []SystemdUnit{
+						{
+							Name:    "my-agent.service",
+							Content: "[Service]",
+							DropIns: []SystemdUnitDropIn{{Name: "10-env.conf", Content: "[Service]"}},
+						},
+						{
+							Name:    "containerd.service",
+							DropIns: []SystemdUnitDropIn{{Name: "10-env.conf", Content: "[Service]"}},
+						},
+					},
+				},
+			},
+		},
+		"invalid registry mirror without endpoints": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Containerd: &ContainerdConfig{
+						RegistryMirrors: []ContainerdRegistryMirror{
+							{Registry: "docker.io"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid duplicate registry mirrors": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Containerd: &ContainerdConfig{
+						RegistryMirrors: []ContainerdRegistryMirror{
+							{Registry: "docker.io", Endpoints: []string{"https://mirror-a.example.com"}},
+							{Registry: "docker.io", Endpoints: []string{"https://mirror-b.example.com"}},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid registry auth without secret name": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					Containerd: &ContainerdConfig{
+						RegistryAuths: []ContainerdRegistryAuth{
+							{Registry: "docker.io"},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid duplicate systemd unit names": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					SystemdUnits: []SystemdUnit{
+						{Name: "my-agent.service", Content: "[Service]"},
+						{Name: "my-agent.service", Enabled: pointer.BoolPtr(true)},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid systemd unit name with path separator": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					SystemdUnits: []SystemdUnit{
+						{Name: "../my-agent.service", Content: "[Service]"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"invalid duplicate drop-in names": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: KubeadmConfigSpec{
+					SystemdUnits: []SystemdUnit{
+						{
+							Name: "containerd.service",
+							DropIns: []SystemdUnitDropIn{
+								{Name: "10-env.conf", Content: "[Service]"},
+								{Name: "10-env.conf", Content: "[Service]"},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
 		"Ignition field is set, format is not Ignition": {
 			enableIgnitionFeature: true,
 			in: &KubeadmConfig{
@@ -285,6 +530,38 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		"Ignition version 3 with containerLinuxConfig": {
+			enableIgnitionFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Ignition,
+					Ignition: &IgnitionSpec{
+						Version:              IgnitionVersion3_3,
+						ContainerLinuxConfig: &ContainerLinuxConfig{},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"Ignition version 3 without containerLinuxConfig": {
+			enableIgnitionFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Ignition,
+					Ignition: &IgnitionSpec{
+						Version: IgnitionVersion3_3,
+					},
+				},
+			},
+		},
 		"Ignition field is not set, format is Ignition": {
 			enableIgnitionFeature: true,
 			in: &KubeadmConfig{
@@ -452,6 +729,206 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		"windows format specified with feature disabled": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Windows,
+				},
+			},
+			expectErr: true,
+		},
+		"valid windows configuration": {
+			enableWindowsFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Windows,
+					JoinConfiguration: &JoinConfiguration{
+						NodeRegistration: NodeRegistrationOptions{
+							KubeletExtraArgs: map[string]string{"node-labels": "os=windows"},
+						},
+					},
+					Files: []File{
+						{
+							Path:     `C:\k\config.txt`,
+							Content:  "Zm9v",
+							Encoding: Base64,
+						},
+					},
+					Users: []User{
+						{
+							Name:              "capi",
+							Groups:            pointer.StringPtr("Administrators"),
+							SSHAuthorizedKeys: []string{"ssh-rsa foo"},
+						},
+					},
+				},
+			},
+		},
+		"windows format with initConfiguration": {
+			enableWindowsFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format:            Windows,
+					InitConfiguration: &InitConfiguration{},
+				},
+			},
+			expectErr: true,
+		},
+		"windows format with control plane join configuration": {
+			enableWindowsFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Windows,
+					JoinConfiguration: &JoinConfiguration{
+						ControlPlane: &JoinControlPlane{},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"windows format with ntp": {
+			enableWindowsFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Windows,
+					NTP:    &NTP{Enabled: pointer.BoolPtr(true)},
+				},
+			},
+			expectErr: true,
+		},
+		"windows format with user password": {
+			enableWindowsFeature: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: Windows,
+					Users: []User{
+						{
+							Name:   "capi",
+							Passwd: pointer.StringPtr("password"),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"external format with the RuntimeSDK feature disabled": {
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: External,
+					External: &ExternalSpec{
+						GenerateExtension: "generate-bootstrap-data.my-extension",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"valid external configuration": {
+			enableRuntimeSDK: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: External,
+					External: &ExternalSpec{
+						GenerateExtension: "generate-bootstrap-data.my-extension",
+					},
+				},
+			},
+			expectErr: false,
+		},
+		"external format without external": {
+			enableRuntimeSDK: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: External,
+				},
+			},
+			expectErr: true,
+		},
+		"external set without external format": {
+			enableRuntimeSDK: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: CloudConfig,
+					External: &ExternalSpec{
+						GenerateExtension: "generate-bootstrap-data.my-extension",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"external format with ntp": {
+			enableRuntimeSDK: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: External,
+					External: &ExternalSpec{
+						GenerateExtension: "generate-bootstrap-data.my-extension",
+					},
+					NTP: &NTP{Enabled: pointer.BoolPtr(true)},
+				},
+			},
+			expectErr: true,
+		},
+		"external format with bootstrap data compression": {
+			enableRuntimeSDK: true,
+			in: &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: "default",
+				},
+				Spec: KubeadmConfigSpec{
+					Format: External,
+					External: &ExternalSpec{
+						GenerateExtension: "generate-bootstrap-data.my-extension",
+					},
+					BootstrapData: &BootstrapDataSpec{Compression: GzipBootstrapDataCompression},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for name, tt := range cases {
@@ -461,6 +938,16 @@ func TestKubeadmConfigValidate(t *testing.T) {
 				// Enabling the feature flag temporarily for this test.
 				defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatIgnition, true)()
 			}
+			if tt.enableWindowsFeature {
+				// NOTE: KubeadmBootstrapFormatWindows feature flag is disabled by default.
+				// Enabling the feature flag temporarily for this test.
+				defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatWindows, true)()
+			}
+			if tt.enableRuntimeSDK {
+				// NOTE: RuntimeSDK feature flag is disabled by default.
+				// Enabling the feature flag temporarily for this test.
+				defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+			}
 			g := NewWithT(t)
 			if tt.expectErr {
 				g.Expect(tt.in.ValidateCreate()).NotTo(Succeed())
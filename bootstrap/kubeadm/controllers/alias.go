@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	kubeadmbootstrapcontrollers "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/controllers"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
 )
 
 // Following types provides access to reconcilers implemented in internal/controllers, thus
@@ -44,6 +45,10 @@ type KubeadmConfigReconciler struct {
 
 	// TokenTTL is the amount of time a bootstrap token (and therefore a KubeadmConfig) will be valid.
 	TokenTTL time.Duration
+
+	// RuntimeClient is used to call the Runtime Extensions generating the bootstrap data
+	// of KubeadmConfigs using the external format.
+	RuntimeClient runtimeclient.Client
 }
 
 // SetupWithManager sets up the reconciler with the Manager.
@@ -52,5 +57,6 @@ func (r *KubeadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 		Client:           r.Client,
 		WatchFilterValue: r.WatchFilterValue,
 		TokenTTL:         r.TokenTTL,
+		RuntimeClient:    r.RuntimeClient,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -51,6 +51,8 @@ type BaseUserData struct {
 	NTP                  *bootstrapv1.NTP
 	DiskSetup            *bootstrapv1.DiskSetup
 	Mounts               []bootstrapv1.MountPoints
+	Containerd           *ContainerdInput
+	SystemdUnits         []bootstrapv1.SystemdUnit
 	ControlPlane         bool
 	UseExperimentalRetry bool
 	KubeadmCommand       string
@@ -61,6 +63,9 @@ type BaseUserData struct {
 func (input *BaseUserData) prepare() error {
 	input.Header = cloudConfigHeader
 	input.WriteFiles = append(input.WriteFiles, input.AdditionalFiles...)
+	if err := input.prepareContainerdAndSystemdUnits(); err != nil {
+		return err
+	}
 	input.KubeadmCommand = fmt.Sprintf(standardJoinCommand, input.KubeadmVerbosity)
 	if input.UseExperimentalRetry {
 		input.KubeadmCommand = retriableJoinScriptName
@@ -74,6 +79,16 @@ func (input *BaseUserData) prepare() error {
 	return nil
 }
 
+// prepareContainerdAndSystemdUnits adds the files and commands required to set up containerd and systemd units;
+// systemd units are set up first, so drop-ins for containerd are in place when it gets restarted.
+func (input *BaseUserData) prepareContainerdAndSystemdUnits() error {
+	if err := input.AddContainerdConfig(); err != nil {
+		return err
+	}
+	input.addSystemdUnits()
+	return nil
+}
+
 func generate(kind string, tpl string, data interface{}) ([]byte, error) {
 	tm := template.New(kind).Funcs(defaultTemplateFuncMap)
 	if _, err := tm.Parse(filesTemplate); err != nil {
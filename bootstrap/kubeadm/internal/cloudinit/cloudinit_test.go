@@ -278,3 +278,72 @@ func TestNewJoinControlPlaneExperimentalRetry(t *testing.T) {
 		g.Expect(out).To(ContainSubstring(f))
 	}
 }
+
+func TestNewNodeContainerdAndSystemdUnits(t *testing.T) {
+	g := NewWithT(t)
+
+	nodeInput := &NodeInput{
+		BaseUserData: BaseUserData{
+			Header:             "test",
+			PreKubeadmCommands: []string{"echo hello"},
+			Containerd: &ContainerdInput{
+				SandboxImage: "registry.k8s.io/pause:3.6",
+			},
+			SystemdUnits: []bootstrapv1.SystemdUnit{
+				{
+					Name:    "my-agent.service",
+					Enabled: pointer.Bool(true),
+					Content: "[Service]\nExecStart=/usr/bin/my-agent",
+				},
+				{
+					Name: "containerd.service",
+					DropIns: []bootstrapv1.SystemdUnitDropIn{
+						{Name: "10-limits.conf", Content: "[Service]\nLimitNOFILE=1048576"},
+					},
+				},
+				{
+					Name:    "unattended-upgrades.service",
+					Enabled: pointer.Bool(false),
+				},
+			},
+		},
+		JoinConfiguration: "my-join-config",
+	}
+
+	out, err := NewNode(nodeInput)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectedFiles := []string{
+		`-   path: /etc/containerd/conf.d/cluster-api.toml
+    owner: root:root
+    permissions: '0600'
+    content: |
+      version = 2`,
+		`-   path: /etc/systemd/system/my-agent.service
+    owner: root:root
+    permissions: '0644'
+    content: |
+      [Service]
+      ExecStart=/usr/bin/my-agent`,
+		`-   path: /etc/systemd/system/containerd.service.d/10-limits.conf
+    owner: root:root
+    permissions: '0644'
+    content: |
+      [Service]
+      LimitNOFILE=1048576`,
+	}
+	for _, f := range expectedFiles {
+		g.Expect(out).To(ContainSubstring(f))
+	}
+	g.Expect(out).NotTo(ContainSubstring("/etc/systemd/system/unattended-upgrades.service"))
+
+	g.Expect(out).To(ContainSubstring(`runcmd:
+  - "systemctl daemon-reload"
+  - "systemctl try-restart my-agent.service"
+  - "systemctl enable --now my-agent.service"
+  - "systemctl try-restart containerd.service"
+  - "systemctl disable --now unattended-upgrades.service"
+  - "systemctl restart containerd"
+  - "echo hello"
+  - kubeadm join`))
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// compressedBoundary is the boundary of the MIME multi-part document wrapping compressed user data.
+	// NOTE: the boundary can't clash with the content of the document, given that base64 encoded data
+	// never contains dashes.
+	compressedBoundary = "CLUSTER-API-BOOTSTRAP-DATA"
+
+	// base64LineLength is the maximum line length for base64 encoded data in MIME documents, as defined in RFC 2045.
+	base64LineLength = 76
+)
+
+// Compress gzip-compresses the given cloud-init user data and wraps it into a MIME multi-part document.
+// The document is plain text, so it can be safely passed through any user data channel, and cloud-init
+// extracts and processes the compressed part natively, including Jinja templating.
+func Compress(userData []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gzip writer")
+	}
+	if _, err := gz.Write(userData); err != nil {
+		return nil, errors.Wrap(err, "failed to compress user data")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to compress user data")
+	}
+
+	var out bytes.Buffer
+	out.WriteString("Content-Type: multipart/mixed; boundary=\"" + compressedBoundary + "\"\r\n")
+	out.WriteString("MIME-Version: 1.0\r\n\r\n")
+
+	mw := multipart.NewWriter(&out)
+	if err := mw.SetBoundary(compressedBoundary); err != nil {
+		return nil, errors.Wrap(err, "failed to set MIME boundary")
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", "application/gzip")
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", `attachment; filename="cloud-config.gz"`)
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create MIME part")
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(compressed.Bytes())
+	for len(encoded) > 0 {
+		n := base64LineLength
+		if len(encoded) < n {
+			n = len(encoded)
+		}
+		if _, err := part.Write([]byte(encoded[:n] + "\r\n")); err != nil {
+			return nil, errors.Wrap(err, "failed to write MIME part")
+		}
+		encoded = encoded[n:]
+	}
+	if err := mw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close MIME document")
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCompress(t *testing.T) {
+	g := NewWithT(t)
+
+	userData := []byte(cloudConfigHeader + strings.Repeat("runcmd:\n  - 'echo {{ ds.meta_data.local_hostname }}'\n", 200))
+
+	out, err := Compress(userData)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(len(out)).To(BeNumerically("<", len(userData)))
+
+	msg, err := mail.ReadMessage(bytes.NewReader(out))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(msg.Header.Get("MIME-Version")).To(Equal("1.0"))
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mediaType).To(Equal("multipart/mixed"))
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	part, err := mr.NextPart()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(part.Header.Get("Content-Type")).To(Equal("application/gzip"))
+	g.Expect(part.Header.Get("Content-Transfer-Encoding")).To(Equal("base64"))
+
+	encoded, err := io.ReadAll(part)
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, line := range strings.Split(strings.TrimSpace(string(encoded)), "\r\n") {
+		g.Expect(len(line)).To(BeNumerically("<=", base64LineLength))
+	}
+	gz, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(strings.ReplaceAll(string(encoded), "\r\n", ""))))
+	g.Expect(err).NotTo(HaveOccurred())
+	decompressed, err := io.ReadAll(gz)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(decompressed).To(Equal(userData))
+
+	_, err = mr.NextPart()
+	g.Expect(err).To(Equal(io.EOF))
+}
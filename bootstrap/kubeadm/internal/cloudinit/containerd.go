@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+const (
+	// ContainerdConfigPath is the path of the containerd configuration drop-in generated from the KubeadmConfig.
+	ContainerdConfigPath = "/etc/containerd/conf.d/cluster-api.toml"
+
+	containerdRestartCommand = "systemctl restart containerd"
+
+	containerdConfigTemplate = `version = 2
+
+[plugins."io.containerd.grpc.v1.cri"]
+{{- with .SandboxImage }}
+  sandbox_image = {{ Quote . }}
+{{- end }}
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "runc"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+  runtime_type = "io.containerd.runc.v2"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+  SystemdCgroup = true
+{{- range .RegistryMirrors }}
+
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors.{{ Quote .Registry }}]
+  endpoint = [{{ QuoteJoin .Endpoints }}]
+{{- end }}
+{{- range .RegistryCredentials }}
+
+[plugins."io.containerd.grpc.v1.cri".registry.configs.{{ Quote .Registry }}.auth]
+  username = {{ Quote .Username }}
+  password = {{ Quote .Password }}
+{{- end }}
+`
+)
+
+// ContainerdRegistryCredentials defines the credentials for a container registry.
+type ContainerdRegistryCredentials struct {
+	Registry string
+	Username string
+	Password string
+}
+
+// ContainerdInput defines the context to generate the containerd configuration.
+type ContainerdInput struct {
+	SandboxImage        string
+	RegistryMirrors     []bootstrapv1.ContainerdRegistryMirror
+	RegistryCredentials []ContainerdRegistryCredentials
+}
+
+// AddContainerdConfig adds the containerd configuration drop-in to the files to be written, and restarts
+// containerd before running kubeadm so the configuration is applied.
+func (input *BaseUserData) AddContainerdConfig() error {
+	if input.Containerd == nil {
+		return nil
+	}
+
+	tpl := template.Must(template.New("containerd").Funcs(template.FuncMap{
+		"Quote":     tomlQuote,
+		"QuoteJoin": tomlQuoteJoin,
+	}).Parse(containerdConfigTemplate))
+
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, input.Containerd); err != nil {
+		return errors.Wrap(err, "failed to generate containerd configuration")
+	}
+
+	input.WriteFiles = append(input.WriteFiles, bootstrapv1.File{
+		Path:        ContainerdConfigPath,
+		Owner:       "root:root",
+		Permissions: "0600",
+		Content:     out.String(),
+	})
+	input.PreKubeadmCommands = append([]string{containerdRestartCommand}, input.PreKubeadmCommands...)
+	return nil
+}
+
+// tomlQuote returns the string as a TOML basic string; JSON string escaping is a subset of TOML's.
+func tomlQuote(s string) string {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	// Encoding a string can't fail.
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func tomlQuoteJoin(s []string) string {
+	quoted := make([]string, 0, len(s))
+	for _, v := range s {
+		quoted = append(quoted, tomlQuote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
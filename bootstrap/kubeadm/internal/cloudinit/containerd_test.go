@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+func TestAddContainerdConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &BaseUserData{
+		PreKubeadmCommands: []string{"echo hello"},
+		Containerd: &ContainerdInput{
+			SandboxImage: "registry.k8s.io/pause:3.6",
+			RegistryMirrors: []bootstrapv1.ContainerdRegistryMirror{
+				{Registry: "docker.io", Endpoints: []string{"https://mirror-a.example.com", "https://mirror-b.example.com"}},
+			},
+			RegistryCredentials: []ContainerdRegistryCredentials{
+				{Registry: "registry.example.com", Username: "user", Password: `pa"ss\word`},
+			},
+		},
+	}
+
+	g.Expect(input.AddContainerdConfig()).To(Succeed())
+	g.Expect(input.PreKubeadmCommands).To(Equal([]string{"systemctl restart containerd", "echo hello"}))
+	g.Expect(input.WriteFiles).To(Equal([]bootstrapv1.File{
+		{
+			Path:        ContainerdConfigPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content: `version = 2
+
+[plugins."io.containerd.grpc.v1.cri"]
+  sandbox_image = "registry.k8s.io/pause:3.6"
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "runc"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+  runtime_type = "io.containerd.runc.v2"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+  SystemdCgroup = true
+
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+  endpoint = ["https://mirror-a.example.com", "https://mirror-b.example.com"]
+
+[plugins."io.containerd.grpc.v1.cri".registry.configs."registry.example.com".auth]
+  username = "user"
+  password = "pa\"ss\\word"
+`,
+		},
+	}))
+}
+
+func TestAddContainerdConfigWithoutConfiguration(t *testing.T) {
+	g := NewWithT(t)
+
+	input := &BaseUserData{PreKubeadmCommands: []string{"echo hello"}}
+
+	g.Expect(input.AddContainerdConfig()).To(Succeed())
+	g.Expect(input.PreKubeadmCommands).To(Equal([]string{"echo hello"}))
+	g.Expect(input.WriteFiles).To(BeEmpty())
+}
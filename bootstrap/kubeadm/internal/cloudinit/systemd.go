@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"fmt"
+	"path"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+const systemdUnitsDir = "/etc/systemd/system"
+
+// addSystemdUnits adds the files for the systemd units and their drop-ins to the files to be written, and
+// reloads, restarts and enables/disables the units before running kubeadm.
+func (input *BaseUserData) addSystemdUnits() {
+	if len(input.SystemdUnits) == 0 {
+		return
+	}
+
+	commands := []string{"systemctl daemon-reload"}
+	for _, unit := range input.SystemdUnits {
+		if unit.Content != "" {
+			input.WriteFiles = append(input.WriteFiles, bootstrapv1.File{
+				Path:        path.Join(systemdUnitsDir, unit.Name),
+				Owner:       "root:root",
+				Permissions: "0644",
+				Content:     unit.Content,
+			})
+		}
+		for _, dropIn := range unit.DropIns {
+			input.WriteFiles = append(input.WriteFiles, bootstrapv1.File{
+				Path:        path.Join(systemdUnitsDir, unit.Name+".d", dropIn.Name),
+				Owner:       "root:root",
+				Permissions: "0644",
+				Content:     dropIn.Content,
+			})
+		}
+
+		if unit.Content != "" || len(unit.DropIns) > 0 {
+			commands = append(commands, fmt.Sprintf("systemctl try-restart %s", unit.Name))
+		}
+		if unit.Enabled != nil {
+			if *unit.Enabled {
+				commands = append(commands, fmt.Sprintf("systemctl enable --now %s", unit.Name))
+			} else {
+				commands = append(commands, fmt.Sprintf("systemctl disable --now %s", unit.Name))
+			}
+		}
+	}
+	input.PreKubeadmCommands = append(commands, input.PreKubeadmCommands...)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// newGenerateBootstrapDataRequest returns the request of the GenerateBootstrapData hook for the given input;
+// additionalFiles, e.g. the certificates of control plane machines, are added before the files of the KubeadmConfig.
+// NOTE: the kubeadm configurations must be set by the caller.
+func newGenerateBootstrapDataRequest(scope *Scope, input *cloudinit.BaseUserData, additionalFiles []bootstrapv1.File) *runtimehooksv1.GenerateBootstrapDataRequest {
+	request := &runtimehooksv1.GenerateBootstrapDataRequest{
+		Cluster:             *scope.Cluster,
+		KubeadmConfigName:   scope.Config.Name,
+		ControlPlane:        scope.ConfigOwner.IsControlPlaneMachine(),
+		KubernetesVersion:   scope.ConfigOwner.KubernetesVersion(),
+		Verbosity:           scope.Config.Spec.Verbosity,
+		PreKubeadmCommands:  input.PreKubeadmCommands,
+		PostKubeadmCommands: input.PostKubeadmCommands,
+	}
+
+	for _, files := range [][]bootstrapv1.File{additionalFiles, input.AdditionalFiles} {
+		for _, f := range files {
+			request.Files = append(request.Files, runtimehooksv1.BootstrapFile{
+				Path:        f.Path,
+				Owner:       f.Owner,
+				Permissions: f.Permissions,
+				Encoding:    string(f.Encoding),
+				Append:      f.Append,
+				Content:     f.Content,
+			})
+		}
+	}
+
+	for _, u := range input.Users {
+		request.Users = append(request.Users, runtimehooksv1.BootstrapUser{
+			Name:              u.Name,
+			Gecos:             u.Gecos,
+			Groups:            u.Groups,
+			HomeDir:           u.HomeDir,
+			Inactive:          u.Inactive,
+			Shell:             u.Shell,
+			Passwd:            u.Passwd,
+			PrimaryGroup:      u.PrimaryGroup,
+			LockPassword:      u.LockPassword,
+			Sudo:              u.Sudo,
+			SSHAuthorizedKeys: u.SSHAuthorizedKeys,
+		})
+	}
+
+	return request
+}
+
+// generateExternalBootstrapData calls the Runtime Extension defined in spec.external.generateExtension
+// to generate the bootstrap data of a KubeadmConfig using the external format.
+func (r *KubeadmConfigReconciler) generateExternalBootstrapData(ctx context.Context, scope *Scope, request *runtimehooksv1.GenerateBootstrapDataRequest) ([]byte, error) {
+	data, err := r.callGenerateBootstrapData(ctx, scope, request)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *KubeadmConfigReconciler) callGenerateBootstrapData(ctx context.Context, scope *Scope, request *runtimehooksv1.GenerateBootstrapDataRequest) ([]byte, error) {
+	if scope.Config.Spec.External == nil {
+		return nil, errors.Errorf("spec.external must be set when using format %q", bootstrapv1.External)
+	}
+	extension := scope.Config.Spec.External.GenerateExtension
+
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return nil, errors.Errorf("can not use external extension %q to generate bootstrap data if RuntimeSDK feature flag is disabled", extension)
+	}
+
+	response := &runtimehooksv1.GenerateBootstrapDataResponse{}
+	if err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.GenerateBootstrapData, scope.Config, extension, request, response); err != nil {
+		return nil, errors.Wrapf(err, "failed to generate bootstrap data using external extension %q", extension)
+	}
+	if len(response.Data) == 0 {
+		return nil, errors.Errorf("external extension %q returned empty bootstrap data", extension)
+	}
+	return response.Data, nil
+}
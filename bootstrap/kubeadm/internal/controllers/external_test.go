@@ -0,0 +1,227 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
+	fakeremote "sigs.k8s.io/cluster-api/controllers/remote/fake"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestKubeadmConfigReconciler_ExternalFormat(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	testcases := []struct {
+		name             string
+		isWorker         bool
+		enableRuntimeSDK bool
+		response         *runtimehooksv1.GenerateBootstrapDataResponse
+		expectErr        bool
+	}{
+		{
+			name:             "init control plane",
+			enableRuntimeSDK: true,
+			response: &runtimehooksv1.GenerateBootstrapDataResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Data:           []byte("external-bootstrap-data"),
+			},
+		},
+		{
+			name:             "worker join",
+			isWorker:         true,
+			enableRuntimeSDK: true,
+			response: &runtimehooksv1.GenerateBootstrapDataResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+				Data:           []byte("external-bootstrap-data"),
+			},
+		},
+		{
+			name:             "extension failure",
+			enableRuntimeSDK: true,
+			response: &runtimehooksv1.GenerateBootstrapDataResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure, Message: "something went wrong"},
+			},
+			expectErr: true,
+		},
+		{
+			name:             "empty bootstrap data",
+			enableRuntimeSDK: true,
+			response: &runtimehooksv1.GenerateBootstrapDataResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "RuntimeSDK feature disabled",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tc.enableRuntimeSDK {
+				defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+			}
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+			cluster.Status.InfrastructureReady = true
+			cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+
+			var machine *clusterv1.Machine
+			var config *bootstrapv1.KubeadmConfig
+			if tc.isWorker {
+				conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+				machine = newWorkerMachineForCluster(cluster)
+				config = newWorkerJoinKubeadmConfig(metav1.NamespaceDefault, "cfg")
+			} else {
+				machine = newControlPlaneMachine(cluster, "machine")
+				config = newControlPlaneInitKubeadmConfig(metav1.NamespaceDefault, "cfg")
+			}
+			addKubeadmConfigToMachine(config, machine)
+			config.Spec.Format = bootstrapv1.External
+			config.Spec.External = &bootstrapv1.ExternalSpec{
+				GenerateExtension: "generate-bootstrap-data.my-extension",
+			}
+
+			objects := []client.Object{
+				cluster,
+				machine,
+				config,
+			}
+			objects = append(objects, createSecrets(t, cluster, config)...)
+
+			myclient := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+			var runtimeClient runtimeclient.Client
+			if tc.response != nil {
+				runtimeClient = fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+						"generate-bootstrap-data.my-extension": tc.response,
+					}).
+					Build()
+			}
+
+			k := &KubeadmConfigReconciler{
+				Client:             myclient,
+				KubeadmInitLock:    &myInitLocker{},
+				RuntimeClient:      runtimeClient,
+				remoteClientGetter: fakeremote.NewClusterClient,
+			}
+			request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)}
+
+			_, err := k.Reconcile(ctx, request)
+			cfg, getErr := getKubeadmConfig(myclient, config.Name, metav1.NamespaceDefault)
+			g.Expect(getErr).NotTo(HaveOccurred())
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(cfg.Status.Ready).To(BeFalse())
+				g.Expect(conditions.GetReason(cfg, bootstrapv1.DataSecretAvailableCondition)).To(Equal(bootstrapv1.DataSecretGenerationFailedReason))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cfg.Status.Ready).To(BeTrue())
+			g.Expect(cfg.Status.DataSecretName).NotTo(BeNil())
+
+			// Verify the bootstrap data secret contains the data returned by the extension, as it is.
+			secret := &corev1.Secret{}
+			g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: *cfg.Status.DataSecretName}, secret)).To(Succeed())
+			g.Expect(string(secret.Data["format"])).To(Equal(string(bootstrapv1.External)))
+			g.Expect(string(secret.Data["value"])).To(Equal("external-bootstrap-data"))
+		})
+	}
+}
+
+func TestNewGenerateBootstrapDataRequest(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	machine := newControlPlaneMachine(cluster, "machine")
+	machine.Spec.Version = pointer.String("v1.24.1")
+	config := newControlPlaneInitKubeadmConfig(metav1.NamespaceDefault, "cfg")
+	config.Spec.Verbosity = pointer.Int32(4)
+
+	configOwner, err := bsutil.GetConfigOwner(ctx, fake.NewClientBuilder().WithObjects(machine).Build(), &metav1.ObjectMeta{
+		Namespace: metav1.NamespaceDefault,
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Machine",
+				Name:       machine.Name,
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	scope := &Scope{
+		Config:      config,
+		ConfigOwner: configOwner,
+		Cluster:     cluster,
+	}
+	input := &cloudinit.BaseUserData{
+		AdditionalFiles: []bootstrapv1.File{
+			{Path: "/etc/foo", Permissions: "0644", Encoding: bootstrapv1.Base64, Content: "Zm9v"},
+		},
+		Users: []bootstrapv1.User{
+			{Name: "capi", Passwd: pointer.String("hashed"), SSHAuthorizedKeys: []string{"ssh-rsa key"}},
+		},
+		PreKubeadmCommands:  []string{"echo pre"},
+		PostKubeadmCommands: []string{"echo post"},
+	}
+	certificates := []bootstrapv1.File{
+		{Path: "/etc/kubernetes/pki/ca.crt", Owner: "root:root", Permissions: "0640", Content: "ca"},
+	}
+
+	request := newGenerateBootstrapDataRequest(scope, input, certificates)
+	g.Expect(request.Cluster.Name).To(Equal(cluster.Name))
+	g.Expect(request.KubeadmConfigName).To(Equal("cfg"))
+	g.Expect(request.ControlPlane).To(BeTrue())
+	g.Expect(request.KubernetesVersion).To(Equal("v1.24.1"))
+	g.Expect(request.Verbosity).To(Equal(pointer.Int32(4)))
+	g.Expect(request.Files).To(Equal([]runtimehooksv1.BootstrapFile{
+		{Path: "/etc/kubernetes/pki/ca.crt", Owner: "root:root", Permissions: "0640", Content: "ca"},
+		{Path: "/etc/foo", Permissions: "0644", Encoding: "base64", Content: "Zm9v"},
+	}))
+	g.Expect(request.Users).To(Equal([]runtimehooksv1.BootstrapUser{
+		{Name: "capi", Passwd: pointer.String("hashed"), SSHAuthorizedKeys: []string{"ssh-rsa key"}},
+	}))
+	g.Expect(request.PreKubeadmCommands).To(Equal([]string{"echo pre"}))
+	g.Expect(request.PostKubeadmCommands).To(Equal([]string{"echo post"}))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+const (
+	// secretNameField is used by the KubeadmConfig controller for indexing KubeadmConfigs by the names of
+	// the Secrets used as file sources or for registry credentials.
+	secretNameField = "spec.secretNames"
+
+	// configMapNameField is used by the KubeadmConfig controller for indexing KubeadmConfigs by the names of
+	// the ConfigMaps used as file sources.
+	configMapNameField = "spec.configMapNames"
+)
+
+// indexByReferencedObjectNames adds the indexes by the names of the Secrets and the ConfigMaps referenced by
+// KubeadmConfigs to the managers cache.
+func indexByReferencedObjectNames(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetCache().IndexField(ctx, &bootstrapv1.KubeadmConfig{},
+		secretNameField,
+		kubeadmConfigBySecretName,
+	); err != nil {
+		return errors.Wrap(err, "error setting index field for Secret names")
+	}
+	if err := mgr.GetCache().IndexField(ctx, &bootstrapv1.KubeadmConfig{},
+		configMapNameField,
+		kubeadmConfigByConfigMapName,
+	); err != nil {
+		return errors.Wrap(err, "error setting index field for ConfigMap names")
+	}
+	return nil
+}
+
+func kubeadmConfigBySecretName(o client.Object) []string {
+	c, ok := o.(*bootstrapv1.KubeadmConfig)
+	if !ok {
+		panic(fmt.Sprintf("Expected KubeadmConfig but got a %T", o))
+	}
+
+	var names []string
+	for _, f := range c.Spec.Files {
+		if f.ContentFrom != nil && f.ContentFrom.ConfigMap == nil && f.ContentFrom.Secret.Name != "" {
+			names = append(names, f.ContentFrom.Secret.Name)
+		}
+	}
+	if c.Spec.Containerd != nil {
+		for _, auth := range c.Spec.Containerd.RegistryAuths {
+			names = append(names, auth.SecretName)
+		}
+	}
+	return names
+}
+
+func kubeadmConfigByConfigMapName(o client.Object) []string {
+	c, ok := o.(*bootstrapv1.KubeadmConfig)
+	if !ok {
+		panic(fmt.Sprintf("Expected KubeadmConfig but got a %T", o))
+	}
+
+	var names []string
+	for _, f := range c.Spec.Files {
+		if f.ContentFrom != nil && f.ContentFrom.ConfigMap != nil {
+			names = append(names, f.ContentFrom.ConfigMap.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+func TestKubeadmConfigBySecretName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   client.Object
+		expected []string
+	}{
+		{
+			name:     "when KubeadmConfig has no files",
+			object:   &bootstrapv1.KubeadmConfig{},
+			expected: nil,
+		},
+		{
+			name: "when KubeadmConfig has Secret file sources and registry credentials",
+			object: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Files: []bootstrapv1.File{
+						{Path: "/content", Content: "foo"},
+						{Path: "/secret", ContentFrom: &bootstrapv1.FileSource{Secret: bootstrapv1.SecretFileSource{Name: "secret1", Key: "key"}}},
+						{Path: "/config-map", ContentFrom: &bootstrapv1.FileSource{ConfigMap: &bootstrapv1.ConfigMapFileSource{Name: "config-map1", Key: "key"}}},
+					},
+					Containerd: &bootstrapv1.ContainerdConfig{
+						RegistryAuths: []bootstrapv1.ContainerdRegistryAuth{{Registry: "registry.example.com", SecretName: "secret2"}},
+					},
+				},
+			},
+			expected: []string{"secret1", "secret2"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := kubeadmConfigBySecretName(test.object)
+			g.Expect(got).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestKubeadmConfigByConfigMapName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		object   client.Object
+		expected []string
+	}{
+		{
+			name:     "when KubeadmConfig has no files",
+			object:   &bootstrapv1.KubeadmConfig{},
+			expected: nil,
+		},
+		{
+			name: "when KubeadmConfig has ConfigMap file sources",
+			object: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Files: []bootstrapv1.File{
+						{Path: "/secret", ContentFrom: &bootstrapv1.FileSource{Secret: bootstrapv1.SecretFileSource{Name: "secret1", Key: "key"}}},
+						{Path: "/config-map", ContentFrom: &bootstrapv1.FileSource{ConfigMap: &bootstrapv1.ConfigMapFileSource{Name: "config-map1", Key: "key"}}},
+					},
+				},
+			},
+			expected: []string{"config-map1"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := kubeadmConfigByConfigMapName(test.object)
+			g.Expect(got).To(Equal(test.expected))
+		})
+	}
+}
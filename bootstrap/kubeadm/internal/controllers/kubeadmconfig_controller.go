@@ -17,9 +17,11 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
+	"text/template"
 	"time"
 
 	"github.com/blang/semver"
@@ -33,6 +35,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -43,11 +46,13 @@ import (
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/locking"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/windows"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -75,6 +80,8 @@ type InitLocker interface {
 // +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs;kubeadmconfigs/status;kubeadmconfigs/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status;machines;machines/status;machinepools;machinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets;events;configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=runtime.cluster.x-k8s.io,resources=extensionconfigs,verbs=get;list;watch
 
 // KubeadmConfigReconciler reconciles a KubeadmConfig object.
 type KubeadmConfigReconciler struct {
@@ -87,6 +94,10 @@ type KubeadmConfigReconciler struct {
 	// TokenTTL is the amount of time a bootstrap token (and therefore a KubeadmConfig) will be valid.
 	TokenTTL time.Duration
 
+	// RuntimeClient is used to call the Runtime Extensions generating the bootstrap data
+	// of KubeadmConfigs using the external format.
+	RuntimeClient runtimeclient.Client
+
 	remoteClientGetter remote.ClusterClientGetter
 }
 
@@ -109,6 +120,9 @@ func (r *KubeadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 	if r.TokenTTL == 0 {
 		r.TokenTTL = DefaultTokenTTL
 	}
+	if err := indexByReferencedObjectNames(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
 
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&bootstrapv1.KubeadmConfig{}).
@@ -116,6 +130,16 @@ func (r *KubeadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 		Watches(
 			&source.Kind{Type: &clusterv1.Machine{}},
 			handler.EnqueueRequestsFromMapFunc(r.MachineToBootstrapMapFunc),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.SecretToKubeadmConfigs),
+			builder.OnlyMetadata,
+		).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.ConfigMapToKubeadmConfigs),
+			builder.OnlyMetadata,
 		).WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue))
 
 	if feature.Gates.Enabled(feature.MachinePool) {
@@ -246,6 +270,11 @@ func (r *KubeadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Status is ready means a config has been generated.
 	case config.Status.Ready:
 		if config.Spec.JoinConfiguration != nil && config.Spec.JoinConfiguration.Discovery.BootstrapToken != nil {
+			if configOwner.IsMachinePool() && tokenRotationMode(config) == bootstrapv1.RotateTokenRotationMode {
+				// If the token rotation mode is Rotate, the token of a MachinePool is rotated before it expires,
+				// no matter whether nodes have already joined, so the bootstrap data always contains a valid token.
+				return r.rotateMachinePoolBootstrapToken(ctx, config, cluster, scope)
+			}
 			if !configOwner.HasNodeRefs() {
 				// If the BootstrapToken has been generated for a join but the config owner has no nodeRefs,
 				// this indicates that the node has not yet joined and the token in the join config has not
@@ -331,13 +360,27 @@ func (r *KubeadmConfigReconciler) rotateMachinePoolBootstrapToken(ctx context.Co
 		log.Info("Altering JoinConfiguration.Discovery.BootstrapToken", "Token", token)
 
 		// update the bootstrap data
-		return r.joinWorker(ctx, scope)
+		res, err := r.joinWorker(ctx, scope)
+		if err != nil {
+			return res, err
+		}
+		now := metav1.Now()
+		config.Status.LastTokenRotationTime = &now
+		return res, nil
 	}
 	return ctrl.Result{
 		RequeueAfter: r.TokenTTL / 3,
 	}, nil
 }
 
+// tokenRotationMode returns the token rotation mode of a KubeadmConfig, defaulting to Refresh.
+func tokenRotationMode(config *bootstrapv1.KubeadmConfig) bootstrapv1.TokenRotationMode {
+	if config.Spec.TokenRotation == nil || config.Spec.TokenRotation.Mode == "" {
+		return bootstrapv1.RefreshTokenRotationMode
+	}
+	return config.Spec.TokenRotation.Mode
+}
+
 func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Context, scope *Scope) (_ ctrl.Result, reterr error) {
 	// initialize the DataSecretAvailableCondition if missing.
 	// this is required in order to avoid the condition's LastTransitionTime to flicker in case of errors surfacing
@@ -400,7 +443,7 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 			},
 		}
 	}
-	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.InitConfiguration, parsedVersion)
+	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.ClusterConfiguration, scope.Config.Spec.InitConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal init configuration")
 		return ctrl.Result{}, err
@@ -442,7 +485,7 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		verbosityFlag = fmt.Sprintf("--v %s", strconv.Itoa(int(*scope.Config.Spec.Verbosity)))
 	}
 
-	files, err := r.resolveFiles(ctx, scope.Config)
+	files, err := r.resolveFiles(ctx, scope)
 	if err != nil {
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
@@ -454,9 +497,17 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		return ctrl.Result{}, err
 	}
 
+	containerd, err := r.resolveContainerd(ctx, scope.Config)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	controlPlaneInput := &cloudinit.ControlPlaneInput{
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:     files,
+			Containerd:          containerd,
+			SystemdUnits:        scope.Config.Spec.SystemdUnits,
 			NTP:                 scope.Config.Spec.NTP,
 			PreKubeadmCommands:  scope.Config.Spec.PreKubeadmCommands,
 			PostKubeadmCommands: scope.Config.Spec.PostKubeadmCommands,
@@ -477,6 +528,13 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 			ControlPlaneInput: controlPlaneInput,
 			Ignition:          scope.Config.Spec.Ignition,
 		})
+	case bootstrapv1.Windows:
+		err = errors.Errorf("format %q is not supported for control plane machines", scope.Config.Spec.Format)
+	case bootstrapv1.External:
+		request := newGenerateBootstrapDataRequest(scope, &controlPlaneInput.BaseUserData, certificates.AsFiles())
+		request.ClusterConfiguration = clusterdata
+		request.InitConfiguration = initdata
+		bootstrapInitData, err = r.generateExternalBootstrapData(ctx, scope, request)
 	default:
 		bootstrapInitData, err = cloudinit.NewInitControlPlane(controlPlaneInput)
 	}
@@ -524,7 +582,7 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", kubernetesVersion)
 	}
 
-	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(scope.Config.Spec.JoinConfiguration, parsedVersion)
+	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(scope.Config.Spec.ClusterConfiguration, scope.Config.Spec.JoinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
 		return ctrl.Result{}, err
@@ -541,7 +599,7 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		verbosityFlag = fmt.Sprintf("--v %s", strconv.Itoa(int(*scope.Config.Spec.Verbosity)))
 	}
 
-	files, err := r.resolveFiles(ctx, scope.Config)
+	files, err := r.resolveFiles(ctx, scope)
 	if err != nil {
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
@@ -553,9 +611,17 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		return ctrl.Result{}, err
 	}
 
+	containerd, err := r.resolveContainerd(ctx, scope.Config)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	nodeInput := &cloudinit.NodeInput{
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:      files,
+			Containerd:           containerd,
+			SystemdUnits:         scope.Config.Spec.SystemdUnits,
 			NTP:                  scope.Config.Spec.NTP,
 			PreKubeadmCommands:   scope.Config.Spec.PreKubeadmCommands,
 			PostKubeadmCommands:  scope.Config.Spec.PostKubeadmCommands,
@@ -575,6 +641,12 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 			NodeInput: nodeInput,
 			Ignition:  scope.Config.Spec.Ignition,
 		})
+	case bootstrapv1.Windows:
+		bootstrapJoinData, err = windows.NewNode(nodeInput)
+	case bootstrapv1.External:
+		request := newGenerateBootstrapDataRequest(scope, &nodeInput.BaseUserData, nil)
+		request.JoinConfiguration = joinData
+		bootstrapJoinData, err = r.generateExternalBootstrapData(ctx, scope, request)
 	default:
 		bootstrapJoinData, err = cloudinit.NewNode(nodeInput)
 	}
@@ -629,7 +701,7 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", kubernetesVersion)
 	}
 
-	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(scope.Config.Spec.JoinConfiguration, parsedVersion)
+	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(scope.Config.Spec.ClusterConfiguration, scope.Config.Spec.JoinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
 		return ctrl.Result{}, err
@@ -642,7 +714,7 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		verbosityFlag = fmt.Sprintf("--v %s", strconv.Itoa(int(*scope.Config.Spec.Verbosity)))
 	}
 
-	files, err := r.resolveFiles(ctx, scope.Config)
+	files, err := r.resolveFiles(ctx, scope)
 	if err != nil {
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
@@ -654,11 +726,19 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, err
 	}
 
+	containerd, err := r.resolveContainerd(ctx, scope.Config)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	controlPlaneJoinInput := &cloudinit.ControlPlaneJoinInput{
 		JoinConfiguration: joinData,
 		Certificates:      certificates,
 		BaseUserData: cloudinit.BaseUserData{
 			AdditionalFiles:      files,
+			Containerd:           containerd,
+			SystemdUnits:         scope.Config.Spec.SystemdUnits,
 			NTP:                  scope.Config.Spec.NTP,
 			PreKubeadmCommands:   scope.Config.Spec.PreKubeadmCommands,
 			PostKubeadmCommands:  scope.Config.Spec.PostKubeadmCommands,
@@ -677,6 +757,12 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 			ControlPlaneJoinInput: controlPlaneJoinInput,
 			Ignition:              scope.Config.Spec.Ignition,
 		})
+	case bootstrapv1.Windows:
+		err = errors.Errorf("format %q is not supported for control plane machines", scope.Config.Spec.Format)
+	case bootstrapv1.External:
+		request := newGenerateBootstrapDataRequest(scope, &controlPlaneJoinInput.BaseUserData, certificates.AsFiles())
+		request.JoinConfiguration = joinData
+		bootstrapJoinData, err = r.generateExternalBootstrapData(ctx, scope, request)
 	default:
 		bootstrapJoinData, err = cloudinit.NewJoinControlPlane(controlPlaneJoinInput)
 	}
@@ -695,43 +781,110 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 }
 
 // resolveFiles maps .Spec.Files into cloudinit.Files, resolving any object references
-// along the way.
-func (r *KubeadmConfigReconciler) resolveFiles(ctx context.Context, cfg *bootstrapv1.KubeadmConfig) ([]bootstrapv1.File, error) {
+// and rendering any templated content along the way.
+func (r *KubeadmConfigReconciler) resolveFiles(ctx context.Context, scope *Scope) ([]bootstrapv1.File, error) {
+	cfg := scope.Config
 	collected := make([]bootstrapv1.File, 0, len(cfg.Spec.Files))
 
 	for i := range cfg.Spec.Files {
 		in := cfg.Spec.Files[i]
 		if in.ContentFrom != nil {
-			data, err := r.resolveSecretFileContent(ctx, cfg.Namespace, in)
+			data, err := r.resolveFileSourceContent(ctx, cfg.Namespace, in.ContentFrom)
 			if err != nil {
 				return nil, errors.Wrapf(err, "failed to resolve file source")
 			}
 			in.ContentFrom = nil
 			in.Content = string(data)
 		}
+		if in.Template {
+			content, err := renderFileTemplate(scope, in)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to render content of file %q", in.Path)
+			}
+			in.Template = false
+			in.Content = content
+		}
 		collected = append(collected, in)
 	}
 
 	return collected, nil
 }
 
+// resolveFileSourceContent returns file content fetched from the object referenced by the file source.
+func (r *KubeadmConfigReconciler) resolveFileSourceContent(ctx context.Context, ns string, source *bootstrapv1.FileSource) ([]byte, error) {
+	if source.ConfigMap != nil {
+		return r.resolveConfigMapFileContent(ctx, ns, *source.ConfigMap)
+	}
+	return r.resolveSecretFileContent(ctx, ns, source.Secret)
+}
+
 // resolveSecretFileContent returns file content fetched from a referenced secret object.
-func (r *KubeadmConfigReconciler) resolveSecretFileContent(ctx context.Context, ns string, source bootstrapv1.File) ([]byte, error) {
+func (r *KubeadmConfigReconciler) resolveSecretFileContent(ctx context.Context, ns string, source bootstrapv1.SecretFileSource) ([]byte, error) {
 	secret := &corev1.Secret{}
-	key := types.NamespacedName{Namespace: ns, Name: source.ContentFrom.Secret.Name}
+	key := types.NamespacedName{Namespace: ns, Name: source.Name}
 	if err := r.Client.Get(ctx, key, secret); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, errors.Wrapf(err, "secret not found: %s", key)
 		}
 		return nil, errors.Wrapf(err, "failed to retrieve Secret %q", key)
 	}
-	data, ok := secret.Data[source.ContentFrom.Secret.Key]
+	data, ok := secret.Data[source.Key]
 	if !ok {
-		return nil, errors.Errorf("secret references non-existent secret key: %q", source.ContentFrom.Secret.Key)
+		return nil, errors.Errorf("secret references non-existent secret key: %q", source.Key)
 	}
 	return data, nil
 }
 
+// resolveConfigMapFileContent returns file content fetched from a referenced config map object.
+func (r *KubeadmConfigReconciler) resolveConfigMapFileContent(ctx context.Context, ns string, source bootstrapv1.ConfigMapFileSource) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: ns, Name: source.Name}
+	if err := r.Client.Get(ctx, key, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "config map not found: %s", key)
+		}
+		return nil, errors.Wrapf(err, "failed to retrieve ConfigMap %q", key)
+	}
+	if data, ok := configMap.Data[source.Key]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := configMap.BinaryData[source.Key]; ok {
+		return data, nil
+	}
+	return nil, errors.Errorf("config map references non-existent config map key: %q", source.Key)
+}
+
+// fileTemplateData defines the variables available when rendering the content of templated files.
+type fileTemplateData struct {
+	ClusterName          string
+	ClusterNamespace     string
+	MachineName          string
+	ControlPlaneEndpoint clusterv1.APIEndpoint
+	KubernetesVersion    string
+}
+
+// renderFileTemplate renders the content of the file as a Go template using the cluster and machine variables.
+func renderFileTemplate(scope *Scope, file bootstrapv1.File) (string, error) {
+	tpl, err := template.New(file.Path).Option("missingkey=error").Parse(file.Content)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse template")
+	}
+
+	data := fileTemplateData{
+		ClusterName:          scope.Cluster.Name,
+		ClusterNamespace:     scope.Cluster.Namespace,
+		MachineName:          scope.ConfigOwner.GetName(),
+		ControlPlaneEndpoint: scope.Cluster.Spec.ControlPlaneEndpoint,
+		KubernetesVersion:    scope.ConfigOwner.KubernetesVersion(),
+	}
+
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, data); err != nil {
+		return "", errors.Wrap(err, "failed to execute template")
+	}
+	return out.String(), nil
+}
+
 // resolveUsers maps .Spec.Users into cloudinit.Users, resolving any object references
 // along the way.
 func (r *KubeadmConfigReconciler) resolveUsers(ctx context.Context, cfg *bootstrapv1.KubeadmConfig) ([]bootstrapv1.User, error) {
@@ -771,6 +924,45 @@ func (r *KubeadmConfigReconciler) resolveSecretPasswordContent(ctx context.Conte
 	return data, nil
 }
 
+// resolveContainerd maps .Spec.Containerd into cloudinit.ContainerdInput, resolving the registry
+// credentials along the way.
+func (r *KubeadmConfigReconciler) resolveContainerd(ctx context.Context, cfg *bootstrapv1.KubeadmConfig) (*cloudinit.ContainerdInput, error) {
+	if cfg.Spec.Containerd == nil {
+		return nil, nil
+	}
+
+	input := &cloudinit.ContainerdInput{
+		SandboxImage:        cfg.Spec.Containerd.SandboxImage,
+		RegistryMirrors:     cfg.Spec.Containerd.RegistryMirrors,
+		RegistryCredentials: make([]cloudinit.ContainerdRegistryCredentials, 0, len(cfg.Spec.Containerd.RegistryAuths)),
+	}
+	for _, auth := range cfg.Spec.Containerd.RegistryAuths {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: cfg.Namespace, Name: auth.SecretName}
+		if err := r.Client.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "secret not found: %s", key)
+			}
+			return nil, errors.Wrapf(err, "failed to retrieve Secret %q", key)
+		}
+		username, ok := secret.Data[corev1.BasicAuthUsernameKey]
+		if !ok {
+			return nil, errors.Errorf("secret %q for registry %q is missing the %q key", key, auth.Registry, corev1.BasicAuthUsernameKey)
+		}
+		password, ok := secret.Data[corev1.BasicAuthPasswordKey]
+		if !ok {
+			return nil, errors.Errorf("secret %q for registry %q is missing the %q key", key, auth.Registry, corev1.BasicAuthPasswordKey)
+		}
+		input.RegistryCredentials = append(input.RegistryCredentials, cloudinit.ContainerdRegistryCredentials{
+			Registry: auth.Registry,
+			Username: string(username),
+			Password: string(password),
+		})
+	}
+
+	return input, nil
+}
+
 // ClusterToKubeadmConfigs is a handler.ToRequestsFunc to be used to enqueue
 // requests for reconciliation of KubeadmConfigs.
 func (r *KubeadmConfigReconciler) ClusterToKubeadmConfigs(o client.Object) []ctrl.Request {
@@ -819,6 +1011,44 @@ func (r *KubeadmConfigReconciler) ClusterToKubeadmConfigs(o client.Object) []ctr
 	return result
 }
 
+// SecretToKubeadmConfigs is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// of KubeadmConfigs which are not ready yet and use the Secret as a file source or for registry credentials.
+func (r *KubeadmConfigReconciler) SecretToKubeadmConfigs(o client.Object) []ctrl.Request {
+	return r.referencingKubeadmConfigs(o, secretNameField)
+}
+
+// ConfigMapToKubeadmConfigs is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// of KubeadmConfigs which are not ready yet and use the ConfigMap as a file source.
+func (r *KubeadmConfigReconciler) ConfigMapToKubeadmConfigs(o client.Object) []ctrl.Request {
+	return r.referencingKubeadmConfigs(o, configMapNameField)
+}
+
+// referencingKubeadmConfigs returns requests for the KubeadmConfigs in the namespace of the object
+// which are not ready yet and reference the object, using the given index field.
+func (r *KubeadmConfigReconciler) referencingKubeadmConfigs(o client.Object, indexField string) []ctrl.Request {
+	result := []ctrl.Request{}
+
+	configList := &bootstrapv1.KubeadmConfigList{}
+	if err := r.Client.List(
+		context.TODO(),
+		configList,
+		client.InNamespace(o.GetNamespace()),
+		client.MatchingFields{indexField: o.GetName()},
+	); err != nil {
+		return nil
+	}
+
+	for i := range configList.Items {
+		c := &configList.Items[i]
+		if c.Status.Ready {
+			continue
+		}
+		result = append(result, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(c)})
+	}
+
+	return result
+}
+
 // MachineToBootstrapMapFunc is a handler.ToRequestsFunc to be used to enqueue
 // request for reconciliation of KubeadmConfig.
 func (r *KubeadmConfigReconciler) MachineToBootstrapMapFunc(o client.Object) []ctrl.Request {
@@ -963,11 +1193,52 @@ func (r *KubeadmConfigReconciler) reconcileTopLevelObjectSettings(ctx context.Co
 	}
 }
 
-// storeBootstrapData creates a new secret with the data passed in as input,
-// sets the reference in the configuration status and ready to true.
+// compressBootstrapData compresses the bootstrap data according to the compression
+// defined in the KubeadmConfig, if any.
+func compressBootstrapData(config *bootstrapv1.KubeadmConfig, data []byte) ([]byte, error) {
+	if config.Spec.BootstrapData == nil || config.Spec.BootstrapData.Compression != bootstrapv1.GzipBootstrapDataCompression {
+		return data, nil
+	}
+
+	switch config.Spec.Format {
+	case bootstrapv1.Ignition:
+		compressed, err := ignition.Compress(data)
+		return compressed, errors.Wrap(err, "failed to compress Ignition bootstrap data")
+	case bootstrapv1.Windows:
+		compressed, err := windows.Compress(data)
+		return compressed, errors.Wrap(err, "failed to compress Windows bootstrap data")
+	case bootstrapv1.External:
+		return nil, errors.Errorf("compression is not supported for format %q", bootstrapv1.External)
+	default:
+		compressed, err := cloudinit.Compress(data)
+		return compressed, errors.Wrap(err, "failed to compress cloud-init bootstrap data")
+	}
+}
+
+// storeBootstrapData creates a new secret with the data passed in as input, after compressing it
+// and validating its size if required, sets the reference in the configuration status and ready to true.
+// NOTE: If the bootstrap data exceeds the size limit, the secret is not created and no error is returned,
+// because retrying can't fix it; the DataSecretAvailable condition reports the issue to the user, and
+// the KubeadmConfig is reconciled again when it is changed.
 func (r *KubeadmConfigReconciler) storeBootstrapData(ctx context.Context, scope *Scope, data []byte) error {
 	log := ctrl.LoggerFrom(ctx)
 
+	data, err := compressBootstrapData(scope.Config, data)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	if bootstrapData := scope.Config.Spec.BootstrapData; bootstrapData != nil && bootstrapData.MaxSize != nil && len(data) > int(*bootstrapData.MaxSize) {
+		msg := fmt.Sprintf("bootstrap data size of %d bytes exceeds the limit of %d bytes defined in spec.bootstrapData.maxSize", len(data), *bootstrapData.MaxSize)
+		if bootstrapData.Compression == "" {
+			msg += "; consider enabling compression using spec.bootstrapData.compression"
+		}
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretSizeLimitExceededReason, clusterv1.ConditionSeverityWarning, msg)
+		log.Info("Bootstrap data exceeds the size limit, waiting for the KubeadmConfig to be changed", "size", len(data), "maxSize", *bootstrapData.MaxSize)
+		return nil
+	}
+
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      scope.Config.Name,
@@ -975,6 +1246,9 @@ func (r *KubeadmConfigReconciler) storeBootstrapData(ctx context.Context, scope
 			Labels: map[string]string{
 				clusterv1.ClusterLabelName: scope.Cluster.Name,
 			},
+			Annotations: map[string]string{
+				bootstrapv1.DataSecretGenerationAnnotation: "1",
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: bootstrapv1.GroupVersion.String(),
@@ -999,6 +1273,18 @@ func (r *KubeadmConfigReconciler) storeBootstrapData(ctx context.Context, scope
 			return errors.Wrapf(err, "failed to create bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
 		}
 		log.Info("bootstrap data secret for KubeadmConfig already exists, updating", "secret", secret.Name, "KubeadmConfig", scope.Config.Name)
+		existing := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+			return errors.Wrapf(err, "failed to get bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+		}
+		// bump the generation only if the bootstrap data changed, so infrastructure providers are not
+		// notified when the data is regenerated unchanged, e.g. after a failed status patch.
+		generation := dataSecretGeneration(existing)
+		if !bytes.Equal(existing.Data["value"], secret.Data["value"]) || !bytes.Equal(existing.Data["format"], secret.Data["format"]) {
+			generation++
+		}
+		secret.Annotations[bootstrapv1.DataSecretGenerationAnnotation] = strconv.FormatInt(generation, 10)
+		secret.ResourceVersion = existing.ResourceVersion
 		if err := r.Client.Update(ctx, secret); err != nil {
 			return errors.Wrapf(err, "failed to update bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
 		}
@@ -1008,3 +1294,13 @@ func (r *KubeadmConfigReconciler) storeBootstrapData(ctx context.Context, scope
 	conditions.MarkTrue(scope.Config, bootstrapv1.DataSecretAvailableCondition)
 	return nil
 }
+
+// dataSecretGeneration returns the generation of a bootstrap data secret; secrets created before the
+// generation annotation was introduced, or with an invalid annotation, are considered at their first generation.
+func dataSecretGeneration(secret *corev1.Secret) int64 {
+	generation, err := strconv.ParseInt(secret.Annotations[bootstrapv1.DataSecretGenerationAnnotation], 10, 64)
+	if err != nil || generation < 1 {
+		return 1
+	}
+	return generation
+}
@@ -28,6 +28,8 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	"k8s.io/utils/pointer"
@@ -40,6 +42,8 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	bootstrapbuilder "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/builder"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
 	fakeremote "sigs.k8s.io/cluster-api/controllers/remote/fake"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
@@ -699,6 +703,12 @@ func TestBootstrapDataFormat(t *testing.T) {
 			format:             bootstrapv1.Ignition,
 			clusterInitialized: true,
 		},
+		{
+			name:               "Windows worker join config",
+			isWorker:           true,
+			format:             bootstrapv1.Windows,
+			clusterInitialized: true,
+		},
 		{
 			name: "Empty format field",
 		},
@@ -789,6 +799,140 @@ func TestBootstrapDataFormat(t *testing.T) {
 				_, reports, err := ignition.Parse(data)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(reports.IsFatal()).NotTo(BeTrue())
+			case bootstrapv1.Windows:
+				// Verify the bootstrap data is a PowerShell script for cloudbase-init joining the cluster.
+				g.Expect(string(data)).To(HavePrefix("#ps1_sysnative\n"))
+				g.Expect(string(data)).To(ContainSubstring("kubeadm join --config"))
+			}
+		})
+	}
+}
+
+func TestBootstrapDataCompressionAndSizeLimit(t *testing.T) {
+	testcases := []struct {
+		name                    string
+		format                  bootstrapv1.Format
+		bootstrapData           *bootstrapv1.BootstrapDataSpec
+		expectSizeLimitExceeded bool
+	}{
+		{
+			name:   "cloud-config with gzip compression",
+			format: bootstrapv1.CloudConfig,
+			bootstrapData: &bootstrapv1.BootstrapDataSpec{
+				Compression: bootstrapv1.GzipBootstrapDataCompression,
+			},
+		},
+		{
+			name:   "Ignition with gzip compression",
+			format: bootstrapv1.Ignition,
+			bootstrapData: &bootstrapv1.BootstrapDataSpec{
+				Compression: bootstrapv1.GzipBootstrapDataCompression,
+			},
+		},
+		{
+			name:   "cloud-config within the size limit",
+			format: bootstrapv1.CloudConfig,
+			bootstrapData: &bootstrapv1.BootstrapDataSpec{
+				MaxSize: pointer.Int32(1024 * 1024),
+			},
+		},
+		{
+			name:   "cloud-config exceeding the size limit",
+			format: bootstrapv1.CloudConfig,
+			bootstrapData: &bootstrapv1.BootstrapDataSpec{
+				MaxSize: pointer.Int32(1024),
+			},
+			expectSizeLimitExceeded: true,
+		},
+		{
+			name:   "Ignition with gzip compression exceeding the size limit",
+			format: bootstrapv1.Ignition,
+			bootstrapData: &bootstrapv1.BootstrapDataSpec{
+				Compression: bootstrapv1.GzipBootstrapDataCompression,
+				MaxSize:     pointer.Int32(1024),
+			},
+			expectSizeLimitExceeded: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+			cluster.Status.InfrastructureReady = true
+			cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+
+			machine := newControlPlaneMachine(cluster, "machine")
+			config := newControlPlaneInitKubeadmConfig(metav1.NamespaceDefault, "cfg")
+			addKubeadmConfigToMachine(config, machine)
+			config.Spec.Format = tc.format
+			config.Spec.BootstrapData = tc.bootstrapData
+
+			objects := []client.Object{
+				cluster,
+				machine,
+				config,
+			}
+			objects = append(objects, createSecrets(t, cluster, config)...)
+
+			myclient := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+			k := &KubeadmConfigReconciler{
+				Client:             myclient,
+				KubeadmInitLock:    &myInitLocker{},
+				remoteClientGetter: fakeremote.NewClusterClient,
+			}
+			request := ctrl.Request{
+				NamespacedName: client.ObjectKey{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "cfg",
+				},
+			}
+
+			result, err := k.Reconcile(ctx, request)
+			g.Expect(err).NotTo(HaveOccurred())
+			cfg, err := getKubeadmConfig(myclient, "cfg", metav1.NamespaceDefault)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.expectSizeLimitExceeded {
+				g.Expect(result).To(Equal(ctrl.Result{}))
+				g.Expect(cfg.Status.Ready).To(BeFalse())
+				g.Expect(cfg.Status.DataSecretName).To(BeNil())
+				condition := conditions.Get(cfg, bootstrapv1.DataSecretAvailableCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(bootstrapv1.DataSecretSizeLimitExceededReason))
+				g.Expect(condition.Message).To(ContainSubstring("exceeds the limit of 1024 bytes"))
+				return
+			}
+
+			g.Expect(cfg.Status.Ready).To(BeTrue())
+			g.Expect(cfg.Status.DataSecretName).NotTo(BeNil())
+
+			secret := &corev1.Secret{}
+			g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: *cfg.Status.DataSecretName}, secret)).To(Succeed())
+			data := secret.Data["value"]
+			if tc.bootstrapData.MaxSize != nil {
+				g.Expect(len(data)).To(BeNumerically("<=", *tc.bootstrapData.MaxSize))
+			}
+
+			switch tc.format {
+			case bootstrapv1.CloudConfig:
+				if tc.bootstrapData.Compression == bootstrapv1.GzipBootstrapDataCompression {
+					g.Expect(string(data)).To(HavePrefix("Content-Type: multipart/mixed"))
+				}
+			case bootstrapv1.Ignition:
+				ign, reports, err := ignition.Parse(data)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(reports.IsFatal()).NotTo(BeTrue())
+				compressed := 0
+				for _, f := range ign.Storage.Files {
+					if f.Contents.Compression == "gzip" {
+						compressed++
+					}
+				}
+				g.Expect(compressed).To(BeNumerically(">", 0))
 			}
 		})
 	}
@@ -866,6 +1010,17 @@ func TestKubeadmConfigSecretCreatedStatusNotPatched(t *testing.T) {
 	g.Expect(cfg.Status.Ready).To(BeTrue())
 	g.Expect(cfg.Status.DataSecretName).NotTo(BeNil())
 	g.Expect(cfg.Status.ObservedGeneration).NotTo(BeNil())
+
+	// the existing secret has been updated with the bootstrap data, so its generation has been bumped.
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	g.Expect(secret.Data["value"]).NotTo(BeEmpty())
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(bootstrapv1.DataSecretGenerationAnnotation, "2"))
+
+	// storing the same bootstrap data again does not bump the generation.
+	scope := &Scope{Config: cfg, Cluster: cluster}
+	g.Expect(k.storeBootstrapData(ctx, scope, secret.Data["value"])).To(Succeed())
+	g.Expect(myclient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	g.Expect(secret.Annotations).To(HaveKeyWithValue(bootstrapv1.DataSecretGenerationAnnotation, "2"))
 }
 
 func TestBootstrapTokenTTLExtension(t *testing.T) {
@@ -1120,6 +1275,7 @@ func TestBootstrapTokenRotationMachinePool(t *testing.T) {
 	g.Expect(cfg.Status.Ready).To(BeTrue())
 	g.Expect(cfg.Status.DataSecretName).NotTo(BeNil())
 	g.Expect(cfg.Status.ObservedGeneration).NotTo(BeNil())
+	g.Expect(cfg.Status.LastTokenRotationTime).To(BeNil())
 
 	l := &corev1.SecretList{}
 	err = myclient.List(ctx, l, client.ListOption(client.InNamespace(metav1.NamespaceSystem)))
@@ -1251,6 +1407,102 @@ func TestBootstrapTokenRotationMachinePool(t *testing.T) {
 	}
 	g.Expect(foundOld).To(BeTrue())
 	g.Expect(foundNew).To(BeTrue())
+
+	// the rotation is reported in status, and the bootstrap data secret has been updated with the new token.
+	cfg, err = getKubeadmConfig(myclient, "workerpool-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.Status.LastTokenRotationTime).NotTo(BeNil())
+
+	dataSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "workerpool-join-cfg"}, dataSecret)).To(Succeed())
+	g.Expect(string(dataSecret.Data["value"])).To(ContainSubstring(cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token))
+	g.Expect(dataSecret.Annotations).To(HaveKeyWithValue(bootstrapv1.DataSecretGenerationAnnotation, "2"))
+}
+
+func TestBootstrapTokenRotationMachinePoolRotateMode(t *testing.T) {
+	_ = feature.MutableGates.Set("MachinePool=true")
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Status.InfrastructureReady = true
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+
+	controlPlaneInitMachine := newControlPlaneMachine(cluster, "control-plane-init-machine")
+	initConfig := newControlPlaneInitKubeadmConfig(controlPlaneInitMachine.Namespace, "control-plane-init-config")
+
+	addKubeadmConfigToMachine(initConfig, controlPlaneInitMachine)
+
+	workerMachinePool := newWorkerMachinePoolForCluster(cluster)
+	workerJoinConfig := newWorkerJoinKubeadmConfig(workerMachinePool.Namespace, "workerpool-join-cfg")
+	workerJoinConfig.Spec.TokenRotation = &bootstrapv1.TokenRotationSpec{Mode: bootstrapv1.RotateTokenRotationMode}
+	addKubeadmConfigToMachinePool(workerJoinConfig, workerMachinePool)
+	objects := []client.Object{
+		cluster,
+		workerMachinePool,
+		workerJoinConfig,
+	}
+
+	objects = append(objects, createSecrets(t, cluster, initConfig)...)
+	myclient := fake.NewClientBuilder().WithObjects(objects...).Build()
+	k := &KubeadmConfigReconciler{
+		Client:             myclient,
+		KubeadmInitLock:    &myInitLocker{},
+		TokenTTL:           DefaultTokenTTL,
+		remoteClientGetter: fakeremote.NewClusterClient,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "workerpool-join-cfg",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cfg, err := getKubeadmConfig(myclient, "workerpool-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.Status.Ready).To(BeTrue())
+	g.Expect(cfg.Status.LastTokenRotationTime).To(BeNil())
+	token := cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token
+
+	l := &corev1.SecretList{}
+	g.Expect(myclient.List(ctx, l, client.InNamespace(metav1.NamespaceSystem))).To(Succeed())
+	g.Expect(l.Items).To(HaveLen(1))
+	tokenExpires := l.Items[0].Data[bootstrapapi.BootstrapTokenExpirationKey]
+
+	<-time.After(1 * time.Second)
+
+	// no nodes have joined yet, but the token is not refreshed: it is going to be rotated before it expires.
+	result, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(k.TokenTTL / 3))
+
+	l = &corev1.SecretList{}
+	g.Expect(myclient.List(ctx, l, client.InNamespace(metav1.NamespaceSystem))).To(Succeed())
+	g.Expect(l.Items).To(HaveLen(1))
+	g.Expect(l.Items[0].Data[bootstrapapi.BootstrapTokenExpirationKey]).To(Equal(tokenExpires))
+
+	// if the token does not exist anymore, e.g. because it expired and has been deleted, it is rotated.
+	g.Expect(myclient.Delete(ctx, &l.Items[0])).To(Succeed())
+
+	result, err = k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Duration(0)))
+
+	l = &corev1.SecretList{}
+	g.Expect(myclient.List(ctx, l, client.InNamespace(metav1.NamespaceSystem))).To(Succeed())
+	g.Expect(l.Items).To(HaveLen(1))
+
+	cfg, err = getKubeadmConfig(myclient, "workerpool-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token).NotTo(Equal(token))
+	g.Expect(cfg.Status.LastTokenRotationTime).NotTo(BeNil())
+
+	dataSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "workerpool-join-cfg"}, dataSecret)).To(Succeed())
+	g.Expect(string(dataSecret.Data["value"])).To(ContainSubstring(cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token))
+	g.Expect(dataSecret.Annotations).To(HaveKeyWithValue(bootstrapv1.DataSecretGenerationAnnotation, "2"))
 }
 
 // Ensure the discovery portion of the JoinConfiguration gets generated correctly.
@@ -1669,6 +1921,40 @@ func TestKubeadmConfigReconciler_ClusterToKubeadmConfigs(t *testing.T) {
 	}
 }
 
+func TestKubeadmConfigReconciler_FileSourceToKubeadmConfigs(t *testing.T) {
+	g := NewWithT(t)
+
+	// NOTE: The fake client does not support field selectors, so only KubeadmConfigs referencing the source are
+	// created here; the indexes used to select them are tested in TestKubeadmConfigBySecretName and
+	// TestKubeadmConfigByConfigMapName.
+	withConfigMap := newKubeadmConfig(metav1.NamespaceDefault, "with-config-map")
+	withConfigMap.Spec.Files = []bootstrapv1.File{
+		{
+			Path: "/config-map",
+			ContentFrom: &bootstrapv1.FileSource{
+				ConfigMap: &bootstrapv1.ConfigMapFileSource{Name: "source", Key: "key"},
+			},
+		},
+	}
+	ready := withConfigMap.DeepCopy()
+	ready.Name = "ready"
+	ready.Status.Ready = true
+	otherNamespace := withConfigMap.DeepCopy()
+	otherNamespace.Namespace = "other"
+
+	fakeClient := fake.NewClientBuilder().WithObjects(withConfigMap, ready, otherNamespace).Build()
+	reconciler := &KubeadmConfigReconciler{
+		Client: fakeClient,
+	}
+
+	source := metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "source"}
+
+	g.Expect(reconciler.ConfigMapToKubeadmConfigs(&corev1.ConfigMap{ObjectMeta: source})).To(ConsistOf(
+		ctrl.Request{NamespacedName: client.ObjectKeyFromObject(withConfigMap)},
+	))
+	g.Expect(reconciler.SecretToKubeadmConfigs(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "empty", Name: "source"}})).To(BeEmpty())
+}
+
 // Reconcile should not fail if the Etcd CA Secret already exists.
 func TestKubeadmConfigReconciler_Reconcile_DoesNotFailIfCASecretsAlreadyExist(t *testing.T) {
 	g := NewWithT(t)
@@ -1812,6 +2098,28 @@ func TestKubeadmConfigReconciler_ResolveFiles(t *testing.T) {
 		},
 	}
 
+	testConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "source",
+		},
+		Data: map[string]string{
+			"key": "bar",
+		},
+		BinaryData: map[string][]byte{
+			"binary": []byte("baz"),
+		},
+	}
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+	machine := newWorkerMachineForCluster(cluster)
+	machine.Spec.Version = pointer.String("v1.24.0")
+	owner, err := runtime.DefaultUnstructuredConverter.ToUnstructured(machine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configOwner := &bsutil.ConfigOwner{Unstructured: &unstructured.Unstructured{Object: owner}}
+
 	cases := map[string]struct {
 		cfg     *bootstrapv1.KubeadmConfig
 		objects []client.Object
@@ -1907,6 +2215,94 @@ func TestKubeadmConfigReconciler_ResolveFiles(t *testing.T) {
 			},
 			objects: []client.Object{testSecret},
 		},
+		"contentFrom config map should convert correctly": {
+			cfg: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Files: []bootstrapv1.File{
+						{
+							ContentFrom: &bootstrapv1.FileSource{
+								ConfigMap: &bootstrapv1.ConfigMapFileSource{
+									Name: "source",
+									Key:  "key",
+								},
+							},
+							Path: "/path",
+						},
+						{
+							ContentFrom: &bootstrapv1.FileSource{
+								ConfigMap: &bootstrapv1.ConfigMapFileSource{
+									Name: "source",
+									Key:  "binary",
+								},
+							},
+							Path: "/binary",
+						},
+					},
+				},
+			},
+			expect: []bootstrapv1.File{
+				{
+					Content: "bar",
+					Path:    "/path",
+				},
+				{
+					Content: "baz",
+					Path:    "/binary",
+				},
+			},
+			objects: []client.Object{testConfigMap},
+		},
+		"templated content should be rendered": {
+			cfg: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Files: []bootstrapv1.File{
+						{
+							Content:  "{{ .ClusterNamespace }}/{{ .ClusterName }} {{ .MachineName }} {{ .ControlPlaneEndpoint.Host }}:{{ .ControlPlaneEndpoint.Port }} {{ .KubernetesVersion }}",
+							Path:     "/path",
+							Template: true,
+						},
+					},
+				},
+			},
+			expect: []bootstrapv1.File{
+				{
+					Content: fmt.Sprintf("default/cluster %s 100.105.150.1:6443 v1.24.0", machine.Name),
+					Path:    "/path",
+				},
+			},
+		},
+		"templated content from config map should be rendered": {
+			cfg: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Files: []bootstrapv1.File{
+						{
+							ContentFrom: &bootstrapv1.FileSource{
+								ConfigMap: &bootstrapv1.ConfigMapFileSource{
+									Name: "template",
+									Key:  "key",
+								},
+							},
+							Path:     "/path",
+							Template: true,
+						},
+					},
+				},
+			},
+			expect: []bootstrapv1.File{
+				{
+					Content: "cluster: cluster",
+					Path:    "/path",
+				},
+			},
+			objects: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "template",
+				},
+				Data: map[string]string{
+					"key": "cluster: {{ .ClusterName }}",
+				},
+			}},
+		},
 	}
 
 	for name, tc := range cases {
@@ -1930,7 +2326,12 @@ func TestKubeadmConfigReconciler_ResolveFiles(t *testing.T) {
 				}
 			}
 
-			files, err := k.resolveFiles(ctx, tc.cfg)
+			scope := &Scope{
+				Config:      tc.cfg,
+				ConfigOwner: configOwner,
+				Cluster:     cluster,
+			}
+			files, err := k.resolveFiles(ctx, scope)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(files).To(Equal(tc.expect))
 			for _, file := range tc.cfg.Spec.Files {
@@ -2069,6 +2470,102 @@ func TestKubeadmConfigReconciler_ResolveUsers(t *testing.T) {
 	}
 }
 
+func TestKubeadmConfigReconciler_ResolveContainerd(t *testing.T) {
+	registrySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "registry",
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("user"),
+			corev1.BasicAuthPasswordKey: []byte("password"),
+		},
+	}
+	incompleteSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "incomplete",
+		},
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte("user"),
+		},
+	}
+	mirrors := []bootstrapv1.ContainerdRegistryMirror{
+		{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}},
+	}
+
+	cases := map[string]struct {
+		containerd *bootstrapv1.ContainerdConfig
+		objects    []client.Object
+		expect     *cloudinit.ContainerdInput
+		expectErr  bool
+	}{
+		"no containerd configuration": {},
+		"configuration without credentials should pass through": {
+			containerd: &bootstrapv1.ContainerdConfig{
+				SandboxImage:    "registry.k8s.io/pause:3.6",
+				RegistryMirrors: mirrors,
+			},
+			expect: &cloudinit.ContainerdInput{
+				SandboxImage:        "registry.k8s.io/pause:3.6",
+				RegistryMirrors:     mirrors,
+				RegistryCredentials: []cloudinit.ContainerdRegistryCredentials{},
+			},
+		},
+		"registry credentials should be resolved from secrets": {
+			containerd: &bootstrapv1.ContainerdConfig{
+				RegistryAuths: []bootstrapv1.ContainerdRegistryAuth{
+					{Registry: "registry.example.com", SecretName: "registry"},
+				},
+			},
+			objects: []client.Object{registrySecret},
+			expect: &cloudinit.ContainerdInput{
+				RegistryCredentials: []cloudinit.ContainerdRegistryCredentials{
+					{Registry: "registry.example.com", Username: "user", Password: "password"},
+				},
+			},
+		},
+		"missing secret should fail": {
+			containerd: &bootstrapv1.ContainerdConfig{
+				RegistryAuths: []bootstrapv1.ContainerdRegistryAuth{
+					{Registry: "registry.example.com", SecretName: "registry"},
+				},
+			},
+			expectErr: true,
+		},
+		"secret without password should fail": {
+			containerd: &bootstrapv1.ContainerdConfig{
+				RegistryAuths: []bootstrapv1.ContainerdRegistryAuth{
+					{Registry: "registry.example.com", SecretName: "incomplete"},
+				},
+			},
+			objects:   []client.Object{incompleteSecret},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			k := &KubeadmConfigReconciler{
+				Client: fake.NewClientBuilder().WithObjects(tc.objects...).Build(),
+			}
+			cfg := newKubeadmConfig(metav1.NamespaceDefault, "cfg")
+			cfg.Spec.Containerd = tc.containerd
+
+			containerd, err := k.resolveContainerd(ctx, cfg)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(containerd).To(Equal(tc.expect))
+		})
+	}
+}
+
 // test utils.
 
 // newWorkerMachineForCluster returns a Machine with the passed Cluster's information and a pre-configured name.
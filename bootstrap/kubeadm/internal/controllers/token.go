@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
 	bootstraputil "k8s.io/cluster-bootstrap/token/util"
@@ -97,10 +98,14 @@ func refreshToken(ctx context.Context, c client.Client, token string, ttl time.D
 	return c.Update(ctx, secret)
 }
 
-// shouldRotate returns true if an existing token is past half of its TTL and should to be rotated.
+// shouldRotate returns true if an existing token is past half of its TTL and should to be rotated,
+// or if the token does not exist anymore, e.g. because it expired and was deleted by the token cleaner.
 func shouldRotate(ctx context.Context, c client.Client, token string, ttl time.Duration) (bool, error) {
 	secret, err := getToken(ctx, c, token)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
 		return false, err
 	}
 
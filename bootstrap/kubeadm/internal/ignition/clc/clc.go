@@ -125,6 +125,24 @@ systemd:
         [Install]
         WantedBy=multi-user.target
     {{- end }}
+    {{- range .SystemdUnits }}
+    - name: {{ .Name }}
+      {{- with .Enabled }}
+      enabled: {{ . }}
+      {{- end }}
+      {{- if .Content }}
+      contents: |
+        {{ .Content | Indent 8 }}
+      {{- end }}
+      {{- if .DropIns }}
+      dropins:
+        {{- range .DropIns }}
+        - name: {{ .Name }}
+          contents: |
+            {{ .Content | Indent 12 }}
+        {{- end }}
+      {{- end }}
+    {{- end }}
 storage:
   {{- if .DiskSetup }}{{- if .DiskSetup.Partitions }}
   disks:
@@ -558,6 +558,89 @@ func TestRender(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "systemd units",
+			input: &cloudinit.BaseUserData{
+				KubeadmCommand: "kubeadm join",
+				SystemdUnits: []bootstrapv1.SystemdUnit{
+					{
+						Name:    "my-agent.service",
+						Enabled: pointer.BoolPtr(true),
+						Content: "[Service]\nExecStart=/usr/bin/my-agent\n[Install]\nWantedBy=multi-user.target",
+					},
+					{
+						Name: "containerd.service",
+						DropIns: []bootstrapv1.SystemdUnitDropIn{
+							{Name: "10-limits.conf", Content: "[Service]\nLimitNOFILE=1048576"},
+						},
+					},
+					{
+						Name:    "unattended-upgrades.service",
+						Enabled: pointer.BoolPtr(false),
+					},
+				},
+			},
+			wantIgnition: types.Config{
+				Ignition: types.Ignition{
+					Version: "2.3.0",
+				},
+				Storage: types.Storage{
+					Files: []types.File{
+						{
+							Node: types.Node{
+								Filesystem: "root",
+								Path:       "/etc/kubeadm.sh",
+							},
+							FileEmbedded1: types.FileEmbedded1{
+								Contents: types.FileContents{
+									Source: "data:,%23!%2Fbin%2Fbash%0Aset%20-e%0A%0A%0Akubeadm%20join%0Amkdir%20-p%20%2Frun%2Fcluster-api%20%26%26%20echo%20success%20%3E%20%2Frun%2Fcluster-api%2Fbootstrap-success.complete%0Amv%20%2Fetc%2Fkubeadm.yml%20%2Ftmp%2F%0A",
+								},
+								Mode: pointer.IntPtr(448),
+							},
+						},
+						{
+							Node: types.Node{
+								Filesystem: "root",
+								Path:       "/etc/kubeadm.yml",
+							},
+							FileEmbedded1: types.FileEmbedded1{
+								Contents: types.FileContents{
+									Source: "data:,---%0Afoo%0A",
+								},
+								Mode: pointer.IntPtr(384),
+							},
+						},
+					},
+				},
+				Systemd: types.Systemd{
+					Units: []types.Unit{
+						{
+							Contents: "[Unit]\nDescription=kubeadm\n# Run only once. After successful run, this file is moved to /tmp/.\nConditionPathExists=/etc/kubeadm.yml\n[Service]\n# To not restart the unit when it exits, as it is expected.\nType=oneshot\nExecStart=/etc/kubeadm.sh\n[Install]\nWantedBy=multi-user.target\n",
+							Enabled:  pointer.BoolPtr(true),
+							Name:     "kubeadm.service",
+						},
+						{
+							Contents: "[Service]\nExecStart=/usr/bin/my-agent\n[Install]\nWantedBy=multi-user.target\n",
+							Enabled:  pointer.BoolPtr(true),
+							Name:     "my-agent.service",
+						},
+						{
+							Dropins: []types.SystemdDropin{
+								{
+									Contents: "[Service]\nLimitNOFILE=1048576\n",
+									Name:     "10-limits.conf",
+								},
+							},
+							Name: "containerd.service",
+						},
+						{
+							Enabled: pointer.BoolPtr(false),
+							Name:    "unattended-upgrades.service",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tc {
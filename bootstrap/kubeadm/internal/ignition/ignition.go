@@ -19,11 +19,21 @@ limitations under the License.
 package ignition
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
+	"strings"
+
+	ignitionTypes "github.com/flatcar-linux/ignition/config/v2_3/types"
+	"github.com/pkg/errors"
+	"github.com/vincent-petithory/dataurl"
+	"k8s.io/utils/pointer"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/clc"
+	v3 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/v3"
 )
 
 const (
@@ -64,6 +74,9 @@ func NewNode(input *NodeInput) ([]byte, string, error) {
 	}
 
 	input.WriteFiles = append(input.WriteFiles, input.AdditionalFiles...)
+	if err := input.AddContainerdConfig(); err != nil {
+		return nil, "", err
+	}
 	input.KubeadmCommand = fmt.Sprintf(kubeadmCommandTemplate, joinSubcommand, input.KubeadmVerbosity)
 
 	return render(&input.BaseUserData, input.Ignition, input.JoinConfiguration)
@@ -81,6 +94,9 @@ func NewJoinControlPlane(input *ControlPlaneJoinInput) ([]byte, string, error) {
 
 	input.WriteFiles = input.Certificates.AsFiles()
 	input.WriteFiles = append(input.WriteFiles, input.AdditionalFiles...)
+	if err := input.AddContainerdConfig(); err != nil {
+		return nil, "", err
+	}
 	input.KubeadmCommand = fmt.Sprintf(kubeadmCommandTemplate, joinSubcommand, input.KubeadmVerbosity)
 
 	return render(&input.BaseUserData, input.Ignition, input.JoinConfiguration)
@@ -98,6 +114,9 @@ func NewInitControlPlane(input *ControlPlaneInput) ([]byte, string, error) {
 
 	input.WriteFiles = input.Certificates.AsFiles()
 	input.WriteFiles = append(input.WriteFiles, input.AdditionalFiles...)
+	if err := input.AddContainerdConfig(); err != nil {
+		return nil, "", err
+	}
 	input.KubeadmCommand = fmt.Sprintf(kubeadmCommandTemplate, initSubcommand, input.KubeadmVerbosity)
 
 	kubeadmConfig := fmt.Sprintf("%s\n---\n%s", input.ClusterConfiguration, input.InitConfiguration)
@@ -106,6 +125,11 @@ func NewInitControlPlane(input *ControlPlaneInput) ([]byte, string, error) {
 }
 
 func render(input *cloudinit.BaseUserData, ignitionConfig *bootstrapv1.IgnitionSpec, kubeadmConfig string) ([]byte, string, error) {
+	if ignitionConfig != nil && ignitionConfig.Version.IsV3() {
+		userData, err := v3.Render(input, ignitionConfig.Version, kubeadmConfig)
+		return userData, "", err
+	}
+
 	clcConfig := &bootstrapv1.ContainerLinuxConfig{}
 	if ignitionConfig != nil && ignitionConfig.ContainerLinuxConfig != nil {
 		clcConfig = ignitionConfig.ContainerLinuxConfig
@@ -113,3 +137,105 @@ func render(input *cloudinit.BaseUserData, ignitionConfig *bootstrapv1.IgnitionS
 
 	return clc.Render(input, clcConfig, kubeadmConfig)
 }
+
+// Compress compresses the contents of the files in the given Ignition configuration using Ignition's
+// native gzip compression. Files which are fetched from remote sources, already compressed, or which
+// would not shrink are left untouched.
+func Compress(userData []byte) ([]byte, error) {
+	version := struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+	}{}
+	if err := json.Unmarshal(userData, &version); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Ignition config")
+	}
+	if strings.HasPrefix(version.Ignition.Version, "3.") {
+		return compressV3(userData)
+	}
+
+	ign := ignitionTypes.Config{}
+	if err := json.Unmarshal(userData, &ign); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Ignition config")
+	}
+
+	for i := range ign.Storage.Files {
+		contents := &ign.Storage.Files[i].Contents
+		if contents.Compression != "" || contents.Source == "" {
+			continue
+		}
+
+		source, ok, err := compressSource(contents.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compress file %q", ign.Storage.Files[i].Path)
+		}
+		if !ok {
+			continue
+		}
+		contents.Source = source
+		contents.Compression = "gzip"
+	}
+
+	out, err := json.Marshal(&ign)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal Ignition config into JSON")
+	}
+	return out, nil
+}
+
+func compressV3(userData []byte) ([]byte, error) {
+	ign := v3.Config{}
+	if err := json.Unmarshal(userData, &ign); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Ignition config")
+	}
+
+	for i := range ign.Storage.Files {
+		contents := ign.Storage.Files[i].Contents
+		if contents == nil || contents.Compression != nil || contents.Source == nil {
+			continue
+		}
+
+		source, ok, err := compressSource(*contents.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compress file %q", ign.Storage.Files[i].Path)
+		}
+		if !ok {
+			continue
+		}
+		contents.Source = &source
+		contents.Compression = pointer.String("gzip")
+	}
+
+	out, err := json.Marshal(&ign)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal Ignition config into JSON")
+	}
+	return out, nil
+}
+
+// compressSource returns the gzip-compressed data URL for the given data URL source, if it is smaller.
+func compressSource(source string) (string, bool, error) {
+	data, err := dataurl.DecodeString(source)
+	if err != nil {
+		// Not a data URL, e.g. a remote source which can't be compressed.
+		return "", false, nil
+	}
+
+	var compressed bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to create gzip writer")
+	}
+	if _, err := gz.Write(data.Data); err != nil {
+		return "", false, err
+	}
+	if err := gz.Close(); err != nil {
+		return "", false, err
+	}
+
+	compressedSource := dataurl.New(compressed.Bytes(), "application/gzip").String()
+	if len(compressedSource) >= len(source) {
+		return "", false, nil
+	}
+	return compressedSource, true, nil
+}
@@ -17,15 +17,22 @@ limitations under the License.
 package ignition_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
 	"testing"
 
+	ignitionTypes "github.com/flatcar-linux/ignition/config/v2_3/types"
+	"github.com/vincent-petithory/dataurl"
+
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition"
+	v3 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/v3"
 )
 
 const testString = "foo bar baz"
@@ -384,3 +391,161 @@ func Test_NewInitControlPlane(t *testing.T) {
 		}
 	})
 }
+
+func Test_Compress(t *testing.T) {
+	t.Parallel()
+
+	largeContent := strings.Repeat(testString+"\n", 100)
+
+	input := &ignition.NodeInput{
+		NodeInput: &cloudinit.NodeInput{
+			BaseUserData: cloudinit.BaseUserData{
+				AdditionalFiles: []bootstrapv1.File{
+					{
+						Path:        "/etc/large",
+						Permissions: "0644",
+						Content:     largeContent,
+					},
+					{
+						Path:        "/etc/small",
+						Permissions: "0644",
+						Content:     "a",
+					},
+				},
+			},
+		},
+		Ignition: &bootstrapv1.IgnitionSpec{},
+	}
+
+	ignitionData, _, err := ignition.NewNode(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	compressed, err := ignition.Compress(ignitionData)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(compressed) >= len(ignitionData) {
+		t.Fatalf("Expected compressed data to be smaller than %d bytes, got %d bytes", len(ignitionData), len(compressed))
+	}
+
+	ign := ignitionTypes.Config{}
+	if err := json.Unmarshal(compressed, &ign); err != nil {
+		t.Fatalf("Unmarshaling compressed Ignition config: %v", err)
+	}
+
+	files := map[string]ignitionTypes.File{}
+	for _, f := range ign.Storage.Files {
+		files[f.Path] = f
+	}
+
+	large, ok := files["/etc/large"]
+	if !ok {
+		t.Fatalf("Expected file %q to exist", "/etc/large")
+	}
+	if large.Contents.Compression != "gzip" {
+		t.Fatalf("Expected file %q to be gzip compressed, got compression %q", large.Path, large.Contents.Compression)
+	}
+	data, err := dataurl.DecodeString(large.Contents.Source)
+	if err != nil {
+		t.Fatalf("Decoding file source: %v", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data.Data))
+	if err != nil {
+		t.Fatalf("Creating gzip reader: %v", err)
+	}
+	content, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("Decompressing file content: %v", err)
+	}
+	if string(content) != largeContent {
+		t.Fatalf("Expected decompressed content %q, got %q", largeContent, string(content))
+	}
+
+	small, ok := files["/etc/small"]
+	if !ok {
+		t.Fatalf("Expected file %q to exist", "/etc/small")
+	}
+	if small.Contents.Compression != "" {
+		t.Fatalf("Expected file %q not to be compressed, got compression %q", small.Path, small.Contents.Compression)
+	}
+}
+
+func Test_CompressV3(t *testing.T) {
+	t.Parallel()
+
+	largeContent := strings.Repeat(testString+"\n", 100)
+
+	input := &ignition.NodeInput{
+		NodeInput: &cloudinit.NodeInput{
+			BaseUserData: cloudinit.BaseUserData{
+				AdditionalFiles: []bootstrapv1.File{
+					{
+						Path:    "/etc/large",
+						Content: largeContent,
+					},
+					{
+						Path:    "/etc/small",
+						Content: "a",
+					},
+				},
+			},
+		},
+		Ignition: &bootstrapv1.IgnitionSpec{
+			Version: bootstrapv1.IgnitionVersion3_3,
+		},
+	}
+
+	ignitionData, _, err := ignition.NewNode(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	compressed, err := ignition.Compress(ignitionData)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ign := v3.Config{}
+	if err := json.Unmarshal(compressed, &ign); err != nil {
+		t.Fatalf("Unmarshaling compressed Ignition config: %v", err)
+	}
+	if ign.Ignition.Version != "3.3.0" {
+		t.Fatalf("Expected Ignition version to be preserved, got %q", ign.Ignition.Version)
+	}
+
+	files := map[string]v3.File{}
+	for _, f := range ign.Storage.Files {
+		files[f.Path] = f
+	}
+
+	large := files["/etc/large"]
+	if large.Contents == nil || large.Contents.Compression == nil || *large.Contents.Compression != "gzip" {
+		t.Fatalf("Expected file %q to be gzip compressed, got %+v", "/etc/large", large.Contents)
+	}
+	if large.Overwrite == nil || !*large.Overwrite {
+		t.Fatalf("Expected file %q to keep overwrite set", "/etc/large")
+	}
+	data, err := dataurl.DecodeString(*large.Contents.Source)
+	if err != nil {
+		t.Fatalf("Decoding file source: %v", err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data.Data))
+	if err != nil {
+		t.Fatalf("Creating gzip reader: %v", err)
+	}
+	content, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("Decompressing file content: %v", err)
+	}
+	if string(content) != largeContent {
+		t.Fatalf("Expected decompressed content %q, got %q", largeContent, string(content))
+	}
+
+	small := files["/etc/small"]
+	if small.Contents == nil || small.Contents.Compression != nil {
+		t.Fatalf("Expected file %q not to be compressed, got %+v", "/etc/small", small.Contents)
+	}
+}
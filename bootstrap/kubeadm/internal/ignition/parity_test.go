@@ -0,0 +1,424 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ignition_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vincent-petithory/dataurl"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition"
+	v3 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/v3"
+)
+
+// cloudConfig is the subset of the cloud-config format generated by the cloudinit package.
+type cloudConfig struct {
+	WriteFiles []struct {
+		Path        string `json:"path"`
+		Owner       string `json:"owner"`
+		Permissions string `json:"permissions"`
+		Encoding    string `json:"encoding"`
+		Append      bool   `json:"append"`
+		Content     string `json:"content"`
+	} `json:"write_files"`
+	RunCmd []string `json:"runcmd"`
+	NTP    *struct {
+		Enabled bool     `json:"enabled"`
+		Servers []string `json:"servers"`
+	} `json:"ntp"`
+	Users []struct {
+		Name              string   `json:"name"`
+		Passwd            *string  `json:"passwd"`
+		Gecos             *string  `json:"gecos"`
+		Groups            *string  `json:"groups"`
+		HomeDir           *string  `json:"homedir"`
+		Shell             *string  `json:"shell"`
+		PrimaryGroup      *string  `json:"primary_group"`
+		Sudo              *string  `json:"sudo"`
+		SSHAuthorizedKeys []string `json:"ssh_authorized_keys"`
+	} `json:"users"`
+	DiskSetup map[string]struct {
+		Layout    bool  `json:"layout"`
+		Overwrite *bool `json:"overwrite"`
+	} `json:"disk_setup"`
+	FSSetup []struct {
+		Label      string   `json:"label"`
+		Filesystem string   `json:"filesystem"`
+		Device     string   `json:"device"`
+		Overwrite  *bool    `json:"overwrite"`
+		ExtraOpts  []string `json:"extra_opts"`
+	} `json:"fs_setup"`
+	Mounts [][]string `json:"mounts"`
+}
+
+// file is the normalized representation of a file, used to compare the files of both formats.
+type file struct {
+	Owner   string
+	Mode    string
+	Append  bool
+	Content string
+}
+
+// parityUserData returns the same user data for both formats, as the renderers mutate their input.
+func parityUserData() cloudinit.BaseUserData {
+	return cloudinit.BaseUserData{
+		PreKubeadmCommands:  []string{"echo pre", "hostnamectl set-hostname node"},
+		PostKubeadmCommands: []string{"echo post"},
+		AdditionalFiles: []bootstrapv1.File{
+			{
+				Path:        "/etc/kubernetes/cloud.json",
+				Owner:       "root:root",
+				Permissions: "0644",
+				Content:     "{\n  \"cloud\": \"CustomCloud\"\n}",
+			},
+			{
+				Path:        "/etc/binary",
+				Owner:       "nobody",
+				Permissions: "0600",
+				Encoding:    bootstrapv1.Base64,
+				Content:     base64.StdEncoding.EncodeToString([]byte{0, 1, 2, 255}),
+			},
+			{
+				Path:    "/etc/environment",
+				Append:  true,
+				Content: "HTTPS_PROXY=http://proxy.example.com:3128\n",
+			},
+		},
+		Users: []bootstrapv1.User{
+			{
+				Name:              "capi",
+				Gecos:             pointer.String("Cluster API"),
+				Groups:            pointer.String("docker, wheel"),
+				HomeDir:           pointer.String("/home/capi"),
+				Shell:             pointer.String("/bin/bash"),
+				Passwd:            pointer.String("hash"),
+				PrimaryGroup:      pointer.String("capi"),
+				Sudo:              pointer.String("ALL=(ALL) NOPASSWD:ALL"),
+				SSHAuthorizedKeys: []string{"ssh-rsa key"},
+			},
+		},
+		NTP: &bootstrapv1.NTP{
+			Enabled: pointer.Bool(true),
+			Servers: []string{"0.pool.ntp.org", "1.pool.ntp.org"},
+		},
+		DiskSetup: &bootstrapv1.DiskSetup{
+			Partitions: []bootstrapv1.Partition{
+				{Device: "/dev/sdb", Layout: true, Overwrite: pointer.Bool(true)},
+			},
+			Filesystems: []bootstrapv1.Filesystem{
+				{Device: "/dev/sdb1", Filesystem: "ext4", Label: "etcd_disk", Overwrite: pointer.Bool(true), ExtraOpts: []string{"-E", "lazy_itable_init=1"}},
+			},
+		},
+		Mounts: []bootstrapv1.MountPoints{
+			{"etcd_disk", "/var/lib/etcddisk"},
+		},
+		Containerd: &cloudinit.ContainerdInput{
+			SandboxImage: "registry.k8s.io/pause:3.6",
+		},
+		SystemdUnits: []bootstrapv1.SystemdUnit{
+			{
+				Name:    "my-agent.service",
+				Enabled: pointer.Bool(true),
+				Content: "[Service]\nExecStart=/usr/bin/my-agent\n[Install]\nWantedBy=multi-user.target",
+			},
+			{
+				Name:    "containerd.service",
+				DropIns: []bootstrapv1.SystemdUnitDropIn{{Name: "10-limits.conf", Content: "[Service]\nLimitNOFILE=1048576"}},
+			},
+			{
+				Name:    "unattended-upgrades.service",
+				Enabled: pointer.Bool(false),
+			},
+		},
+	}
+}
+
+// Test_IgnitionV3CloudInitParity verifies that the Ignition v3 configuration sets up the machine in the same way
+// as the cloud-config generated from the same input: the differences are limited to how each format runs kubeadm
+// and to features which are native in one format and implemented with files or commands in the other.
+func Test_IgnitionV3CloudInitParity(t *testing.T) {
+	t.Parallel()
+
+	cloudInitData, err := cloudinit.NewNode(&cloudinit.NodeInput{
+		BaseUserData:      parityUserData(),
+		JoinConfiguration: "join-config",
+	})
+	if err != nil {
+		t.Fatalf("Rendering cloud-config: %v", err)
+	}
+	cc := cloudConfig{}
+	if err := yaml.Unmarshal(cloudInitData, &cc); err != nil {
+		t.Fatalf("Parsing generated cloud-config: %v", err)
+	}
+
+	ignitionData, _, err := ignition.NewNode(&ignition.NodeInput{
+		NodeInput: &cloudinit.NodeInput{
+			BaseUserData:      parityUserData(),
+			JoinConfiguration: "join-config",
+		},
+		Ignition: &bootstrapv1.IgnitionSpec{Version: bootstrapv1.IgnitionVersion3_3},
+	})
+	if err != nil {
+		t.Fatalf("Rendering Ignition: %v", err)
+	}
+	ign := v3.Config{}
+	if err := json.Unmarshal(ignitionData, &ign); err != nil {
+		t.Fatalf("Parsing generated Ignition: %v", err)
+	}
+
+	t.Run("files", func(t *testing.T) {
+		cloudInitFiles := map[string]file{}
+		for _, f := range cc.WriteFiles {
+			// kubeadm configuration and systemd units are handled differently in each format and are compared separately.
+			if strings.HasPrefix(f.Path, "/run/kubeadm/") || strings.HasPrefix(f.Path, "/run/cluster-api/") || strings.HasPrefix(f.Path, "/etc/systemd/system/") {
+				continue
+			}
+			content := f.Content
+			if f.Encoding == "base64" {
+				decoded, err := base64.StdEncoding.DecodeString(f.Content)
+				if err != nil {
+					t.Fatalf("Decoding content of file %q: %v", f.Path, err)
+				}
+				content = string(decoded)
+			}
+			cloudInitFiles[f.Path] = file{Owner: f.Owner, Mode: f.Permissions, Append: f.Append, Content: content}
+		}
+
+		ignitionFiles := map[string]file{}
+		for _, f := range ign.Storage.Files {
+			// kubeadm configuration, users and NTP are handled differently in each format and are compared separately.
+			if strings.HasPrefix(f.Path, "/etc/kubeadm.") || strings.HasPrefix(f.Path, "/etc/sudoers.d/") || f.Path == "/etc/ntp.conf" {
+				continue
+			}
+			out := file{}
+			if f.User != nil && f.User.Name != nil {
+				out.Owner = *f.User.Name
+			}
+			if f.Group != nil && f.Group.Name != nil {
+				out.Owner += ":" + *f.Group.Name
+			}
+			if f.Mode != nil {
+				out.Mode = fmt.Sprintf("%04o", *f.Mode)
+			}
+			resource := f.Contents
+			if len(f.Append) > 0 {
+				out.Append = true
+				resource = &f.Append[0]
+			}
+			out.Content = decodeSource(t, resource)
+			ignitionFiles[f.Path] = out
+		}
+
+		if diff := cmp.Diff(cloudInitFiles, ignitionFiles); diff != "" {
+			t.Fatalf("Files mismatch (-cloud-init +ignition):\n%s", diff)
+		}
+	})
+
+	t.Run("systemd units", func(t *testing.T) {
+		cloudInitUnits := map[string]string{}
+		for _, f := range cc.WriteFiles {
+			if strings.HasPrefix(f.Path, "/etc/systemd/system/") {
+				cloudInitUnits[strings.TrimPrefix(f.Path, "/etc/systemd/system/")] = f.Content
+			}
+		}
+		cloudInitEnabled := map[string]bool{}
+		for _, cmd := range cc.RunCmd {
+			if unit := strings.TrimPrefix(cmd, "systemctl enable --now "); unit != cmd {
+				cloudInitEnabled[unit] = true
+			}
+			if unit := strings.TrimPrefix(cmd, "systemctl disable --now "); unit != cmd {
+				cloudInitEnabled[unit] = false
+			}
+		}
+
+		ignitionUnits := map[string]string{}
+		ignitionEnabled := map[string]bool{}
+		for _, u := range ign.Systemd.Units {
+			// Units generated for kubeadm, NTP and mounts are specific to Ignition.
+			if u.Name == "kubeadm.service" || u.Name == "ntpd.service" || strings.HasSuffix(u.Name, ".mount") {
+				continue
+			}
+			if u.Contents != nil {
+				ignitionUnits[u.Name] = *u.Contents
+			}
+			for _, d := range u.Dropins {
+				ignitionUnits[path.Join(u.Name+".d", d.Name)] = *d.Contents
+			}
+			if u.Enabled != nil {
+				ignitionEnabled[u.Name] = *u.Enabled
+			}
+		}
+
+		if diff := cmp.Diff(cloudInitUnits, ignitionUnits); diff != "" {
+			t.Fatalf("Systemd units mismatch (-cloud-init +ignition):\n%s", diff)
+		}
+		if diff := cmp.Diff(cloudInitEnabled, ignitionEnabled); diff != "" {
+			t.Fatalf("Enabled systemd units mismatch (-cloud-init +ignition):\n%s", diff)
+		}
+	})
+
+	t.Run("commands", func(t *testing.T) {
+		var cloudInitCommands []string
+		for _, cmd := range cc.RunCmd {
+			// Systemd units are set up natively by Ignition.
+			if cmd == "systemctl daemon-reload" || strings.HasPrefix(cmd, "systemctl try-restart ") ||
+				strings.HasPrefix(cmd, "systemctl enable --now ") || strings.HasPrefix(cmd, "systemctl disable --now ") {
+				continue
+			}
+			if strings.HasPrefix(cmd, "kubeadm ") {
+				cmd = "kubeadm"
+			}
+			cloudInitCommands = append(cloudInitCommands, cmd)
+		}
+
+		var ignitionCommands []string
+		for _, f := range ign.Storage.Files {
+			if f.Path != "/etc/kubeadm.sh" {
+				continue
+			}
+			for _, line := range strings.Split(decodeSource(t, f.Contents), "\n") {
+				switch {
+				case line == "" || line == "#!/bin/bash" || line == "set -e":
+				case strings.HasPrefix(line, "mkdir -p /run/cluster-api") || strings.HasPrefix(line, "mv /etc/kubeadm.yml"):
+				case strings.HasPrefix(line, "kubeadm "):
+					ignitionCommands = append(ignitionCommands, "kubeadm")
+				default:
+					ignitionCommands = append(ignitionCommands, line)
+				}
+			}
+		}
+
+		if diff := cmp.Diff(cloudInitCommands, ignitionCommands); diff != "" {
+			t.Fatalf("Commands mismatch (-cloud-init +ignition):\n%s", diff)
+		}
+	})
+
+	t.Run("users", func(t *testing.T) {
+		if len(cc.Users) != len(ign.Passwd.Users) {
+			t.Fatalf("Expected %d users, got %d", len(cc.Users), len(ign.Passwd.Users))
+		}
+		for i, want := range cc.Users {
+			got := ign.Passwd.Users[i]
+			if diff := cmp.Diff(
+				[]interface{}{want.Name, want.Passwd, want.Gecos, strings.Split(*want.Groups, ", "), want.HomeDir, want.Shell, want.PrimaryGroup, want.SSHAuthorizedKeys},
+				[]interface{}{got.Name, got.PasswordHash, got.Gecos, got.Groups, got.HomeDir, got.Shell, got.PrimaryGroup, got.SSHAuthorizedKeys},
+			); diff != "" {
+				t.Fatalf("User mismatch (-cloud-init +ignition):\n%s", diff)
+			}
+
+			sudoers := ""
+			for _, f := range ign.Storage.Files {
+				if f.Path == "/etc/sudoers.d/"+got.Name {
+					sudoers = decodeSource(t, f.Contents)
+				}
+			}
+			if want := fmt.Sprintf("%s %s\n", want.Name, *want.Sudo); sudoers != want {
+				t.Fatalf("Expected sudoers %q, got %q", want, sudoers)
+			}
+		}
+	})
+
+	t.Run("ntp", func(t *testing.T) {
+		ntpConf := ""
+		for _, f := range ign.Storage.Files {
+			if f.Path == "/etc/ntp.conf" {
+				ntpConf = decodeSource(t, f.Contents)
+			}
+		}
+		for _, server := range cc.NTP.Servers {
+			if !strings.Contains(ntpConf, "\nserver "+server+"\n") {
+				t.Fatalf("Expected NTP server %q in %q", server, ntpConf)
+			}
+		}
+	})
+
+	t.Run("disks and filesystems", func(t *testing.T) {
+		cloudInitDisks := map[string]*bool{}
+		for device, disk := range cc.DiskSetup {
+			if !disk.Layout {
+				t.Fatalf("Expected disk %q to have a layout", device)
+			}
+			cloudInitDisks[device] = disk.Overwrite
+		}
+		ignitionDisks := map[string]*bool{}
+		for _, disk := range ign.Storage.Disks {
+			if len(disk.Partitions) != 1 {
+				t.Fatalf("Expected disk %q to have a single partition", disk.Device)
+			}
+			ignitionDisks[disk.Device] = disk.WipeTable
+		}
+		if diff := cmp.Diff(cloudInitDisks, ignitionDisks); diff != "" {
+			t.Fatalf("Disks mismatch (-cloud-init +ignition):\n%s", diff)
+		}
+
+		var cloudInitFilesystems, ignitionFilesystems []v3.Filesystem
+		for _, fs := range cc.FSSetup {
+			fs := fs
+			cloudInitFilesystems = append(cloudInitFilesystems, v3.Filesystem{
+				Device:         fs.Device,
+				Format:         &fs.Filesystem,
+				Label:          &fs.Label,
+				Options:        fs.ExtraOpts,
+				WipeFilesystem: fs.Overwrite,
+			})
+		}
+		ignitionFilesystems = append(ignitionFilesystems, ign.Storage.Filesystems...)
+		if diff := cmp.Diff(cloudInitFilesystems, ignitionFilesystems); diff != "" {
+			t.Fatalf("Filesystems mismatch (-cloud-init +ignition):\n%s", diff)
+		}
+	})
+
+	t.Run("mounts", func(t *testing.T) {
+		for _, mount := range cc.Mounts {
+			found := false
+			for _, u := range ign.Systemd.Units {
+				if u.Contents != nil && strings.HasSuffix(u.Name, ".mount") && strings.Contains(*u.Contents, "\nWhere="+mount[1]+"\n") {
+					found = true
+					if u.Enabled == nil || !*u.Enabled {
+						t.Fatalf("Expected mount unit %q to be enabled", u.Name)
+					}
+				}
+			}
+			if !found {
+				t.Fatalf("Expected a mount unit for %q", mount[1])
+			}
+		}
+	})
+}
+
+func decodeSource(t *testing.T, resource *v3.Resource) string {
+	t.Helper()
+
+	if resource == nil || resource.Source == nil {
+		return ""
+	}
+	data, err := dataurl.DecodeString(*resource.Source)
+	if err != nil {
+		t.Fatalf("Decoding source %q: %v", *resource.Source, err)
+	}
+	return string(data.Data)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+// The types below are the subset of the Ignition spec 3.x configuration used by the bootstrap provider.
+// All the fields are available since Ignition spec 3.1, so the same types can be used for all the
+// supported 3.x versions. More info: https://coreos.github.io/ignition/configuration-v3_3/
+
+// Config is the root of an Ignition configuration.
+type Config struct {
+	Ignition Ignition `json:"ignition"`
+	Passwd   Passwd   `json:"passwd"`
+	Storage  Storage  `json:"storage"`
+	Systemd  Systemd  `json:"systemd"`
+}
+
+// Ignition contains metadata about the configuration itself.
+type Ignition struct {
+	Version string `json:"version"`
+}
+
+// Passwd contains the users to be added to the system.
+type Passwd struct {
+	Users []PasswdUser `json:"users,omitempty"`
+}
+
+// PasswdUser defines a user to be added to the system.
+type PasswdUser struct {
+	Name              string   `json:"name"`
+	Gecos             *string  `json:"gecos,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	HomeDir           *string  `json:"homeDir,omitempty"`
+	PasswordHash      *string  `json:"passwordHash,omitempty"`
+	PrimaryGroup      *string  `json:"primaryGroup,omitempty"`
+	Shell             *string  `json:"shell,omitempty"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+}
+
+// Storage describes the desired state of the system's storage devices.
+type Storage struct {
+	Disks       []Disk       `json:"disks,omitempty"`
+	Files       []File       `json:"files,omitempty"`
+	Filesystems []Filesystem `json:"filesystems,omitempty"`
+}
+
+// Disk defines the partition table of a disk.
+type Disk struct {
+	Device     string      `json:"device"`
+	Partitions []Partition `json:"partitions,omitempty"`
+	WipeTable  *bool       `json:"wipeTable,omitempty"`
+}
+
+// Partition defines a partition of a disk.
+type Partition struct {
+	Label    *string `json:"label,omitempty"`
+	Number   int     `json:"number,omitempty"`
+	SizeMiB  *int    `json:"sizeMiB,omitempty"`
+	StartMiB *int    `json:"startMiB,omitempty"`
+}
+
+// Filesystem defines a filesystem to be created on a device.
+type Filesystem struct {
+	Device         string   `json:"device"`
+	Format         *string  `json:"format,omitempty"`
+	Label          *string  `json:"label,omitempty"`
+	Options        []string `json:"options,omitempty"`
+	WipeFilesystem *bool    `json:"wipeFilesystem,omitempty"`
+}
+
+// File defines a file to be written.
+type File struct {
+	Path      string     `json:"path"`
+	Overwrite *bool      `json:"overwrite,omitempty"`
+	User      *NodeUser  `json:"user,omitempty"`
+	Group     *NodeGroup `json:"group,omitempty"`
+	Append    []Resource `json:"append,omitempty"`
+	Contents  *Resource  `json:"contents,omitempty"`
+	Mode      *int       `json:"mode,omitempty"`
+}
+
+// NodeUser defines the owner of a file.
+type NodeUser struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// NodeGroup defines the group of a file.
+type NodeGroup struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Resource defines the source of the contents of a file.
+type Resource struct {
+	Compression *string `json:"compression,omitempty"`
+	Source      *string `json:"source,omitempty"`
+}
+
+// Systemd describes the desired state of the systemd units.
+type Systemd struct {
+	Units []Unit `json:"units,omitempty"`
+}
+
+// Unit defines a systemd unit.
+type Unit struct {
+	Name     string   `json:"name"`
+	Contents *string  `json:"contents,omitempty"`
+	Dropins  []Dropin `json:"dropins,omitempty"`
+	Enabled  *bool    `json:"enabled,omitempty"`
+}
+
+// Dropin defines a drop-in for a systemd unit.
+type Dropin struct {
+	Name     string  `json:"name"`
+	Contents *string `json:"contents,omitempty"`
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v3 generates bootstrap data in Ignition spec 3.x format, as consumed by Fedora CoreOS, RHCOS
+// and recent Flatcar Container Linux releases.
+//
+// Unlike the clc package, the Ignition configuration is generated natively, without going through
+// Container Linux Config, but the resulting machine setup is the same: kubeadm is run by the
+// /etc/kubeadm.sh script from the kubeadm.service systemd unit, and /etc/kubeadm.yml contains the
+// generated kubeadm configuration.
+package v3
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/vincent-petithory/dataurl"
+	"k8s.io/utils/pointer"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+const (
+	kubeadmScriptTemplate = `#!/bin/bash
+set -e
+{{ range .PreKubeadmCommands }}
+{{ . }}
+{{- end }}
+
+{{ .KubeadmCommand }}
+mkdir -p /run/cluster-api && echo success > /run/cluster-api/bootstrap-success.complete
+mv /etc/kubeadm.yml /tmp/
+{{range .PostKubeadmCommands }}
+{{ . }}
+{{- end }}
+`
+
+	kubeadmUnit = `[Unit]
+Description=kubeadm
+# Run only once. After successful run, this file is moved to /tmp/.
+ConditionPathExists=/etc/kubeadm.yml
+[Service]
+# To not restart the unit when it exits, as it is expected.
+Type=oneshot
+ExecStart=/etc/kubeadm.sh
+[Install]
+WantedBy=multi-user.target
+`
+
+	mountUnitTemplate = `[Unit]
+Description = Mount {{ .Label }}
+
+[Mount]
+What={{ .Device }}
+Where={{ .Mountpoint }}
+Options={{ Join .Options "," }}
+
+[Install]
+WantedBy=multi-user.target
+`
+
+	sshdConfigTemplate = `# Use most defaults for sshd configuration.
+Subsystem sftp internal-sftp
+ClientAliveInterval 180
+UseDNS no
+UsePAM yes
+PrintLastLog no # handled by PAM
+PrintMotd no # handled by PAM
+
+Match User {{ . }}
+  PasswordAuthentication yes
+`
+
+	ntpConfigTemplate = `# Common pool
+{{- range . }}
+server {{ . }}
+{{- end }}
+
+# Warning: Using default NTP settings will leave your NTP
+# server accessible to all hosts on the Internet.
+
+# If you want to deny all machines (including your own)
+# from accessing the NTP server, uncomment:
+#restrict default ignore
+
+# Default configuration:
+# - Allow only time queries, at a limited rate, sending KoD when in excess.
+# - Allow all local queries (IPv4, IPv6)
+restrict default nomodify nopeer noquery notrap limited kod
+restrict 127.0.0.1
+restrict [::1]
+`
+)
+
+var templateFuncs = template.FuncMap{
+	"Join": strings.Join,
+}
+
+// Render renders the provided user data into an Ignition configuration of the given spec 3.x version.
+func Render(input *cloudinit.BaseUserData, version bootstrapv1.IgnitionVersion, kubeadmConfig string) ([]byte, error) {
+	if input == nil {
+		return nil, errors.New("empty base user data")
+	}
+	if !version.IsV3() {
+		return nil, errors.Errorf("unsupported Ignition version %q", version)
+	}
+
+	config := Config{
+		Ignition: Ignition{Version: string(version) + ".0"},
+	}
+
+	config.Passwd.Users = users(input.Users)
+
+	files, err := files(input, kubeadmConfig)
+	if err != nil {
+		return nil, err
+	}
+	config.Storage.Files = files
+
+	if input.DiskSetup != nil {
+		config.Storage.Disks = disks(input.DiskSetup.Partitions)
+		config.Storage.Filesystems = filesystems(input.DiskSetup.Filesystems)
+	}
+
+	units, err := units(input)
+	if err != nil {
+		return nil, err
+	}
+	config.Systemd.Units = units
+
+	userData, err := json.Marshal(&config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal Ignition config into JSON")
+	}
+	return userData, nil
+}
+
+func users(in []bootstrapv1.User) []PasswdUser {
+	var out []PasswdUser
+	for _, user := range in {
+		u := PasswdUser{
+			Name:              user.Name,
+			Gecos:             user.Gecos,
+			HomeDir:           user.HomeDir,
+			PasswordHash:      user.Passwd,
+			PrimaryGroup:      user.PrimaryGroup,
+			Shell:             user.Shell,
+			SSHAuthorizedKeys: user.SSHAuthorizedKeys,
+		}
+		if user.Groups != nil {
+			u.Groups = strings.Split(*user.Groups, ", ")
+		}
+		out = append(out, u)
+	}
+	return out
+}
+
+func files(input *cloudinit.BaseUserData, kubeadmConfig string) ([]File, error) {
+	var out []File
+
+	usersWithPasswordAuth := []string{}
+	for _, user := range input.Users {
+		if user.Sudo != nil {
+			out = append(out, inlineFile(fmt.Sprintf("/etc/sudoers.d/%s", user.Name), 0600, fmt.Sprintf("%s %s", user.Name, *user.Sudo)))
+		}
+		if user.LockPassword != nil && !*user.LockPassword {
+			usersWithPasswordAuth = append(usersWithPasswordAuth, user.Name)
+		}
+	}
+	if len(usersWithPasswordAuth) > 0 {
+		sshdConfig, err := execute(sshdConfigTemplate, strings.Join(usersWithPasswordAuth, ","))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inlineFile("/etc/ssh/sshd_config", 0600, sshdConfig))
+	}
+
+	for _, f := range input.WriteFiles {
+		file, err := writeFile(f)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, file)
+	}
+
+	kubeadmScript, err := execute(kubeadmScriptTemplate, input)
+	if err != nil {
+		return nil, err
+	}
+	out = append(out,
+		inlineFile("/etc/kubeadm.sh", 0700, kubeadmScript),
+		inlineFile("/etc/kubeadm.yml", 0600, "---\n"+kubeadmConfig),
+	)
+
+	if input.NTP != nil && input.NTP.Enabled != nil && *input.NTP.Enabled && len(input.NTP.Servers) > 0 {
+		ntpConfig, err := execute(ntpConfigTemplate, input.NTP.Servers)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, inlineFile("/etc/ntp.conf", 0644, ntpConfig))
+	}
+
+	return out, nil
+}
+
+func writeFile(in bootstrapv1.File) (File, error) {
+	var data []byte
+	switch in.Encoding {
+	case "":
+		data = []byte(normalizeContent(in.Content))
+	case bootstrapv1.Base64:
+		decoded, err := base64.StdEncoding.DecodeString(in.Content)
+		if err != nil {
+			return File{}, errors.Wrapf(err, "failed to decode content of file %q", in.Path)
+		}
+		data = decoded
+	default:
+		return File{}, errors.Errorf("encoding %q of file %q is not supported", in.Encoding, in.Path)
+	}
+
+	source := dataURL(data)
+	out := File{Path: in.Path}
+	if in.Append {
+		out.Append = []Resource{{Source: &source}}
+	} else {
+		out.Overwrite = pointer.Bool(true)
+		out.Contents = &Resource{Source: &source}
+	}
+
+	user, group := parseOwner(in.Owner)
+	if user != nil {
+		out.User = &NodeUser{Name: user}
+	}
+	if group != nil {
+		out.Group = &NodeGroup{Name: group}
+	}
+
+	if in.Permissions != "" {
+		mode, err := strconv.ParseInt(in.Permissions, 8, 32)
+		if err != nil {
+			return File{}, errors.Wrapf(err, "failed to parse permissions of file %q", in.Path)
+		}
+		out.Mode = pointer.Int(int(mode))
+	}
+
+	return out, nil
+}
+
+func inlineFile(path string, mode int, content string) File {
+	source := dataURL([]byte(normalizeContent(content)))
+	return File{
+		Path:      path,
+		Overwrite: pointer.Bool(true),
+		Contents:  &Resource{Source: &source},
+		Mode:      pointer.Int(mode),
+	}
+}
+
+func disks(partitions []bootstrapv1.Partition) []Disk {
+	var out []Disk
+	for _, partition := range partitions {
+		disk := Disk{
+			Device:    partition.Device,
+			WipeTable: partition.Overwrite,
+		}
+		if partition.Layout {
+			// A single partition spanning the whole disk.
+			disk.Partitions = []Partition{{}}
+		}
+		out = append(out, disk)
+	}
+	return out
+}
+
+func filesystems(in []bootstrapv1.Filesystem) []Filesystem {
+	var out []Filesystem
+	for _, fs := range in {
+		out = append(out, Filesystem{
+			Device:         fs.Device,
+			Format:         pointer.String(fs.Filesystem),
+			Label:          pointer.String(fs.Label),
+			Options:        fs.ExtraOpts,
+			WipeFilesystem: fs.Overwrite,
+		})
+	}
+	return out
+}
+
+func units(input *cloudinit.BaseUserData) ([]Unit, error) {
+	out := []Unit{
+		{
+			Name:     "kubeadm.service",
+			Enabled:  pointer.Bool(true),
+			Contents: pointer.String(kubeadmUnit),
+		},
+	}
+
+	if input.NTP != nil && input.NTP.Enabled != nil && *input.NTP.Enabled {
+		out = append(out, Unit{
+			Name:    "ntpd.service",
+			Enabled: pointer.Bool(true),
+		})
+	}
+
+	filesystemDevicesByLabel := map[string]string{}
+	if input.DiskSetup != nil {
+		for _, filesystem := range input.DiskSetup.Filesystems {
+			filesystemDevicesByLabel[filesystem.Label] = filesystem.Device
+		}
+	}
+	for _, mount := range input.Mounts {
+		if len(mount) < 2 {
+			return nil, errors.Errorf("mount %v must specify a filesystem label and a mount point", mount)
+		}
+		contents, err := execute(mountUnitTemplate, map[string]interface{}{
+			"Label":      mount[0],
+			"Device":     filesystemDevicesByLabel[mount[0]],
+			"Mountpoint": mount[1],
+			"Options":    []string(mount[2:]),
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Unit{
+			Name:     mountpointName(mount[1]) + ".mount",
+			Enabled:  pointer.Bool(true),
+			Contents: pointer.String(contents),
+		})
+	}
+
+	for _, unit := range input.SystemdUnits {
+		u := Unit{
+			Name:    unit.Name,
+			Enabled: unit.Enabled,
+		}
+		if unit.Content != "" {
+			u.Contents = pointer.String(normalizeContent(unit.Content))
+		}
+		for _, dropIn := range unit.DropIns {
+			u.Dropins = append(u.Dropins, Dropin{
+				Name:     dropIn.Name,
+				Contents: pointer.String(normalizeContent(dropIn.Content)),
+			})
+		}
+		out = append(out, u)
+	}
+
+	return out, nil
+}
+
+func execute(tpl string, data interface{}) (string, error) {
+	t := template.Must(template.New("template").Funcs(templateFuncs).Parse(tpl))
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", errors.Wrap(err, "failed to render template")
+	}
+	return out.String(), nil
+}
+
+// normalizeContent ensures the content ends with a single newline, consistently with the cloud-config
+// and Container Linux Config formats, which embed content as YAML literal blocks.
+func normalizeContent(content string) string {
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return ""
+	}
+	return content + "\n"
+}
+
+func dataURL(data []byte) string {
+	return "data:," + dataurl.Escape(data)
+}
+
+func mountpointName(name string) string {
+	return strings.TrimPrefix(strings.ReplaceAll(name, "/", "-"), "-")
+}
+
+func parseOwner(owner string) (*string, *string) {
+	if owner == "" {
+		return nil, nil
+	}
+
+	parseEntity := func(entity string) *string {
+		entity = strings.TrimSpace(entity)
+		if entity == "" {
+			return nil
+		}
+		return &entity
+	}
+
+	ownerSlice := strings.SplitN(owner, ":", 2)
+	if len(ownerSlice) == 1 {
+		return parseEntity(ownerSlice[0]), nil
+	}
+	return parseEntity(ownerSlice[0]), parseEntity(ownerSlice[1])
+}
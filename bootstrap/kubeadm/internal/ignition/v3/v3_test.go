@@ -0,0 +1,312 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	v3 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/ignition/v3"
+)
+
+const kubeadmUnit = "[Unit]\nDescription=kubeadm\n# Run only once. After successful run, this file is moved to /tmp/.\nConditionPathExists=/etc/kubeadm.yml\n[Service]\n# To not restart the unit when it exits, as it is expected.\nType=oneshot\nExecStart=/etc/kubeadm.sh\n[Install]\nWantedBy=multi-user.target\n"
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		desc         string
+		input        *cloudinit.BaseUserData
+		wantIgnition v3.Config
+	}{
+		{
+			desc: "minimal configuration",
+			input: &cloudinit.BaseUserData{
+				KubeadmCommand: "kubeadm join",
+			},
+			wantIgnition: v3.Config{
+				Ignition: v3.Ignition{Version: "3.3.0"},
+				Storage: v3.Storage{
+					Files: []v3.File{
+						{
+							Path:      "/etc/kubeadm.sh",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,%23!%2Fbin%2Fbash%0Aset%20-e%0A%0A%0Akubeadm%20join%0Amkdir%20-p%20%2Frun%2Fcluster-api%20%26%26%20echo%20success%20%3E%20%2Frun%2Fcluster-api%2Fbootstrap-success.complete%0Amv%20%2Fetc%2Fkubeadm.yml%20%2Ftmp%2F%0A")},
+							Mode:      pointer.Int(448),
+						},
+						{
+							Path:      "/etc/kubeadm.yml",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,---%0Afoo%0A")},
+							Mode:      pointer.Int(384),
+						},
+					},
+				},
+				Systemd: v3.Systemd{
+					Units: []v3.Unit{
+						{
+							Name:     "kubeadm.service",
+							Enabled:  pointer.Bool(true),
+							Contents: pointer.String(kubeadmUnit),
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "all supported fields",
+			input: &cloudinit.BaseUserData{
+				PreKubeadmCommands:  []string{"pre-command"},
+				PostKubeadmCommands: []string{"post-command"},
+				KubeadmCommand:      "kubeadm join",
+				WriteFiles: []bootstrapv1.File{
+					{
+						Path:        "/etc/plain.yaml",
+						Owner:       "nobody:nogroup",
+						Permissions: "0600",
+						Content:     "foo",
+					},
+					{
+						Path:     "/etc/base64.bin",
+						Encoding: bootstrapv1.Base64,
+						Content:  "AAEC",
+					},
+					{
+						Path:    "/etc/appended.conf",
+						Owner:   "nobody",
+						Append:  true,
+						Content: "bar\n",
+					},
+				},
+				Users: []bootstrapv1.User{
+					{
+						Name:              "capi",
+						Gecos:             pointer.String("Cluster API"),
+						Groups:            pointer.String("docker, wheel"),
+						HomeDir:           pointer.String("/home/capi"),
+						Shell:             pointer.String("/bin/bash"),
+						Passwd:            pointer.String("hash"),
+						PrimaryGroup:      pointer.String("capi"),
+						LockPassword:      pointer.Bool(false),
+						Sudo:              pointer.String("ALL=(ALL) NOPASSWD:ALL"),
+						SSHAuthorizedKeys: []string{"ssh-rsa key"},
+					},
+				},
+				NTP: &bootstrapv1.NTP{
+					Enabled: pointer.Bool(true),
+					Servers: []string{"time.example.com"},
+				},
+				DiskSetup: &bootstrapv1.DiskSetup{
+					Partitions: []bootstrapv1.Partition{
+						{Device: "/dev/sdb", Layout: true, Overwrite: pointer.Bool(true)},
+					},
+					Filesystems: []bootstrapv1.Filesystem{
+						{Device: "/dev/sdb1", Filesystem: "ext4", Label: "etcd_disk", Overwrite: pointer.Bool(true), ExtraOpts: []string{"-E", "lazy_itable_init=1"}},
+					},
+				},
+				Mounts: []bootstrapv1.MountPoints{
+					{"etcd_disk", "/var/lib/etcddisk", "defaults", "noatime"},
+				},
+				SystemdUnits: []bootstrapv1.SystemdUnit{
+					{
+						Name:    "my-agent.service",
+						Enabled: pointer.Bool(true),
+						Content: "[Service]\nExecStart=/usr/bin/my-agent\n[Install]\nWantedBy=multi-user.target",
+					},
+					{
+						Name:    "containerd.service",
+						DropIns: []bootstrapv1.SystemdUnitDropIn{{Name: "10-limits.conf", Content: "[Service]\nLimitNOFILE=1048576"}},
+					},
+				},
+			},
+			wantIgnition: v3.Config{
+				Ignition: v3.Ignition{Version: "3.3.0"},
+				Passwd: v3.Passwd{
+					Users: []v3.PasswdUser{
+						{
+							Name:              "capi",
+							Gecos:             pointer.String("Cluster API"),
+							Groups:            []string{"docker", "wheel"},
+							HomeDir:           pointer.String("/home/capi"),
+							PasswordHash:      pointer.String("hash"),
+							PrimaryGroup:      pointer.String("capi"),
+							Shell:             pointer.String("/bin/bash"),
+							SSHAuthorizedKeys: []string{"ssh-rsa key"},
+						},
+					},
+				},
+				Storage: v3.Storage{
+					Disks: []v3.Disk{
+						{
+							Device:     "/dev/sdb",
+							Partitions: []v3.Partition{{}},
+							WipeTable:  pointer.Bool(true),
+						},
+					},
+					Filesystems: []v3.Filesystem{
+						{
+							Device:         "/dev/sdb1",
+							Format:         pointer.String("ext4"),
+							Label:          pointer.String("etcd_disk"),
+							Options:        []string{"-E", "lazy_itable_init=1"},
+							WipeFilesystem: pointer.Bool(true),
+						},
+					},
+					Files: []v3.File{
+						{
+							Path:      "/etc/sudoers.d/capi",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,capi%20ALL%3D(ALL)%20NOPASSWD%3AALL%0A")},
+							Mode:      pointer.Int(384),
+						},
+						{
+							Path:      "/etc/ssh/sshd_config",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,%23%20Use%20most%20defaults%20for%20sshd%20configuration.%0ASubsystem%20sftp%20internal-sftp%0AClientAliveInterval%20180%0AUseDNS%20no%0AUsePAM%20yes%0APrintLastLog%20no%20%23%20handled%20by%20PAM%0APrintMotd%20no%20%23%20handled%20by%20PAM%0A%0AMatch%20User%20capi%0A%20%20PasswordAuthentication%20yes%0A")},
+							Mode:      pointer.Int(384),
+						},
+						{
+							Path:      "/etc/plain.yaml",
+							Overwrite: pointer.Bool(true),
+							User:      &v3.NodeUser{Name: pointer.String("nobody")},
+							Group:     &v3.NodeGroup{Name: pointer.String("nogroup")},
+							Contents:  &v3.Resource{Source: pointer.String("data:,foo%0A")},
+							Mode:      pointer.Int(384),
+						},
+						{
+							Path:      "/etc/base64.bin",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,%00%01%02")},
+						},
+						{
+							Path:   "/etc/appended.conf",
+							User:   &v3.NodeUser{Name: pointer.String("nobody")},
+							Append: []v3.Resource{{Source: pointer.String("data:,bar%0A")}},
+						},
+						{
+							Path:      "/etc/kubeadm.sh",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,%23!%2Fbin%2Fbash%0Aset%20-e%0A%0Apre-command%0A%0Akubeadm%20join%0Amkdir%20-p%20%2Frun%2Fcluster-api%20%26%26%20echo%20success%20%3E%20%2Frun%2Fcluster-api%2Fbootstrap-success.complete%0Amv%20%2Fetc%2Fkubeadm.yml%20%2Ftmp%2F%0A%0Apost-command%0A")},
+							Mode:      pointer.Int(448),
+						},
+						{
+							Path:      "/etc/kubeadm.yml",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,---%0Afoo%0A")},
+							Mode:      pointer.Int(384),
+						},
+						{
+							Path:      "/etc/ntp.conf",
+							Overwrite: pointer.Bool(true),
+							Contents:  &v3.Resource{Source: pointer.String("data:,%23%20Common%20pool%0Aserver%20time.example.com%0A%0A%23%20Warning%3A%20Using%20default%20NTP%20settings%20will%20leave%20your%20NTP%0A%23%20server%20accessible%20to%20all%20hosts%20on%20the%20Internet.%0A%0A%23%20If%20you%20want%20to%20deny%20all%20machines%20(including%20your%20own)%0A%23%20from%20accessing%20the%20NTP%20server%2C%20uncomment%3A%0A%23restrict%20default%20ignore%0A%0A%23%20Default%20configuration%3A%0A%23%20-%20Allow%20only%20time%20queries%2C%20at%20a%20limited%20rate%2C%20sending%20KoD%20when%20in%20excess.%0A%23%20-%20Allow%20all%20local%20queries%20(IPv4%2C%20IPv6)%0Arestrict%20default%20nomodify%20nopeer%20noquery%20notrap%20limited%20kod%0Arestrict%20127.0.0.1%0Arestrict%20%5B%3A%3A1%5D%0A")},
+							Mode:      pointer.Int(420),
+						},
+					},
+				},
+				Systemd: v3.Systemd{
+					Units: []v3.Unit{
+						{
+							Name:     "kubeadm.service",
+							Enabled:  pointer.Bool(true),
+							Contents: pointer.String(kubeadmUnit),
+						},
+						{
+							Name:    "ntpd.service",
+							Enabled: pointer.Bool(true),
+						},
+						{
+							Name:     "var-lib-etcddisk.mount",
+							Enabled:  pointer.Bool(true),
+							Contents: pointer.String("[Unit]\nDescription = Mount etcd_disk\n\n[Mount]\nWhat=/dev/sdb1\nWhere=/var/lib/etcddisk\nOptions=defaults,noatime\n\n[Install]\nWantedBy=multi-user.target\n"),
+						},
+						{
+							Name:     "my-agent.service",
+							Enabled:  pointer.Bool(true),
+							Contents: pointer.String("[Service]\nExecStart=/usr/bin/my-agent\n[Install]\nWantedBy=multi-user.target\n"),
+						},
+						{
+							Name: "containerd.service",
+							Dropins: []v3.Dropin{
+								{Name: "10-limits.conf", Contents: pointer.String("[Service]\nLimitNOFILE=1048576\n")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ignitionBytes, err := v3.Render(tt.input, bootstrapv1.IgnitionVersion3_3, "foo")
+			if err != nil {
+				t.Fatalf("rendering: %v", err)
+			}
+
+			ign := v3.Config{}
+			if err := json.Unmarshal(ignitionBytes, &ign); err != nil {
+				t.Fatalf("Parsing generated Ignition: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.wantIgnition, ign); diff != "" {
+				t.Fatalf("Ignition mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("validates input parameter", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := v3.Render(nil, bootstrapv1.IgnitionVersion3_3, "foo"); err == nil {
+			t.Fatalf("Expected error while rendering nil input")
+		}
+	})
+
+	t.Run("validates version parameter", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := v3.Render(&cloudinit.BaseUserData{}, bootstrapv1.IgnitionVersion2_3, "foo"); err == nil {
+			t.Fatalf("Expected error while rendering Ignition version 2.3")
+		}
+	})
+
+	t.Run("uses the given version", func(t *testing.T) {
+		t.Parallel()
+
+		ignitionBytes, err := v3.Render(&cloudinit.BaseUserData{}, bootstrapv1.IgnitionVersion3_1, "foo")
+		if err != nil {
+			t.Fatalf("rendering: %v", err)
+		}
+
+		ign := v3.Config{}
+		if err := json.Unmarshal(ignitionBytes, &ign); err != nil {
+			t.Fatalf("Parsing generated Ignition: %v", err)
+		}
+		if ign.Ignition.Version != "3.1.0" {
+			t.Fatalf("Expected Ignition version 3.1.0, got %q", ign.Ignition.Version)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+#ps1_sysnative
+# Bootstrap script generated by the Cluster API bootstrap provider kubeadm.
+$ErrorActionPreference = 'Stop'
+
+function Write-BootstrapFile {
+  param(
+    [Parameter(Mandatory = $true)][string]$Path,
+    [string]$Content,
+    [string]$Text,
+    [switch]$Append
+  )
+  $directory = Split-Path -Parent $Path
+  if ($directory) {
+    New-Item -ItemType Directory -Force -Path $directory | Out-Null
+  }
+  if ($PSBoundParameters.ContainsKey('Text')) {
+    $bytes = [System.Text.Encoding]::ASCII.GetBytes($Text + "`n")
+  } else {
+    $bytes = [System.Convert]::FromBase64String($Content)
+  }
+  $mode = if ($Append) { [System.IO.FileMode]::Append } else { [System.IO.FileMode]::Create }
+  $stream = [System.IO.File]::Open($Path, $mode)
+  try {
+    $stream.Write($bytes, 0, $bytes.Length)
+  } finally {
+    $stream.Dispose()
+  }
+}
+
+Write-BootstrapFile -Path 'C:\run\kubeadm\kubeadm-join-config.yaml' -Text @'
+---
+apiVersion: kubeadm.k8s.io/v1beta3
+kind: JoinConfiguration
+discovery:
+  bootstrapToken:
+    apiServerEndpoint: 10.0.0.1:6443
+    token: abcdef.0123456789abcdef
+    unsafeSkipCAVerification: true
+nodeRegistration:
+  criSocket: npipe:////./pipe/containerd-containerd
+  kubeletExtraArgs:
+    cloud-provider: external
+    node-labels: kubernetes.io/os=windows
+'@
+
+kubeadm join --config 'C:\run\kubeadm\kubeadm-join-config.yaml'
+if ($LASTEXITCODE -ne 0) {
+  throw "kubeadm join failed with exit code $LASTEXITCODE"
+}
+New-Item -ItemType Directory -Force -Path 'C:\run\cluster-api' | Out-Null
+Set-Content -Path 'C:\run\cluster-api\bootstrap-success.complete' -Value 'success'
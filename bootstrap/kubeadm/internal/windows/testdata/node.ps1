@@ -0,0 +1,92 @@
+#ps1_sysnative
+# Bootstrap script generated by the Cluster API bootstrap provider kubeadm.
+$ErrorActionPreference = 'Stop'
+
+function Write-BootstrapFile {
+  param(
+    [Parameter(Mandatory = $true)][string]$Path,
+    [string]$Content,
+    [string]$Text,
+    [switch]$Append
+  )
+  $directory = Split-Path -Parent $Path
+  if ($directory) {
+    New-Item -ItemType Directory -Force -Path $directory | Out-Null
+  }
+  if ($PSBoundParameters.ContainsKey('Text')) {
+    $bytes = [System.Text.Encoding]::ASCII.GetBytes($Text + "`n")
+  } else {
+    $bytes = [System.Convert]::FromBase64String($Content)
+  }
+  $mode = if ($Append) { [System.IO.FileMode]::Append } else { [System.IO.FileMode]::Create }
+  $stream = [System.IO.File]::Open($Path, $mode)
+  try {
+    $stream.Write($bytes, 0, $bytes.Length)
+  } finally {
+    $stream.Dispose()
+  }
+}
+
+if (-not (Get-LocalUser -Name 'capi' -ErrorAction SilentlyContinue)) {
+  New-LocalUser -Name 'capi' -NoPassword -FullName 'Cluster API''s admin' | Out-Null
+}
+Add-LocalGroupMember -Group 'Administrators' -Member 'capi' -ErrorAction SilentlyContinue
+Add-LocalGroupMember -Group 'Remote Desktop Users' -Member 'capi' -ErrorAction SilentlyContinue
+
+if (-not (Get-LocalUser -Name 'viewer' -ErrorAction SilentlyContinue)) {
+  New-LocalUser -Name 'viewer' -NoPassword | Out-Null
+}
+Disable-LocalUser -Name 'viewer'
+
+Write-BootstrapFile -Path 'C:\k\cloud-config.json' -Text @'
+{"cloud": "CustomCloud"}
+'@
+
+Write-BootstrapFile -Path 'C:\k\extra.txt' -Append -Text @'
+extra
+'@
+
+Write-BootstrapFile -Path 'C:\k\compressed.txt' -Text @'
+compressed
+'@
+
+Write-BootstrapFile -Path 'C:\k\no-newline.txt' -Content 'bm8gbmV3bGluZQ=='
+
+Write-BootstrapFile -Path 'C:\k\here-string.txt' -Content 'QCcKJ0AK'
+
+Write-BootstrapFile -Path 'C:\ProgramData\ssh\administrators_authorized_keys' -Append -Text @'
+ssh-rsa AAAA capi@example.com
+'@
+
+Write-BootstrapFile -Path 'C:\Users\viewer\.ssh\authorized_keys' -Append -Text @'
+ssh-ed25519 AAAA viewer@example.com
+ssh-ed25519 BBBB viewer@example.com
+'@
+
+Write-BootstrapFile -Path 'C:\run\kubeadm\kubeadm-join-config.yaml' -Text @'
+---
+apiVersion: kubeadm.k8s.io/v1beta3
+kind: JoinConfiguration
+discovery:
+  bootstrapToken:
+    apiServerEndpoint: 10.0.0.1:6443
+    token: abcdef.0123456789abcdef
+    unsafeSkipCAVerification: true
+nodeRegistration:
+  criSocket: npipe:////./pipe/containerd-containerd
+  kubeletExtraArgs:
+    cloud-provider: external
+    node-labels: kubernetes.io/os=windows
+'@
+
+Set-Service -Name containerd -StartupType Automatic
+Start-Service containerd
+
+kubeadm join --config 'C:\run\kubeadm\kubeadm-join-config.yaml' --v 5
+if ($LASTEXITCODE -ne 0) {
+  throw "kubeadm join failed with exit code $LASTEXITCODE"
+}
+New-Item -ItemType Directory -Force -Path 'C:\run\cluster-api' | Out-Null
+Set-Content -Path 'C:\run\cluster-api\bootstrap-success.complete' -Value 'success'
+
+Write-Output 'joined'
@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package windows generates bootstrap data for Windows nodes in the form of a PowerShell
+// script, which can be executed by cloudbase-init, by exposing an API similar to 'internal/cloudinit' package.
+package windows
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+)
+
+const (
+	kubeadmJoinConfigPath = `C:\run\kubeadm\kubeadm-join-config.yaml`
+	sentinelFileDir       = `C:\run\cluster-api`
+	sentinelFilePath      = sentinelFileDir + `\bootstrap-success.complete`
+	joinCommandTemplate   = "kubeadm join --config %s %s"
+
+	administratorsGroup = "Administrators"
+	// administratorsAuthorizedKeysPath is the file used by OpenSSH for Windows for the authorized keys
+	// of the members of the Administrators group, instead of the authorized_keys file in the user profile.
+	administratorsAuthorizedKeysPath = `C:\ProgramData\ssh\administrators_authorized_keys`
+	userAuthorizedKeysPathTemplate   = `C:\Users\%s\.ssh\authorized_keys`
+
+	scriptTemplate = `#ps1_sysnative
+# Bootstrap script generated by the Cluster API bootstrap provider kubeadm.
+$ErrorActionPreference = 'Stop'
+
+function Write-BootstrapFile {
+  param(
+    [Parameter(Mandatory = $true)][string]$Path,
+    [string]$Content,
+    [string]$Text,
+    [switch]$Append
+  )
+  $directory = Split-Path -Parent $Path
+  if ($directory) {
+    New-Item -ItemType Directory -Force -Path $directory | Out-Null
+  }
+  if ($PSBoundParameters.ContainsKey('Text')) {
+    $bytes = [System.Text.Encoding]::ASCII.GetBytes($Text + "` + "`" + `n")
+  } else {
+    $bytes = [System.Convert]::FromBase64String($Content)
+  }
+  $mode = if ($Append) { [System.IO.FileMode]::Append } else { [System.IO.FileMode]::Create }
+  $stream = [System.IO.File]::Open($Path, $mode)
+  try {
+    $stream.Write($bytes, 0, $bytes.Length)
+  } finally {
+    $stream.Dispose()
+  }
+}
+{{- range .Users }}
+
+if (-not (Get-LocalUser -Name {{ quote .Name }} -ErrorAction SilentlyContinue)) {
+  New-LocalUser -Name {{ quote .Name }} -NoPassword{{ if .FullName }} -FullName {{ quote .FullName }}{{ end }} | Out-Null
+}
+{{- if .Disabled }}
+Disable-LocalUser -Name {{ quote .Name }}
+{{- end }}
+{{- $name := .Name }}
+{{- range .Groups }}
+Add-LocalGroupMember -Group {{ quote . }} -Member {{ quote $name }} -ErrorAction SilentlyContinue
+{{- end }}
+{{- end }}
+{{- range .Files }}
+
+{{ if .Text -}}
+Write-BootstrapFile -Path {{ quote .Path }}{{ if .Append }} -Append{{ end }} -Text @'
+{{ .Text }}
+'@
+{{- else -}}
+Write-BootstrapFile -Path {{ quote .Path }}{{ if .Append }} -Append{{ end }} -Content '{{ .Content }}'
+{{- end }}
+{{- end }}
+{{- if .PreKubeadmCommands }}
+{{ range .PreKubeadmCommands }}
+{{ . }}
+{{- end }}
+{{- end }}
+
+{{ .KubeadmCommand }}
+if ($LASTEXITCODE -ne 0) {
+  throw "kubeadm join failed with exit code $LASTEXITCODE"
+}
+New-Item -ItemType Directory -Force -Path {{ quote .SentinelFileDir }} | Out-Null
+Set-Content -Path {{ quote .SentinelFilePath }} -Value 'success'
+{{- if .PostKubeadmCommands }}
+{{ range .PostKubeadmCommands }}
+{{ . }}
+{{- end }}
+{{- end }}
+`
+)
+
+// scriptInput defines the context to generate the bootstrap script.
+type scriptInput struct {
+	Users               []user
+	Files               []file
+	PreKubeadmCommands  []string
+	KubeadmCommand      string
+	SentinelFileDir     string
+	SentinelFilePath    string
+	PostKubeadmCommands []string
+}
+
+// user defines a local user to create.
+type user struct {
+	Name     string
+	FullName string
+	Groups   []string
+	Disabled bool
+}
+
+// file defines a file to write. Text files are embedded as they are, without their trailing newline,
+// to keep the script readable, while any other content is base64 encoded.
+type file struct {
+	Path    string
+	Text    string
+	Content string
+	Append  bool
+}
+
+// NewNode returns the PowerShell bootstrap script for a new Windows worker node joining the cluster.
+func NewNode(input *cloudinit.NodeInput) ([]byte, error) {
+	if input == nil {
+		return nil, errors.New("input can't be nil")
+	}
+
+	data := &scriptInput{
+		PreKubeadmCommands:  input.PreKubeadmCommands,
+		KubeadmCommand:      strings.TrimSpace(fmt.Sprintf(joinCommandTemplate, quote(kubeadmJoinConfigPath), input.KubeadmVerbosity)),
+		SentinelFileDir:     sentinelFileDir,
+		SentinelFilePath:    sentinelFilePath,
+		PostKubeadmCommands: input.PostKubeadmCommands,
+	}
+
+	for _, f := range input.AdditionalFiles {
+		content, err := decodeContent(f)
+		if err != nil {
+			return nil, err
+		}
+		data.Files = append(data.Files, newFile(f.Path, content, f.Append))
+	}
+
+	for _, u := range input.Users {
+		out := user{Name: u.Name, Disabled: u.Inactive != nil && *u.Inactive}
+		if u.Gecos != nil {
+			out.FullName = *u.Gecos
+		}
+		administrator := false
+		if u.Groups != nil {
+			for _, group := range strings.Split(*u.Groups, ",") {
+				group = strings.TrimSpace(group)
+				if group == "" {
+					continue
+				}
+				out.Groups = append(out.Groups, group)
+				administrator = administrator || strings.EqualFold(group, administratorsGroup)
+			}
+		}
+		data.Users = append(data.Users, out)
+
+		if len(u.SSHAuthorizedKeys) > 0 {
+			path := fmt.Sprintf(userAuthorizedKeysPathTemplate, u.Name)
+			if administrator {
+				path = administratorsAuthorizedKeysPath
+			}
+			data.Files = append(data.Files, newFile(path, []byte(strings.Join(u.SSHAuthorizedKeys, "\n")+"\n"), true))
+		}
+	}
+
+	data.Files = append(data.Files, newFile(kubeadmJoinConfigPath, []byte("---\n"+input.JoinConfiguration), false))
+
+	return generate(data)
+}
+
+// Compress gzip-compresses the given bootstrap script; cloudbase-init decompresses gzip user data natively.
+func Compress(userData []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gzip writer")
+	}
+	if _, err := gz.Write(userData); err != nil {
+		return nil, errors.Wrap(err, "failed to compress user data")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to compress user data")
+	}
+	return compressed.Bytes(), nil
+}
+
+func generate(data *scriptInput) ([]byte, error) {
+	t, err := template.New("windows").Funcs(template.FuncMap{"quote": quote}).Parse(scriptTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse windows template")
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return nil, errors.Wrap(err, "failed to generate windows template")
+	}
+
+	return out.Bytes(), nil
+}
+
+// newFile returns a file to write with the given content.
+func newFile(path string, content []byte, appendContent bool) file {
+	if isText(content) {
+		return file{Path: path, Text: strings.TrimSuffix(string(content), "\n"), Append: appendContent}
+	}
+	return file{Path: path, Content: base64.StdEncoding.EncodeToString(content), Append: appendContent}
+}
+
+// isText returns true if the content can be embedded in the script as a single-quoted here-string, i.e.
+// if it is printable ASCII text terminated by a newline, in which no line could terminate the here-string.
+// NOTE: content is limited to ASCII because Windows PowerShell reads scripts without byte order mark
+// using the system code page.
+func isText(content []byte) bool {
+	if len(content) == 0 || content[len(content)-1] != '\n' {
+		return false
+	}
+	for _, c := range content {
+		if (c < ' ' || c > '~') && c != '\n' && c != '\t' {
+			return false
+		}
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), "'@") {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeContent returns the raw content of a file, decoding it according to its encoding.
+func decodeContent(f bootstrapv1.File) ([]byte, error) {
+	content := []byte(f.Content)
+	if f.Encoding == bootstrapv1.Base64 || f.Encoding == bootstrapv1.GzipBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(f.Content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode content of file %q", f.Path)
+		}
+		content = decoded
+	}
+	if f.Encoding == bootstrapv1.Gzip || f.Encoding == bootstrapv1.GzipBase64 {
+		gz, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decompress content of file %q", f.Path)
+		}
+		decompressed, err := io.ReadAll(gz)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decompress content of file %q", f.Path)
+		}
+		content = decompressed
+	}
+	return content, nil
+}
+
+// quote returns s as a PowerShell single-quoted string, in which no expansion takes place.
+// PowerShell also accepts typographic single quotes as delimiters, so they are escaped too.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteRune('\'')
+	for _, r := range s {
+		switch r {
+		case '\'', '\u2018', '\u2019', '\u201a', '\u201b':
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteRune('\'')
+	return b.String()
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package windows_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/windows"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const joinConfiguration = `apiVersion: kubeadm.k8s.io/v1beta3
+kind: JoinConfiguration
+discovery:
+  bootstrapToken:
+    apiServerEndpoint: 10.0.0.1:6443
+    token: abcdef.0123456789abcdef
+    unsafeSkipCAVerification: true
+nodeRegistration:
+  criSocket: npipe:////./pipe/containerd-containerd
+  kubeletExtraArgs:
+    cloud-provider: external
+    node-labels: kubernetes.io/os=windows
+`
+
+func TestNewNode(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		desc   string
+		input  *cloudinit.NodeInput
+		golden string
+	}{
+		{
+			desc: "minimal configuration",
+			input: &cloudinit.NodeInput{
+				JoinConfiguration: joinConfiguration,
+			},
+			golden: "minimal.ps1",
+		},
+		{
+			desc: "files, users and commands",
+			input: &cloudinit.NodeInput{
+				BaseUserData: cloudinit.BaseUserData{
+					AdditionalFiles: []bootstrapv1.File{
+						{
+							Path:    `C:\k\cloud-config.json`,
+							Content: "{\"cloud\": \"CustomCloud\"}\n",
+						},
+						{
+							Path:     `C:\k\extra.txt`,
+							Encoding: bootstrapv1.Base64,
+							Content:  base64.StdEncoding.EncodeToString([]byte("extra\n")),
+							Append:   true,
+						},
+						{
+							Path:     `C:\k\compressed.txt`,
+							Encoding: bootstrapv1.GzipBase64,
+							Content:  base64.StdEncoding.EncodeToString(gzipData(t, "compressed\n")),
+						},
+						{
+							Path:    `C:\k\no-newline.txt`,
+							Content: "no newline",
+						},
+						{
+							Path:    `C:\k\here-string.txt`,
+							Content: "@'\n'@\n",
+						},
+					},
+					Users: []bootstrapv1.User{
+						{
+							Name:              "capi",
+							Gecos:             pointer.String("Cluster API's admin"),
+							Groups:            pointer.String("Administrators, Remote Desktop Users"),
+							SSHAuthorizedKeys: []string{"ssh-rsa AAAA capi@example.com"},
+						},
+						{
+							Name:              "viewer",
+							Inactive:          pointer.Bool(true),
+							SSHAuthorizedKeys: []string{"ssh-ed25519 AAAA viewer@example.com", "ssh-ed25519 BBBB viewer@example.com"},
+						},
+					},
+					PreKubeadmCommands:  []string{"Set-Service -Name containerd -StartupType Automatic", "Start-Service containerd"},
+					PostKubeadmCommands: []string{"Write-Output 'joined'"},
+					KubeadmVerbosity:    "--v 5",
+				},
+				JoinConfiguration: joinConfiguration,
+			},
+			golden: "node.ps1",
+		},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			script, err := windows.NewNode(tt.input)
+			if err != nil {
+				t.Fatalf("generating bootstrap script: %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, script, 0600); err != nil {
+					t.Fatalf("updating golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(path) //nolint:gosec
+			if err != nil {
+				t.Fatalf("reading golden file: %v", err)
+			}
+
+			if diff := cmp.Diff(string(want), string(script)); diff != "" {
+				t.Errorf("Unexpected diff with golden file %s (-want +got):\n%s", path, diff)
+			}
+		})
+	}
+}
+
+func TestNewNodeInvalidFileContent(t *testing.T) {
+	t.Parallel()
+
+	_, err := windows.NewNode(&cloudinit.NodeInput{
+		BaseUserData: cloudinit.BaseUserData{
+			AdditionalFiles: []bootstrapv1.File{
+				{
+					Path:     `C:\k\invalid.txt`,
+					Encoding: bootstrapv1.Base64,
+					Content:  "not base64!",
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for invalid base64 content")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+
+	script, err := windows.NewNode(&cloudinit.NodeInput{JoinConfiguration: joinConfiguration})
+	if err != nil {
+		t.Fatalf("generating bootstrap script: %v", err)
+	}
+
+	compressed, err := windows.Compress(script)
+	if err != nil {
+		t.Fatalf("compressing bootstrap script: %v", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("reading compressed bootstrap script: %v", err)
+	}
+	decompressed, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("reading compressed bootstrap script: %v", err)
+	}
+
+	if diff := cmp.Diff(string(script), string(decompressed)); diff != "" {
+		t.Errorf("Unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func gzipData(t *testing.T, data string) []byte {
+	t.Helper()
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write([]byte(data)); err != nil {
+		t.Fatalf("compressing data: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("compressing data: %v", err)
+	}
+	return b.Bytes()
+}
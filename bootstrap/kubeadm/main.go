@@ -47,11 +47,18 @@ import (
 	kubeadmbootstrapcontrollers "sigs.k8s.io/cluster-api/bootstrap/kubeadm/controllers"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimecontrollers "sigs.k8s.io/cluster-api/exp/runtime/controllers"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/feature"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	"sigs.k8s.io/cluster-api/version"
 )
 
 var (
+	catalog  = runtimecatalog.New()
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
 )
@@ -65,7 +72,11 @@ func init() {
 	_ = bootstrapv1alpha3.AddToScheme(scheme)
 	_ = bootstrapv1alpha4.AddToScheme(scheme)
 	_ = bootstrapv1.AddToScheme(scheme)
+	_ = runtimev1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
+
+	// Register the RuntimeHook types into the catalog.
+	_ = runtimehooksv1.AddToCatalog(catalog)
 }
 
 var (
@@ -78,6 +89,7 @@ var (
 	watchNamespace              string
 	profilerAddress             string
 	kubeadmConfigConcurrency    int
+	extensionConfigConcurrency  int
 	syncPeriod                  time.Duration
 	webhookPort                 int
 	webhookCertDir              string
@@ -115,6 +127,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&kubeadmConfigConcurrency, "kubeadmconfig-concurrency", 10,
 		"Number of kubeadm configs to process simultaneously")
 
+	fs.IntVar(&extensionConfigConcurrency, "extensionconfig-concurrency", 10,
+		"Number of extension configs to process simultaneously")
+
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Minute,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)")
 
@@ -213,10 +228,34 @@ func setupChecks(mgr ctrl.Manager) {
 }
 
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
+	var runtimeClient runtimeclient.Client
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		// This is the creation of the runtimeClient for the controllers, embedding a shared catalog and registry instance.
+		runtimeClient = runtimeclient.New(runtimeclient.Options{
+			Catalog:  catalog,
+			Registry: runtimeregistry.New(),
+			Client:   mgr.GetClient(),
+		})
+
+		// ExtensionConfigs are owned by the core Cluster API controller, which discovers their handlers;
+		// the registry of this manager is only kept in sync with them.
+		if err := (&runtimecontrollers.ExtensionConfigReconciler{
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			RuntimeClient:    runtimeClient,
+			WatchFilterValue: watchFilterValue,
+			ReadOnly:         true,
+		}).SetupWithManager(ctx, mgr, concurrency(extensionConfigConcurrency)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ExtensionConfig")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&kubeadmbootstrapcontrollers.KubeadmConfigReconciler{
 		Client:           mgr.GetClient(),
 		WatchFilterValue: watchFilterValue,
 		TokenTTL:         tokenTTL,
+		RuntimeClient:    runtimeClient,
 	}).SetupWithManager(ctx, mgr, concurrency(kubeadmConfigConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeadmConfig")
 		os.Exit(1)
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstreamv1beta4
+
+import (
+	"sort"
+
+	apimachineryconversion "k8s.io/apimachinery/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+// ConvertTo converts this ClusterConfiguration to the Hub version (v1beta1).
+func (src *ClusterConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1.ClusterConfiguration)
+	return Convert_upstreamv1beta4_ClusterConfiguration_To_v1beta1_ClusterConfiguration(src, dst, nil)
+}
+
+// ConvertFrom converts from the ClusterConfiguration Hub version (v1beta1) to this version.
+func (dst *ClusterConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1.ClusterConfiguration)
+	return Convert_v1beta1_ClusterConfiguration_To_upstreamv1beta4_ClusterConfiguration(src, dst, nil)
+}
+
+// ConvertTo converts this InitConfiguration to the Hub version (v1beta1).
+func (src *InitConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1.InitConfiguration)
+	return Convert_upstreamv1beta4_InitConfiguration_To_v1beta1_InitConfiguration(src, dst, nil)
+}
+
+// ConvertFrom converts from the InitConfiguration Hub version (v1beta1) to this version.
+func (dst *InitConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1.InitConfiguration)
+	return Convert_v1beta1_InitConfiguration_To_upstreamv1beta4_InitConfiguration(src, dst, nil)
+}
+
+// ConvertTo converts this JoinConfiguration to the Hub version (v1beta1).
+func (src *JoinConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1.JoinConfiguration)
+	return Convert_upstreamv1beta4_JoinConfiguration_To_v1beta1_JoinConfiguration(src, dst, nil)
+}
+
+// ConvertFrom converts from the JoinConfiguration Hub version (v1beta1) to this version.
+func (dst *JoinConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1.JoinConfiguration)
+	return Convert_v1beta1_JoinConfiguration_To_upstreamv1beta4_JoinConfiguration(src, dst, nil)
+}
+
+// ConvertFromClusterConfiguration converts from the ClusterConfiguration Hub version (v1beta1) the fields
+// that have been moved to the InitConfiguration in this version.
+func (dst *InitConfiguration) ConvertFromClusterConfiguration(clusterConfiguration *bootstrapv1.ClusterConfiguration) error {
+	if clusterConfiguration == nil || clusterConfiguration.APIServer.TimeoutForControlPlane == nil {
+		return nil
+	}
+
+	if dst.Timeouts == nil {
+		dst.Timeouts = &Timeouts{}
+	}
+	dst.Timeouts.ControlPlaneComponentHealthCheck = clusterConfiguration.APIServer.TimeoutForControlPlane
+	return nil
+}
+
+// ConvertFromClusterConfiguration converts from the ClusterConfiguration Hub version (v1beta1) the fields
+// that have been moved to the JoinConfiguration in this version.
+func (dst *JoinConfiguration) ConvertFromClusterConfiguration(clusterConfiguration *bootstrapv1.ClusterConfiguration) error {
+	if clusterConfiguration == nil || clusterConfiguration.APIServer.TimeoutForControlPlane == nil {
+		return nil
+	}
+
+	if dst.Timeouts == nil {
+		dst.Timeouts = &Timeouts{}
+	}
+	dst.Timeouts.ControlPlaneComponentHealthCheck = clusterConfiguration.APIServer.TimeoutForControlPlane
+	return nil
+}
+
+func Convert_upstreamv1beta4_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in *ClusterConfiguration, out *bootstrapv1.ClusterConfiguration, s apimachineryconversion.Scope) error {
+	// ClusterConfiguration.Proxy, EncryptionAlgorithm, CertificateValidityPeriod and CACertificateValidityPeriod exist in v1beta4 types but not in bootstrapv1.ClusterConfiguration (Cluster API does not support them for now). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in, out, s)
+}
+
+func Convert_v1beta1_APIServer_To_upstreamv1beta4_APIServer(in *bootstrapv1.APIServer, out *APIServer, s apimachineryconversion.Scope) error {
+	// APIServer.TimeoutForControlPlane has been moved to InitConfiguration.Timeouts and JoinConfiguration.Timeouts in v1beta4; it is converted by ConvertFromClusterConfiguration.
+	return autoConvert_v1beta1_APIServer_To_upstreamv1beta4_APIServer(in, out, s)
+}
+
+func Convert_upstreamv1beta4_ControlPlaneComponent_To_v1beta1_ControlPlaneComponent(in *ControlPlaneComponent, out *bootstrapv1.ControlPlaneComponent, s apimachineryconversion.Scope) error {
+	// ControlPlaneComponent.ExtraEnvs exists in v1beta4 types but not in bootstrapv1.ControlPlaneComponent (Cluster API does not support it for now). Ignoring when converting.
+	if err := autoConvert_upstreamv1beta4_ControlPlaneComponent_To_v1beta1_ControlPlaneComponent(in, out, s); err != nil {
+		return err
+	}
+	out.ExtraArgs = convertFromArgs(in.ExtraArgs)
+	return nil
+}
+
+func Convert_v1beta1_ControlPlaneComponent_To_upstreamv1beta4_ControlPlaneComponent(in *bootstrapv1.ControlPlaneComponent, out *ControlPlaneComponent, s apimachineryconversion.Scope) error {
+	if err := autoConvert_v1beta1_ControlPlaneComponent_To_upstreamv1beta4_ControlPlaneComponent(in, out, s); err != nil {
+		return err
+	}
+	out.ExtraArgs = convertToArgs(in.ExtraArgs)
+	return nil
+}
+
+func Convert_upstreamv1beta4_DNS_To_v1beta1_DNS(in *DNS, out *bootstrapv1.DNS, s apimachineryconversion.Scope) error {
+	// DNS.Disabled exists in v1beta4 types but not in bootstrapv1.DNS (Cluster API does not support it for now). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_DNS_To_v1beta1_DNS(in, out, s)
+}
+
+func Convert_v1beta1_Discovery_To_upstreamv1beta4_Discovery(in *bootstrapv1.Discovery, out *Discovery, s apimachineryconversion.Scope) error {
+	// Discovery.Timeout has been moved to JoinConfiguration.Timeouts.Discovery in v1beta4; it is converted when converting the JoinConfiguration.
+	return autoConvert_v1beta1_Discovery_To_upstreamv1beta4_Discovery(in, out, s)
+}
+
+func Convert_upstreamv1beta4_InitConfiguration_To_v1beta1_InitConfiguration(in *InitConfiguration, out *bootstrapv1.InitConfiguration, s apimachineryconversion.Scope) error {
+	// InitConfiguration.CertificateKey, DryRun and Timeouts exist in v1beta4 types but not in bootstrapv1.InitConfiguration (Cluster API does not uses automatic copy certs or does not support DryRun and Timeouts for now). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_InitConfiguration_To_v1beta1_InitConfiguration(in, out, s)
+}
+
+func Convert_upstreamv1beta4_JoinConfiguration_To_v1beta1_JoinConfiguration(in *JoinConfiguration, out *bootstrapv1.JoinConfiguration, s apimachineryconversion.Scope) error {
+	// JoinConfiguration.DryRun and Timeouts exist in v1beta4 types but not in bootstrapv1.JoinConfiguration (Cluster API does not support them for now). Ignoring when converting,
+	// except for Timeouts.Discovery which corresponds to Discovery.Timeout in bootstrapv1.JoinConfiguration.
+	if err := autoConvert_upstreamv1beta4_JoinConfiguration_To_v1beta1_JoinConfiguration(in, out, s); err != nil {
+		return err
+	}
+	if in.Timeouts != nil {
+		out.Discovery.Timeout = in.Timeouts.Discovery
+	}
+	return nil
+}
+
+func Convert_v1beta1_JoinConfiguration_To_upstreamv1beta4_JoinConfiguration(in *bootstrapv1.JoinConfiguration, out *JoinConfiguration, s apimachineryconversion.Scope) error {
+	if err := autoConvert_v1beta1_JoinConfiguration_To_upstreamv1beta4_JoinConfiguration(in, out, s); err != nil {
+		return err
+	}
+	if in.Discovery.Timeout != nil {
+		if out.Timeouts == nil {
+			out.Timeouts = &Timeouts{}
+		}
+		out.Timeouts.Discovery = in.Discovery.Timeout
+	}
+	return nil
+}
+
+func Convert_upstreamv1beta4_JoinControlPlane_To_v1beta1_JoinControlPlane(in *JoinControlPlane, out *bootstrapv1.JoinControlPlane, s apimachineryconversion.Scope) error {
+	// JoinControlPlane.CertificateKey exists in v1beta4 types but not in bootstrapv1.JoinControlPlane (Cluster API does not uses automatic copy certs). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_JoinControlPlane_To_v1beta1_JoinControlPlane(in, out, s)
+}
+
+func Convert_upstreamv1beta4_LocalEtcd_To_v1beta1_LocalEtcd(in *LocalEtcd, out *bootstrapv1.LocalEtcd, s apimachineryconversion.Scope) error {
+	// LocalEtcd.ExtraEnvs exists in v1beta4 types but not in bootstrapv1.LocalEtcd (Cluster API does not support it for now). Ignoring when converting.
+	if err := autoConvert_upstreamv1beta4_LocalEtcd_To_v1beta1_LocalEtcd(in, out, s); err != nil {
+		return err
+	}
+	out.ExtraArgs = convertFromArgs(in.ExtraArgs)
+	return nil
+}
+
+func Convert_v1beta1_LocalEtcd_To_upstreamv1beta4_LocalEtcd(in *bootstrapv1.LocalEtcd, out *LocalEtcd, s apimachineryconversion.Scope) error {
+	if err := autoConvert_v1beta1_LocalEtcd_To_upstreamv1beta4_LocalEtcd(in, out, s); err != nil {
+		return err
+	}
+	out.ExtraArgs = convertToArgs(in.ExtraArgs)
+	return nil
+}
+
+func Convert_upstreamv1beta4_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in *NodeRegistrationOptions, out *bootstrapv1.NodeRegistrationOptions, s apimachineryconversion.Scope) error {
+	// NodeRegistrationOptions.ImagePullPolicy and ImagePullSerial exist in v1beta4 types but not in bootstrapv1.NodeRegistrationOptions (Cluster API does not support them for now). Ignoring when converting.
+	if err := autoConvert_upstreamv1beta4_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in, out, s); err != nil {
+		return err
+	}
+	out.KubeletExtraArgs = convertFromArgs(in.KubeletExtraArgs)
+	return nil
+}
+
+func Convert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta4_NodeRegistrationOptions(in *bootstrapv1.NodeRegistrationOptions, out *NodeRegistrationOptions, s apimachineryconversion.Scope) error {
+	if err := autoConvert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta4_NodeRegistrationOptions(in, out, s); err != nil {
+		return err
+	}
+	out.KubeletExtraArgs = convertToArgs(in.KubeletExtraArgs)
+	return nil
+}
+
+// convertToArgs converts a map of extra args into the list of Arg used in v1beta4.
+// NOTE: Args are sorted by name, so the resulting kubeadm config is deterministic.
+func convertToArgs(in map[string]string) []Arg {
+	if in == nil {
+		return nil
+	}
+	args := make([]Arg, 0, len(in))
+	for name, value := range in {
+		args = append(args, Arg{Name: name, Value: value})
+	}
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].Name < args[j].Name
+	})
+	return args
+}
+
+// convertFromArgs converts a list of Arg used in v1beta4 into a map of extra args.
+// NOTE: In case an arg is repeated, the last value wins.
+func convertFromArgs(in []Arg) map[string]string {
+	if in == nil {
+		return nil
+	}
+	args := make(map[string]string, len(in))
+	for _, arg := range in {
+		args[arg.Name] = arg.Value
+	}
+	return args
+}
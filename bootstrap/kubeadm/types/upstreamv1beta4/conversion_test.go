@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstreamv1beta4
+
+import (
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+)
+
+func TestFuzzyConversion(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+
+	t.Run("for ClusterConfiguration", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &bootstrapv1.ClusterConfiguration{},
+		Spoke:  &ClusterConfiguration{},
+		// NOTE: Kubeadm types does not have ObjectMeta, so we are required to skip data annotation cleanup in the spoke-hub-spoke round trip test.
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for InitConfiguration", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &bootstrapv1.InitConfiguration{},
+		Spoke:  &InitConfiguration{},
+		// NOTE: Kubeadm types does not have ObjectMeta, so we are required to skip data annotation cleanup in the spoke-hub-spoke round trip test.
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for JoinConfiguration", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &bootstrapv1.JoinConfiguration{},
+		Spoke:  &JoinConfiguration{},
+		// NOTE: Kubeadm types does not have ObjectMeta, so we are required to skip data annotation cleanup in the spoke-hub-spoke round trip test.
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+}
+
+func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		clusterConfigurationFuzzer,
+		controlPlaneComponentFuzzer,
+		dnsFuzzer,
+		localEtcdFuzzer,
+		nodeRegistrationOptionsFuzzer,
+		initConfigurationFuzzer,
+		joinConfigurationFuzzer,
+		joinControlPlanesFuzzer,
+		kubeadmAPIServerFuzzer,
+	}
+}
+
+func clusterConfigurationFuzzer(obj *ClusterConfiguration, c fuzz.Continue) {
+	c.Fuzz(obj)
+
+	// ClusterConfiguration.Proxy, EncryptionAlgorithm, CertificateValidityPeriod and CACertificateValidityPeriod do not exist in v1beta1,
+	// so setting them to empty values in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.Proxy = Proxy{}
+	obj.EncryptionAlgorithm = ""
+	obj.CertificateValidityPeriod = nil
+	obj.CACertificateValidityPeriod = nil
+}
+
+func controlPlaneComponentFuzzer(obj *ControlPlaneComponent, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ControlPlaneComponent.ExtraArgs is a map in v1beta1, so dropping duplicated args and sorting them by name in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ExtraArgs = convertToArgs(convertFromArgs(obj.ExtraArgs))
+
+	// ControlPlaneComponent.ExtraEnvs does not exist in v1beta1, so setting it to nil in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ExtraEnvs = nil
+}
+
+func dnsFuzzer(obj *DNS, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// DNS.Disabled does not exist in v1beta1, so setting it to false in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.Disabled = false
+}
+
+func localEtcdFuzzer(obj *LocalEtcd, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// LocalEtcd.ExtraArgs is a map in v1beta1, so dropping duplicated args and sorting them by name in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ExtraArgs = convertToArgs(convertFromArgs(obj.ExtraArgs))
+
+	// LocalEtcd.ExtraEnvs does not exist in v1beta1, so setting it to nil in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ExtraEnvs = nil
+}
+
+func nodeRegistrationOptionsFuzzer(obj *NodeRegistrationOptions, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// NodeRegistrationOptions.KubeletExtraArgs is a map in v1beta1, so dropping duplicated args and sorting them by name in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.KubeletExtraArgs = convertToArgs(convertFromArgs(obj.KubeletExtraArgs))
+
+	// NodeRegistrationOptions.ImagePullPolicy and ImagePullSerial do not exist in v1beta1, so setting them to empty values in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ImagePullPolicy = ""
+	obj.ImagePullSerial = nil
+}
+
+func joinControlPlanesFuzzer(obj *JoinControlPlane, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// JoinControlPlane.CertificateKey does not exist in v1beta1, so setting it to empty string in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.CertificateKey = ""
+}
+
+func initConfigurationFuzzer(obj *InitConfiguration, c fuzz.Continue) {
+	c.Fuzz(obj)
+
+	// InitConfiguration.CertificateKey, DryRun and Timeouts do not exist in v1beta1, so setting them to empty values in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.CertificateKey = ""
+	obj.DryRun = false
+	obj.Timeouts = nil
+}
+
+func joinConfigurationFuzzer(obj *JoinConfiguration, c fuzz.Continue) {
+	c.Fuzz(obj)
+
+	// JoinConfiguration.DryRun does not exist in v1beta1, so setting it to false in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.DryRun = false
+
+	// JoinConfiguration.Timeouts does not exist in v1beta1, except for Timeouts.Discovery which corresponds to Discovery.Timeout,
+	// so dropping all the other timeouts in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	if obj.Timeouts != nil {
+		if obj.Timeouts.Discovery == nil {
+			obj.Timeouts = nil
+		} else {
+			obj.Timeouts = &Timeouts{Discovery: obj.Timeouts.Discovery}
+		}
+	}
+}
+
+func kubeadmAPIServerFuzzer(obj *bootstrapv1.APIServer, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// APIServer.TimeoutForControlPlane does not exist in the v1beta4 ClusterConfiguration, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta4 -> v1beta1 round trip errors.
+	obj.TimeoutForControlPlane = nil
+}
+
+func TestConvertFromClusterConfiguration(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterConfiguration := &bootstrapv1.ClusterConfiguration{
+		APIServer: bootstrapv1.APIServer{
+			TimeoutForControlPlane: &metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}
+
+	initConfiguration := &InitConfiguration{}
+	g.Expect(initConfiguration.ConvertFromClusterConfiguration(clusterConfiguration)).To(Succeed())
+	g.Expect(initConfiguration.Timeouts).ToNot(BeNil())
+	g.Expect(initConfiguration.Timeouts.ControlPlaneComponentHealthCheck).To(Equal(clusterConfiguration.APIServer.TimeoutForControlPlane))
+
+	joinConfiguration := &JoinConfiguration{Timeouts: &Timeouts{Discovery: &metav1.Duration{Duration: time.Minute}}}
+	g.Expect(joinConfiguration.ConvertFromClusterConfiguration(clusterConfiguration)).To(Succeed())
+	g.Expect(joinConfiguration.Timeouts.ControlPlaneComponentHealthCheck).To(Equal(clusterConfiguration.APIServer.TimeoutForControlPlane))
+	g.Expect(joinConfiguration.Timeouts.Discovery).To(Equal(&metav1.Duration{Duration: time.Minute}))
+
+	// Nothing changes if the ClusterConfiguration is not set.
+	initConfiguration = &InitConfiguration{}
+	g.Expect(initConfiguration.ConvertFromClusterConfiguration(nil)).To(Succeed())
+	g.Expect(initConfiguration.Timeouts).To(BeNil())
+}
+
+func TestConvertArgs(t *testing.T) {
+	g := NewWithT(t)
+
+	args := convertToArgs(map[string]string{"foo": "1", "bar": "2"})
+	g.Expect(args).To(Equal([]Arg{{Name: "bar", Value: "2"}, {Name: "foo", Value: "1"}}))
+
+	g.Expect(convertFromArgs([]Arg{{Name: "foo", Value: "1"}, {Name: "foo", Value: "2"}})).To(Equal(map[string]string{"foo": "2"}))
+
+	g.Expect(convertToArgs(nil)).To(BeNil())
+	g.Expect(convertFromArgs(nil)).To(BeNil())
+}
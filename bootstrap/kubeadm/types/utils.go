@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 	"sigs.k8s.io/cluster-api/util/version"
 )
 
@@ -37,8 +38,10 @@ var (
 	v1beta1KubeadmVersion = semver.MustParse("1.13.0")
 	v1beta2KubeadmVersion = semver.MustParse("1.15.0")
 	v1beta3KubeadmVersion = semver.MustParse("1.22.0")
+	v1beta4KubeadmVersion = semver.MustParse("1.31.0")
 
 	clusterConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.ClusterConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.ClusterConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.ClusterConfiguration{},
@@ -51,12 +54,14 @@ var (
 	}
 
 	initConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.InitConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.InitConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.InitConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.InitConfiguration{},
 	}
 
 	joinConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.JoinConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.JoinConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.JoinConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.JoinConfiguration{},
@@ -74,14 +79,17 @@ func KubeVersionToKubeadmAPIGroupVersion(v semver.Version) (schema.GroupVersion,
 	case version.Compare(v, v1beta3KubeadmVersion, version.WithoutPreReleases()) < 0:
 		// NOTE: All the Kubernetes version >= v1.15 and < v1.22 should use the kubeadm API version v1beta2
 		return upstreamv1beta2.GroupVersion, nil
+	case version.Compare(v, v1beta4KubeadmVersion, version.WithoutPreReleases()) < 0:
+		// NOTE: All the Kubernetes version >= v1.22 and < v1.31 should use the kubeadm API version v1beta3
+		return upstreamv1beta3.GroupVersion, nil
 	default:
-		// NOTE: All the Kubernetes version greater or equal to v1.22 should use the kubeadm API version v1beta3.
-		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta3,
-		// no matter if kubeadm API versions newer than v1beta3 could be introduced by those release.
-		// This is acceptable because v1beta3 will be supported by kubeadm until the deprecation cycle completes
+		// NOTE: All the Kubernetes version greater or equal to v1.31 should use the kubeadm API version v1beta4.
+		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta4,
+		// no matter if kubeadm API versions newer than v1beta4 could be introduced by those release.
+		// This is acceptable because v1beta4 will be supported by kubeadm until the deprecation cycle completes
 		// (9 months minimum after the deprecation date, not yet announced now); this gives Cluster API project time to
 		// introduce support for newer releases without blocking users to deploy newer version of Kubernetes.
-		return upstreamv1beta3.GroupVersion, nil
+		return upstreamv1beta4.GroupVersion, nil
 	}
 }
 
@@ -89,31 +97,41 @@ func KubeVersionToKubeadmAPIGroupVersion(v semver.Version) (schema.GroupVersion,
 // for the given Kubernetes Version.
 // NOTE: This assumes Kubernetes Version equals to kubeadm version.
 func MarshalClusterConfigurationForVersion(obj *bootstrapv1.ClusterConfiguration, version semver.Version) (string, error) {
-	return marshalForVersion(obj, version, clusterConfigurationVersionTypeMap)
+	return marshalForVersion(obj, version, clusterConfigurationVersionTypeMap, nil)
 }
 
 // MarshalClusterStatusForVersion converts a Cluster API ClusterStatus type to the kubeadm API type
 // for the given Kubernetes Version.
 // NOTE: This assumes Kubernetes Version equals to kubeadm version.
 func MarshalClusterStatusForVersion(obj *bootstrapv1.ClusterStatus, version semver.Version) (string, error) {
-	return marshalForVersion(obj, version, clusterStatusVersionTypeMap)
+	return marshalForVersion(obj, version, clusterStatusVersionTypeMap, nil)
 }
 
 // MarshalInitConfigurationForVersion converts a Cluster API InitConfiguration type to the kubeadm API type
 // for the given Kubernetes Version.
+// The ClusterConfiguration is used to convert fields that have been moved to the InitConfiguration in
+// newer kubeadm API versions, e.g. APIServer.TimeoutForControlPlane; it could be nil.
 // NOTE: This assumes Kubernetes Version equals to kubeadm version.
-func MarshalInitConfigurationForVersion(obj *bootstrapv1.InitConfiguration, version semver.Version) (string, error) {
-	return marshalForVersion(obj, version, initConfigurationVersionTypeMap)
+func MarshalInitConfigurationForVersion(clusterConfiguration *bootstrapv1.ClusterConfiguration, obj *bootstrapv1.InitConfiguration, version semver.Version) (string, error) {
+	return marshalForVersion(obj, version, initConfigurationVersionTypeMap, clusterConfiguration)
 }
 
 // MarshalJoinConfigurationForVersion converts a Cluster API JoinConfiguration type to the kubeadm API type
 // for the given Kubernetes Version.
+// The ClusterConfiguration is used to convert fields that have been moved to the JoinConfiguration in
+// newer kubeadm API versions, e.g. APIServer.TimeoutForControlPlane; it could be nil.
 // NOTE: This assumes Kubernetes Version equals to kubeadm version.
-func MarshalJoinConfigurationForVersion(obj *bootstrapv1.JoinConfiguration, version semver.Version) (string, error) {
-	return marshalForVersion(obj, version, joinConfigurationVersionTypeMap)
+func MarshalJoinConfigurationForVersion(clusterConfiguration *bootstrapv1.ClusterConfiguration, obj *bootstrapv1.JoinConfiguration, version semver.Version) (string, error) {
+	return marshalForVersion(obj, version, joinConfigurationVersionTypeMap, clusterConfiguration)
+}
+
+// convertibleFromClusterConfiguration is implemented by the kubeadm API types that require fields of the
+// Cluster API ClusterConfiguration type to be converted, e.g. the v1beta4 InitConfiguration and JoinConfiguration.
+type convertibleFromClusterConfiguration interface {
+	ConvertFromClusterConfiguration(clusterConfiguration *bootstrapv1.ClusterConfiguration) error
 }
 
-func marshalForVersion(obj conversion.Hub, version semver.Version, kubeadmObjVersionTypeMap map[schema.GroupVersion]conversion.Convertible) (string, error) {
+func marshalForVersion(obj conversion.Hub, version semver.Version, kubeadmObjVersionTypeMap map[schema.GroupVersion]conversion.Convertible, clusterConfiguration *bootstrapv1.ClusterConfiguration) (string, error) {
 	kubeadmAPIGroupVersion, err := KubeVersionToKubeadmAPIGroupVersion(version)
 	if err != nil {
 		return "", err
@@ -129,6 +147,12 @@ func marshalForVersion(obj conversion.Hub, version semver.Version, kubeadmObjVer
 		return "", errors.Wrapf(err, "failed to convert to KubeadmAPI type for version %s", kubeadmAPIGroupVersion)
 	}
 
+	if convertible, ok := targetKubeadmObj.(convertibleFromClusterConfiguration); ok {
+		if err := convertible.ConvertFromClusterConfiguration(clusterConfiguration); err != nil {
+			return "", errors.Wrapf(err, "failed to convert ClusterConfiguration to KubeadmAPI type for version %s", kubeadmAPIGroupVersion)
+		}
+	}
+
 	codecs, err := getCodecsFor(kubeadmAPIGroupVersion, targetKubeadmObj)
 	if err != nil {
 		return "", err
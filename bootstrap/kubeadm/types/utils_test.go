@@ -18,16 +18,19 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 )
 
 func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
@@ -120,12 +123,28 @@ func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
 			want:    upstreamv1beta3.GroupVersion,
 			wantErr: false,
 		},
+		{
+			name: "pass with minimum kubernetes alpha version for kubeadm API v1beta4",
+			args: args{
+				version: semver.MustParse("1.31.0-alpha.0.734+ba502ee555924a"),
+			},
+			want:    upstreamv1beta4.GroupVersion,
+			wantErr: false,
+		},
+		{
+			name: "pass with minimum kubernetes version for kubeadm API v1beta4",
+			args: args{
+				version: semver.MustParse("1.31.0"),
+			},
+			want:    upstreamv1beta4.GroupVersion,
+			wantErr: false,
+		},
 		{
 			name: "pass with future kubernetes version",
 			args: args{
 				version: semver.MustParse("99.99.99"),
 			},
-			want:    upstreamv1beta3.GroupVersion,
+			want:    upstreamv1beta4.GroupVersion,
 			wantErr: false,
 		},
 	}
@@ -203,6 +222,35 @@ func TestMarshalClusterConfigurationForVersion(t *testing.T) {
 				"scheduler: {}\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+							ExtraArgs: map[string]string{"foo": "bar", "baz": "qux"},
+						},
+						TimeoutForControlPlane: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiServer:\n" +
+				"  extraArgs:\n" +
+				"  - name: baz\n" +
+				"    value: qux\n" +
+				"  - name: foo\n" +
+				"    value: bar\n" +
+				"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"controllerManager: {}\n" +
+				"dns: {}\n" +
+				"etcd: {}\n" +
+				"kind: ClusterConfiguration\n" +
+				"networking: {}\n" +
+				"proxy: {}\n" +
+				"scheduler: {}\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -278,8 +326,9 @@ func TestMarshalClusterStatusForVersion(t *testing.T) {
 
 func TestMarshalInitConfigurationForVersion(t *testing.T) {
 	type args struct {
-		capiObj *bootstrapv1.InitConfiguration
-		version semver.Version
+		clusterConfiguration *bootstrapv1.ClusterConfiguration
+		capiObj              *bootstrapv1.InitConfiguration
+		version              semver.Version
 	}
 	tests := []struct {
 		name    string
@@ -338,12 +387,42 @@ func TestMarshalInitConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				clusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						TimeoutForControlPlane: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				capiObj: &bootstrapv1.InitConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						KubeletExtraArgs:      map[string]string{"foo": "bar"},
+						IgnorePreflightErrors: []string{"some-preflight-check"},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" +
+				"kind: InitConfiguration\n" +
+				"localAPIEndpoint: {}\n" +
+				"nodeRegistration:\n" +
+				"  ignorePreflightErrors:\n" +
+				"  - some-preflight-check\n" +
+				"  kubeletExtraArgs:\n" +
+				"  - name: foo\n" +
+				"    value: bar\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  controlPlaneComponentHealthCheck: 10m0s\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			got, err := MarshalInitConfigurationForVersion(tt.args.capiObj, tt.args.version)
+			got, err := MarshalInitConfigurationForVersion(tt.args.clusterConfiguration, tt.args.capiObj, tt.args.version)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -356,8 +435,9 @@ func TestMarshalInitConfigurationForVersion(t *testing.T) {
 
 func TestMarshalJoinConfigurationForVersion(t *testing.T) {
 	type args struct {
-		capiObj *bootstrapv1.JoinConfiguration
-		version semver.Version
+		clusterConfiguration *bootstrapv1.ClusterConfiguration
+		capiObj              *bootstrapv1.JoinConfiguration
+		version              semver.Version
 	}
 	tests := []struct {
 		name    string
@@ -414,12 +494,36 @@ func TestMarshalJoinConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.JoinConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						IgnorePreflightErrors: []string{"some-preflight-check"},
+					},
+					Discovery: bootstrapv1.Discovery{
+						Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"discovery: {}\n" +
+				"kind: JoinConfiguration\n" +
+				"nodeRegistration:\n" +
+				"  ignorePreflightErrors:\n" +
+				"  - some-preflight-check\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  discovery: 5m0s\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			got, err := MarshalJoinConfigurationForVersion(tt.args.capiObj, tt.args.version)
+			got, err := MarshalJoinConfigurationForVersion(tt.args.clusterConfiguration, tt.args.capiObj, tt.args.version)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -485,6 +589,31 @@ func TestUnmarshalClusterConfiguration(t *testing.T) {
 			want:    &bootstrapv1.ClusterConfiguration{},
 			wantErr: false,
 		},
+		{
+			name: "Parses a v1beta4 kubeadm configuration",
+			args: args{
+				yaml: "apiServer:\n" +
+					"  extraArgs:\n" +
+					"  - name: foo\n" +
+					"    value: bar\n" +
+					"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+					"controllerManager: {}\n" +
+					"dns: {}\n" +
+					"etcd: {}\n" +
+					"kind: ClusterConfiguration\n" +
+					"networking: {}\n" +
+					"proxy: {}\n" +
+					"scheduler: {}\n",
+			},
+			want: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{
+					ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+						ExtraArgs: map[string]string{"foo": "bar"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
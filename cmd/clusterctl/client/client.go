@@ -30,6 +30,9 @@ type Client interface {
 	// GetProvidersConfig returns the list of providers configured for this instance of clusterctl.
 	GetProvidersConfig() ([]Provider, error)
 
+	// CleanCache removes all the data stored in the clusterctl cache for provider repository downloads.
+	CleanCache() error
+
 	// GetProviderComponents returns the provider components for a given provider with options including targetNamespace.
 	GetProviderComponents(provider string, providerType clusterctlv1.ProviderType, options ComponentsOptions) (Components, error)
 
@@ -91,6 +94,8 @@ type AlphaClient interface {
 	RolloutUndo(options RolloutOptions) error
 	// TopologyPlan dry runs the topology reconciler
 	TopologyPlan(options TopologyPlanOptions) (*TopologyPlanOutput, error)
+	// TopologyRebase checks and previews the rebase of a Cluster to a different ClusterClass, applying it if requested.
+	TopologyRebase(options TopologyRebaseOptions) (*TopologyRebaseOutput, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
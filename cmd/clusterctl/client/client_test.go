@@ -76,6 +76,10 @@ func (f fakeClient) GetProvidersConfig() ([]Provider, error) {
 	return f.internalClient.GetProvidersConfig()
 }
 
+func (f fakeClient) CleanCache() error {
+	return f.internalClient.CleanCache()
+}
+
 func (f fakeClient) GetProviderComponents(provider string, providerType clusterctlv1.ProviderType, options ComponentsOptions) (Components, error) {
 	return f.internalClient.GetProviderComponents(provider, providerType, options)
 }
@@ -152,6 +156,10 @@ func (f fakeClient) TopologyPlan(options TopologyPlanOptions) (*cluster.Topology
 	return f.internalClient.TopologyPlan(options)
 }
 
+func (f fakeClient) TopologyRebase(options TopologyRebaseOptions) (*cluster.TopologyRebaseOutput, error) {
+	return f.internalClient.TopologyRebase(options)
+}
+
 // newFakeClient returns a clusterctl client that allows to execute tests on a set of fake config, fake repositories and fake clusters.
 // you can use WithCluster and WithRepository to prepare for the test case.
 func newFakeClient(configClient config.Client) *fakeClient {
@@ -388,6 +396,18 @@ func (f fakeConfigClient) ImageMeta() config.ImageMetaClient {
 	return f.internalclient.ImageMeta()
 }
 
+func (f fakeConfigClient) Cache() config.CacheClient {
+	return f.internalclient.Cache()
+}
+
+func (f fakeConfigClient) Verification() config.VerificationClient {
+	return f.internalclient.Verification()
+}
+
+func (f fakeConfigClient) TemplateProcessors() config.TemplateProcessorsClient {
+	return f.internalclient.TemplateProcessors()
+}
+
 func (f *fakeConfigClient) WithVar(key, value string) *fakeConfigClient {
 	f.fakeReader.WithVar(key, value)
 	return f
@@ -797,6 +797,18 @@ func (f fakeConfigClient) ImageMeta() config.ImageMetaClient {
 	return f.internalclient.ImageMeta()
 }
 
+func (f fakeConfigClient) Cache() config.CacheClient {
+	return f.internalclient.Cache()
+}
+
+func (f fakeConfigClient) Verification() config.VerificationClient {
+	return f.internalclient.Verification()
+}
+
+func (f fakeConfigClient) TemplateProcessors() config.TemplateProcessorsClient {
+	return f.internalclient.TemplateProcessors()
+}
+
 func (f *fakeConfigClient) WithVar(key, value string) *fakeConfigClient {
 	f.fakeReader.WithVar(key, value)
 	return f
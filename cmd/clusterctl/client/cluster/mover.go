@@ -314,6 +314,12 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 	clusterClasses := graph.getClusterClasses()
 	log.Info("Moving Cluster API objects", "ClusterClasses", len(clusterClasses))
 
+	// Ensure all the ClusterClasses used by the Clusters are either moved or already exist in the target cluster.
+	log.V(1).Info("Checking the ClusterClasses in the target cluster")
+	if err := o.checkTargetClusterClasses(graph, toProxy); err != nil {
+		return err
+	}
+
 	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
 	log.V(1).Info("Pausing the source cluster")
 	if err := setClusterPause(o.fromProxy, clusters, true, o.dryRun); err != nil {
@@ -419,6 +425,12 @@ func (o *objectMover) restore(graph *objectGraph, toProxy Proxy) error {
 	// Get clusterclasses from graph
 	clusterClasses := graph.getClusterClasses()
 
+	// Ensure all the ClusterClasses used by the Clusters are either restored or already exist in the target cluster.
+	log.V(1).Info("Checking the ClusterClasses in the target cluster")
+	if err := o.checkTargetClusterClasses(graph, toProxy); err != nil {
+		return err
+	}
+
 	// Ensure all the expected target namespaces are in place before creating objects.
 	log.V(1).Info("Creating target namespaces, if missing")
 	if err := o.ensureNamespaces(graph, toProxy); err != nil {
@@ -652,6 +664,52 @@ func pauseClusterClass(proxy Proxy, n *node, pause bool) error {
 	return nil
 }
 
+// checkTargetClusterClasses checks that the ClusterClasses used by the Clusters in the object graph exist in the target cluster,
+// if they are not part of the object graph, e.g. when moving a namespace with Clusters using a ClusterClass in another namespace.
+// NOTE: ClusterClasses in other namespaces are not moved, because they can be used by Clusters that are not moved.
+func (o *objectMover) checkTargetClusterClasses(graph *objectGraph, toProxy Proxy) error {
+	if o.dryRun {
+		return nil
+	}
+
+	clusterClasses := map[types.NamespacedName]bool{}
+	for _, clusterClass := range graph.getClusterClasses() {
+		clusterClasses[types.NamespacedName{Namespace: clusterClass.identity.Namespace, Name: clusterClass.identity.Name}] = true
+	}
+
+	var cs client.Client
+	errList := []error{}
+	for _, cluster := range graph.getClusters() {
+		className, ok := cluster.additionalInfo[clusterTopologyNameKey]
+		if !ok {
+			continue
+		}
+		classNamespace := cluster.additionalInfo[clusterTopologyNamespaceKey]
+		key := types.NamespacedName{Namespace: fmt.Sprint(classNamespace), Name: fmt.Sprint(className)}
+		if clusterClasses[key] {
+			continue
+		}
+
+		if cs == nil {
+			var err error
+			cs, err = toProxy.NewClient()
+			if err != nil {
+				return err
+			}
+		}
+		if err := cs.Get(ctx, key, &clusterv1.ClusterClass{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				errList = append(errList, errors.Errorf("ClusterClass %s used by Cluster %s/%s is not moved and it does not exist in the target cluster",
+					key, cluster.identity.Namespace, cluster.identity.Name))
+				continue
+			}
+			return errors.Wrapf(err, "failed to get ClusterClass %s from the target cluster", key)
+		}
+		clusterClasses[key] = true
+	}
+	return kerrors.NewAggregate(errList)
+}
+
 // ensureNamespaces ensures all the expected target namespaces are in place before creating objects.
 func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error {
 	if o.dryRun {
@@ -1727,6 +1727,81 @@ func Test_objectMoverService_ensureNamespaces(t *testing.T) {
 	}
 }
 
+func Test_objectMover_checkTargetClusterClasses(t *testing.T) {
+	type fields struct {
+		objs []client.Object
+	}
+	type args struct {
+		toProxy Proxy
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "pass if the ClusterClass is moved together with the Cluster",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").Objs()...)
+					return deduplicateObjects(objs)
+				}(),
+			},
+			args: args{
+				toProxy: test.NewFakeProxy(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "pass if the ClusterClass in another namespace exists in the target cluster",
+			fields: fields{
+				objs: test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").WithTopologyClassNamespace("ns2").Objs(),
+			},
+			args: args{
+				toProxy: test.NewFakeProxy().WithObjs(test.NewFakeClusterClass("ns2", "class1").Objs()...),
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail if the ClusterClass in another namespace does not exist in the target cluster",
+			fields: fields{
+				objs: test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").WithTopologyClassNamespace("ns2").Objs(),
+			},
+			args: args{
+				toProxy: test.NewFakeProxy(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			graph := getObjectGraphWithObjs(tt.fields.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+
+			// Trigger discovery the content of the source cluster
+			g.Expect(graph.Discovery("")).To(Succeed())
+
+			mover := objectMover{
+				fromProxy: graph.proxy,
+			}
+
+			err := mover.checkTargetClusterClasses(graph, tt.args.toProxy)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func Test_createTargetObject(t *testing.T) {
 	type args struct {
 		fromProxy Proxy
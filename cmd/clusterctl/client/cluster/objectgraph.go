@@ -36,7 +36,10 @@ import (
 	secretutil "sigs.k8s.io/cluster-api/util/secret"
 )
 
-const clusterTopologyNameKey = "cluster.spec.topology.class"
+const (
+	clusterTopologyNameKey      = "cluster.spec.topology.class"
+	clusterTopologyNamespaceKey = "cluster.spec.topology.classNamespace"
+)
 
 type empty struct{}
 
@@ -132,7 +135,7 @@ func (n *node) identityStr() string {
 
 func (n *node) captureAdditionalInformation(obj *unstructured.Unstructured) error {
 	// If the node is a cluster check it see if it is uses a managed topology.
-	// In case, it uses a managed topology capture the name of the cluster class in use.
+	// In case, it uses a managed topology capture the name and the namespace of the cluster class in use.
 	if n.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
 		cluster := &clusterv1.Cluster{}
 		if err := localScheme.Convert(obj, cluster, nil); err != nil {
@@ -143,6 +146,7 @@ func (n *node) captureAdditionalInformation(obj *unstructured.Unstructured) erro
 				n.additionalInfo = map[string]interface{}{}
 			}
 			n.additionalInfo[clusterTopologyNameKey] = cluster.Spec.Topology.Class
+			n.additionalInfo[clusterTopologyNamespaceKey] = cluster.GetClassKey().Namespace
 		}
 	}
 
@@ -591,7 +595,7 @@ func (o *objectGraph) setSoftOwnership() {
 			// if the cluster uses a managed topoloy and uses the clusterclass
 			// set the clusterclass as a soft owner of the cluster.
 			if className, ok := cluster.additionalInfo[clusterTopologyNameKey]; ok {
-				if className == clusterClass.identity.Name && clusterClass.identity.Namespace == cluster.identity.Namespace {
+				if className == clusterClass.identity.Name && clusterClass.identity.Namespace == cluster.additionalInfo[clusterTopologyNamespaceKey] {
 					cluster.addSoftOwner(clusterClass)
 				}
 			}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/component-base/featuregate"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -42,7 +44,10 @@ import (
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster/internal/dryrun"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/contract"
 	clustertopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
+	"sigs.k8s.io/cluster-api/internal/topology/check"
+	"sigs.k8s.io/cluster-api/internal/topology/variables"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 )
 
@@ -54,6 +59,7 @@ const (
 // TopologyClient has methods to work with ClusterClass and ManagedTopologies.
 type TopologyClient interface {
 	Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error)
+	Rebase(in *TopologyRebaseInput) (*TopologyRebaseOutput, error)
 }
 
 // topologyClient implements TopologyClient.
@@ -218,6 +224,225 @@ func (t *topologyClient) Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error
 	return res, nil
 }
 
+// TopologyRebaseInput defines the input for the Rebase function.
+type TopologyRebaseInput struct {
+	// ClusterName is the name of the Cluster to rebase.
+	ClusterName string
+	// Namespace is the namespace of the Cluster. If empty, the current namespace is used.
+	Namespace string
+	// ClusterClass is the name of the ClusterClass the Cluster should be rebased to.
+	ClusterClass string
+	// ClusterClassNamespace is the namespace of the ClusterClass the Cluster should be rebased to.
+	// If empty, the namespace of the ClusterClass currently used by the Cluster is used.
+	ClusterClassNamespace string
+	// Apply defines if the rebase should be applied to the Cluster; if false, only a preview of the changes is computed.
+	Apply bool
+}
+
+// TopologyRebaseOutput defines the output of the Rebase function.
+type TopologyRebaseOutput struct {
+	// Cluster is the rebased Cluster.
+	Cluster client.ObjectKey
+	// FromClass is the name of the ClusterClass currently used by the Cluster.
+	FromClass string
+	// ToClass is the name of the ClusterClass the Cluster is rebased to.
+	ToClass string
+	// Errors is the list of incompatibilities between the Cluster, its current ClusterClass and the target ClusterClass.
+	// If there are errors, the Cluster can't be rebased, and the change summary is not computed.
+	Errors field.ErrorList
+	// ChangeSummary is the full list of changes (objects created, modified and deleted) observed when
+	// dry running the topology reconciler on the rebased Cluster.
+	*ChangeSummary
+	// Rollouts is the list of objects whose machine templates are modified by the rebase, thus triggering
+	// a rollout of their Machines, e.g. MachineDeployments and control planes.
+	Rollouts []*unstructured.Unstructured
+	// Applied is true if the rebase has been applied to the Cluster.
+	Applied bool
+}
+
+// Rebase rebases a Cluster to a different ClusterClass.
+// It checks that the target ClusterClass allows the namespace of the Cluster, that it is compatible with the current
+// ClusterClass and that the Cluster topology, including its variables, is valid for the target ClusterClass;
+// then it dry runs the topology reconciler on the rebased Cluster to preview the changes and, if requested, applies the rebase.
+func (t *topologyClient) Rebase(in *TopologyRebaseInput) (*TopologyRebaseOutput, error) {
+	ctx := context.TODO()
+
+	if err := t.proxy.CheckClusterAvailable(); err != nil {
+		return nil, errors.Wrap(err, "a management cluster is required to rebase a Cluster")
+	}
+	c, err := t.proxy.NewClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a client to the cluster")
+	}
+
+	namespace := in.Namespace
+	if namespace == "" {
+		namespace, err = t.proxy.CurrentNamespace()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get current namespace")
+		}
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: in.ClusterName}, cluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s/%s", namespace, in.ClusterName)
+	}
+	if cluster.Spec.Topology == nil {
+		return nil, errors.Errorf("Cluster %s/%s does not use a managed topology", namespace, in.ClusterName)
+	}
+	// If the namespace of the target ClusterClass is not specified, the Cluster is rebased to a ClusterClass
+	// in the same namespace of the current ClusterClass, and classNamespace is preserved.
+	currentClassKey := cluster.GetClassKey()
+	targetClassKey := client.ObjectKey{Namespace: currentClassKey.Namespace, Name: in.ClusterClass}
+	classNamespace := cluster.Spec.Topology.ClassNamespace
+	if in.ClusterClassNamespace != "" {
+		targetClassKey.Namespace = in.ClusterClassNamespace
+		classNamespace = in.ClusterClassNamespace
+	}
+	if currentClassKey == targetClassKey {
+		return nil, errors.Errorf("Cluster %s/%s already uses ClusterClass %s", namespace, in.ClusterName, targetClassKey)
+	}
+
+	currentClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, currentClassKey, currentClass); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ClusterClass %s", currentClassKey)
+	}
+	targetClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, targetClassKey, targetClass); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ClusterClass %s", targetClassKey)
+	}
+
+	res := &TopologyRebaseOutput{
+		Cluster:       client.ObjectKeyFromObject(cluster),
+		FromClass:     cluster.Spec.Topology.Class,
+		ToClass:       in.ClusterClass,
+		ChangeSummary: &dryrun.ChangeSummary{},
+	}
+
+	rebasedCluster := cluster.DeepCopy()
+	rebasedCluster.Spec.Topology.Class = in.ClusterClass
+	rebasedCluster.Spec.Topology.ClassNamespace = classNamespace
+
+	// The target ClusterClass must allow the namespace of the Cluster.
+	if targetClassKey.Namespace != namespace {
+		ns := &corev1.Namespace{}
+		if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+			return nil, errors.Wrapf(err, "failed to get Namespace %s", namespace)
+		}
+		allowed, err := check.ClusterClassAllowsNamespace(targetClass, ns)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			res.Errors = append(res.Errors, field.Forbidden(field.NewPath("spec", "topology", "classNamespace"),
+				fmt.Sprintf("ClusterClass %q in namespace %q does not allow Clusters in namespace %q", targetClass.Name, targetClass.Namespace, namespace)))
+			return res, nil
+		}
+	}
+
+	// Run the compatibility checks executed by the Cluster validation webhook when the ClusterClass changes,
+	// and validate the Cluster topology, including its variables, against the target ClusterClass.
+	res.Errors = append(res.Errors, check.ClusterClassesAreCompatible(currentClass, targetClass)...)
+	res.Errors = append(res.Errors, check.MachineDeploymentTopologiesAreValidAndDefinedInClusterClass(rebasedCluster, targetClass)...)
+	res.Errors = append(res.Errors, defaultAndValidateVariables(rebasedCluster, targetClass)...)
+	if len(res.Errors) > 0 {
+		return res, nil
+	}
+
+	// Preview the changes by planning the change of the ClusterClass; the Cluster from the management
+	// cluster is merged with the one in the input, like when applying a file with the same content.
+	rebase := &unstructured.Unstructured{}
+	rebase.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("Cluster"))
+	rebase.SetNamespace(namespace)
+	rebase.SetName(in.ClusterName)
+	if err := unstructured.SetNestedField(rebase.Object, in.ClusterClass, "spec", "topology", "class"); err != nil {
+		return nil, errors.Wrap(err, "failed to set the ClusterClass of the rebased Cluster")
+	}
+	if classNamespace != "" {
+		if err := unstructured.SetNestedField(rebase.Object, classNamespace, "spec", "topology", "classNamespace"); err != nil {
+			return nil, errors.Wrap(err, "failed to set the ClusterClass namespace of the rebased Cluster")
+		}
+	}
+	plan, err := t.Plan(&TopologyPlanInput{
+		Objs:              []*unstructured.Unstructured{rebase},
+		TargetClusterName: in.ClusterName,
+		TargetNamespace:   namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to preview the rebase")
+	}
+	if plan.ChangeSummary != nil {
+		res.ChangeSummary = plan.ChangeSummary
+	}
+	res.Rollouts = rollouts(res.ChangeSummary)
+
+	if !in.Apply {
+		return res, nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.Topology.Class = in.ClusterClass
+	cluster.Spec.Topology.ClassNamespace = classNamespace
+	if err := c.Patch(ctx, cluster, patch); err != nil {
+		return nil, errors.Wrapf(err, "failed to rebase Cluster %s/%s to ClusterClass %s", namespace, in.ClusterName, targetClassKey)
+	}
+	res.Applied = true
+	return res, nil
+}
+
+// defaultAndValidateVariables defaults and validates the variables of the Cluster topology, including the
+// MachineDeployment overrides, against the variable schemas of the ClusterClass, like the Cluster webhooks do.
+func defaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	fldPath := field.NewPath("spec", "topology")
+
+	defaultedVariables, allErrs := variables.DefaultClusterVariables(cluster.Spec.Topology.Variables, clusterClass.Spec.Variables,
+		fldPath.Child("variables"))
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	allErrs = append(allErrs, variables.ValidateClusterVariables(defaultedVariables, clusterClass.Spec.Variables,
+		fldPath.Child("variables"))...)
+
+	if cluster.Spec.Topology.Workers == nil {
+		return allErrs
+	}
+	for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		// Continue if there are no variable overrides.
+		if md.Variables == nil || len(md.Variables.Overrides) == 0 {
+			continue
+		}
+
+		mdPath := fldPath.Child("workers", "machineDeployments").Index(i).Child("variables", "overrides")
+		defaultedOverrides, errs := variables.DefaultMachineDeploymentVariables(md.Variables.Overrides, clusterClass.Spec.Variables, mdPath)
+		if len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		allErrs = append(allErrs, variables.ValidateTopLevelClusterVariablesExist(defaultedOverrides, defaultedVariables, mdPath)...)
+		allErrs = append(allErrs, variables.ValidateMachineDeploymentVariables(defaultedOverrides, clusterClass.Spec.Variables, mdPath)...)
+	}
+	return allErrs
+}
+
+// rollouts returns the modified objects whose machine templates change, i.e. MachineDeployments where
+// spec.template changes, and control planes where spec.machineTemplate.infrastructureRef changes.
+func rollouts(changes *ChangeSummary) []*unstructured.Unstructured {
+	res := []*unstructured.Unstructured{}
+	for _, m := range changes.Modified {
+		path := contract.ControlPlane().MachineTemplate().InfrastructureRef().Path()
+		if m.After.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind() {
+			path = []string{"spec", "template"}
+		}
+
+		before, _, _ := unstructured.NestedFieldNoCopy(m.Before.Object, path...)
+		after, found, _ := unstructured.NestedFieldNoCopy(m.After.Object, path...)
+		if found && !reflect.DeepEqual(before, after) {
+			res = append(res, m.After)
+		}
+	}
+	return res
+}
+
 // validateInput checks that the topology plan input does not violate any of the below expectations:
 // - no more than 1 cluster in the input.
 // - no more than 1 clusterclass in the input.
@@ -582,7 +807,7 @@ func (t *topologyClient) affectedClusters(ctx context.Context, in *TopologyPlanI
 	// Each of the Cluster that uses the ClusterClass in the input is an affected cluster.
 	for _, cc := range affectedClusterClasses {
 		for i := range clusterList.Items {
-			if clusterList.Items[i].Spec.Topology != nil && clusterList.Items[i].Spec.Topology.Class == cc.Name {
+			if clusterList.Items[i].Spec.Topology != nil && clusterList.Items[i].GetClassKey() == cc {
 				affectedClusters[client.ObjectKeyFromObject(&clusterList.Items[i])] = true
 			}
 		}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
 	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
@@ -57,6 +58,23 @@ var (
 
 	//go:embed assets/topology-test/objects-in-different-namespaces.yaml
 	objsInDifferentNamespacesYAML []byte
+
+	// anotherClusterClassYAML uses a different DockerMachineTemplate for the control plane.
+	//go:embed assets/topology-test/another-cluster-class.yaml
+	anotherClusterClassYAML []byte
+
+	// incompatibleClusterClassYAML uses a different kind for the infrastructure cluster template.
+	//go:embed assets/topology-test/incompatible-cluster-class.yaml
+	incompatibleClusterClassYAML []byte
+
+	// sharedClusterClassesYAML defines a ClusterClass in the shared namespace which allows Clusters in the default
+	// namespace, and a ClusterClass in the shared namespace which doesn't.
+	//go:embed assets/topology-test/shared-cluster-classes.yaml
+	sharedClusterClassesYAML []byte
+
+	// variablesClusterClassYAML defines a required variable which is not set by the existing Cluster.
+	//go:embed assets/topology-test/variables-cluster-class.yaml
+	variablesClusterClassYAML []byte
 )
 
 func Test_topologyClient_Plan(t *testing.T) {
@@ -325,6 +343,152 @@ func Test_topologyClient_Plan(t *testing.T) {
 	}
 }
 
+func Test_topologyClient_Rebase(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *TopologyRebaseInput
+		wantErr       bool
+		wantCompatErr bool
+		wantCreated   []string
+		wantRollouts  []string
+		wantClassNS   string
+	}{
+		{
+			name: "Preview the rebase to a compatible ClusterClass",
+			in: &TopologyRebaseInput{
+				ClusterName:  "my-cluster",
+				Namespace:    "default",
+				ClusterClass: "another-cluster-class",
+			},
+			wantCreated:  []string{"DockerMachineTemplate"},
+			wantRollouts: []string{"KubeadmControlPlane"},
+		},
+		{
+			name: "Apply the rebase to a compatible ClusterClass",
+			in: &TopologyRebaseInput{
+				ClusterName:  "my-cluster",
+				Namespace:    "default",
+				ClusterClass: "another-cluster-class",
+				Apply:        true,
+			},
+			wantCreated:  []string{"DockerMachineTemplate"},
+			wantRollouts: []string{"KubeadmControlPlane"},
+		},
+		{
+			name: "Report incompatibilities with the target ClusterClass",
+			in: &TopologyRebaseInput{
+				ClusterName:  "my-cluster",
+				Namespace:    "default",
+				ClusterClass: "incompatible-cluster-class",
+				Apply:        true,
+			},
+			wantCompatErr: true,
+		},
+		{
+			name: "Apply the rebase to a ClusterClass in a namespace which allows the namespace of the Cluster",
+			in: &TopologyRebaseInput{
+				ClusterName:           "my-cluster",
+				Namespace:             "default",
+				ClusterClass:          "shared-cluster-class",
+				ClusterClassNamespace: "shared",
+				Apply:                 true,
+			},
+			wantClassNS: "shared",
+		},
+		{
+			name: "Report a ClusterClass in a namespace which doesn't allow the namespace of the Cluster",
+			in: &TopologyRebaseInput{
+				ClusterName:           "my-cluster",
+				Namespace:             "default",
+				ClusterClass:          "private-cluster-class",
+				ClusterClassNamespace: "shared",
+				Apply:                 true,
+			},
+			wantCompatErr: true,
+		},
+		{
+			name: "Report variables which are not valid for the target ClusterClass",
+			in: &TopologyRebaseInput{
+				ClusterName:  "my-cluster",
+				Namespace:    "default",
+				ClusterClass: "variables-cluster-class",
+				Apply:        true,
+			},
+			wantCompatErr: true,
+		},
+		{
+			name: "Fail if the target ClusterClass does not exist",
+			in: &TopologyRebaseInput{
+				ClusterName:  "my-cluster",
+				Namespace:    "default",
+				ClusterClass: "does-not-exist",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fail if the Cluster already uses the target ClusterClass",
+			in: &TopologyRebaseInput{
+				ClusterName:  "my-cluster",
+				Namespace:    "default",
+				ClusterClass: "my-cluster-class",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			existingObjects := []client.Object{}
+			for _, o := range mustToUnstructured(mockCRDsYAML, existingMyClusterClassYAML, existingMyClusterYAML, anotherClusterClassYAML, incompatibleClusterClassYAML,
+				sharedClusterClassesYAML, variablesClusterClassYAML) {
+				existingObjects = append(existingObjects, o)
+			}
+			proxy := test.NewFakeProxy().WithClusterAvailable(true).WithFakeCAPISetup().WithObjs(existingObjects...)
+			tc := newTopologyClient(proxy, newInventoryClient(proxy, nil))
+
+			res, err := tc.Rebase(tt.in)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(res.Cluster).To(Equal(client.ObjectKey{Namespace: "default", Name: "my-cluster"}))
+			g.Expect(res.FromClass).To(Equal("my-cluster-class"))
+			g.Expect(res.ToClass).To(Equal(tt.in.ClusterClass))
+
+			c, err := proxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+			cluster := &clusterv1.Cluster{}
+			g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "my-cluster"}, cluster)).To(Succeed())
+
+			if tt.wantCompatErr {
+				g.Expect(res.Errors).ToNot(BeEmpty())
+				g.Expect(res.Applied).To(BeFalse())
+				g.Expect(cluster.Spec.Topology.Class).To(Equal("my-cluster-class"))
+				g.Expect(cluster.Spec.Topology.ClassNamespace).To(BeEmpty())
+				return
+			}
+			g.Expect(res.Errors).To(BeEmpty())
+
+			for _, kind := range tt.wantCreated {
+				g.Expect(res.Created).To(ContainElement(MatchTopologyPlanOutputItem(kind, "default", "my-cluster-")))
+			}
+			for _, kind := range tt.wantRollouts {
+				g.Expect(res.Rollouts).To(ContainElement(MatchTopologyPlanOutputItem(kind, "default", "my-cluster-")))
+			}
+
+			g.Expect(res.Applied).To(Equal(tt.in.Apply))
+			if tt.in.Apply {
+				g.Expect(cluster.Spec.Topology.Class).To(Equal(tt.in.ClusterClass))
+				g.Expect(cluster.Spec.Topology.ClassNamespace).To(Equal(tt.wantClassNS))
+			} else {
+				g.Expect(cluster.Spec.Topology.Class).To(Equal("my-cluster-class"))
+			}
+		})
+	}
+}
+
 func MatchTopologyPlanOutputItem(kind, namespace, namePrefix string) types.GomegaMatcher {
 	return &topologyPlanOutputItemMatcher{kind, namespace, namePrefix}
 }
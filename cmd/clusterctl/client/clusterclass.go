@@ -35,7 +35,7 @@ import (
 // are references in the template. If the cluster class referenced already exists in the cluster it is not added to the
 // template.
 func addClusterClassIfMissing(template Template, clusterClassClient repository.ClusterClassClient, clusterClient cluster.Client, targetNamespace string, listVariablesOnly bool) (Template, error) {
-	classes, err := clusterClassNamesFromTemplate(template)
+	classes, err := clusterClassKeysFromTemplate(template, targetNamespace)
 	if err != nil {
 		return nil, err
 	}
@@ -59,11 +59,13 @@ func addClusterClassIfMissing(template Template, clusterClassClient repository.C
 	return mergedTemplate, nil
 }
 
-// clusterClassNamesFromTemplate returns the list of cluster classes referenced
+// clusterClassKeysFromTemplate returns the list of cluster classes referenced
 // by custers defined in the template. If not clusters are defined in the template
 // or if no cluster uses a cluster class it returns an empty list.
-func clusterClassNamesFromTemplate(template Template) ([]string, error) {
-	classes := []string{}
+// NOTE: cluster classes are expected to be in the target namespace, unless
+// spec.topology.classNamespace is set.
+func clusterClassKeysFromTemplate(template Template, targetNamespace string) ([]client.ObjectKey, error) {
+	classes := []client.ObjectKey{}
 
 	// loop thorugh all the objects and if the object is a cluster
 	// check and see if cluster.spec.topology.class is defined.
@@ -80,14 +82,20 @@ func clusterClassNamesFromTemplate(template Template) ([]string, error) {
 		if cluster.Spec.Topology == nil {
 			continue
 		}
-		classes = append(classes, cluster.Spec.Topology.Class)
+		namespace := cluster.Spec.Topology.ClassNamespace
+		if namespace == "" {
+			namespace = targetNamespace
+		}
+		classes = append(classes, client.ObjectKey{Namespace: namespace, Name: cluster.Spec.Topology.Class})
 	}
 	return classes, nil
 }
 
 // fetchMissingClusterClassTemplates returns a list of templates for cluster classes that do not yet exist
 // in the cluster. If the cluster is not initialized, all the ClusterClasses are added.
-func fetchMissingClusterClassTemplates(clusterClassClient repository.ClusterClassClient, clusterClient cluster.Client, classes []string, targetNamespace string, listVariablesOnly bool) (Template, error) {
+// NOTE: ClusterClasses in a namespace other than the target namespace are never added, because
+// the template can only target a single namespace; they are expected to be installed beforehand.
+func fetchMissingClusterClassTemplates(clusterClassClient repository.ClusterClassClient, clusterClient cluster.Client, classes []client.ObjectKey, targetNamespace string, listVariablesOnly bool) (Template, error) {
 	// first check if the cluster is initialized.
 	// If it is initialized:
 	//    For every ClusterClass check if it already exists in the cluster.
@@ -118,7 +126,7 @@ func fetchMissingClusterClassTemplates(clusterClassClient repository.ClusterClas
 	templates := []repository.Template{}
 	for _, class := range classes {
 		if clusterInitialized {
-			exists, err := clusterClassExists(c, class, targetNamespace)
+			exists, err := clusterClassExists(c, class.Name, class.Namespace)
 			if err != nil {
 				return nil, err
 			}
@@ -126,11 +134,17 @@ func fetchMissingClusterClassTemplates(clusterClassClient repository.ClusterClas
 				continue
 			}
 		}
+		if class.Namespace != targetNamespace {
+			if clusterInitialized {
+				return nil, errors.Errorf("ClusterClass %q does not exist in the cluster; ClusterClasses outside of the target namespace %q must be installed beforehand", class.String(), targetNamespace)
+			}
+			continue
+		}
 		// The cluster is either not initialized or the ClusterClass does not yet exist in the cluster.
 		// Fetch the cluster class to install.
-		clusterClassTemplate, err := clusterClassClient.Get(class, targetNamespace, listVariablesOnly)
+		clusterClassTemplate, err := clusterClassClient.Get(class.Name, class.Namespace, listVariablesOnly)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get the cluster class template for %q", class)
+			return nil, errors.Wrapf(err, "failed to get the cluster class template for %q", class.String())
 		}
 
 		// If any of the objects in the ClusterClass template already exist in the cluster then
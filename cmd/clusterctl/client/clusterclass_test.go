@@ -97,6 +97,7 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 		objs                        []client.Object
 		clusterClassTemplateContent []byte
 		targetNamespace             string
+		classNamespace              string
 		listVariablesOnly           bool
 		wantClusterClassInTemplate  bool
 		wantError                   bool
@@ -148,6 +149,44 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 			wantClusterClassInTemplate:  false,
 			wantError:                   true,
 		},
+		{
+			name:                        "should NOT add the cluster class to the template if cluster is not initialized and the class namespace is not the target namespace",
+			clusterInitialized:          false,
+			objs:                        []client.Object{},
+			targetNamespace:             "ns5",
+			classNamespace:              "shared",
+			clusterClassTemplateContent: clusterClassYAML("shared", "dev"),
+			listVariablesOnly:           false,
+			wantClusterClassInTemplate:  false,
+			wantError:                   false,
+		},
+		{
+			name:                        "should throw error if the cluster is initialized and the cluster class is not installed in the class namespace",
+			clusterInitialized:          true,
+			objs:                        []client.Object{},
+			targetNamespace:             "ns5",
+			classNamespace:              "shared",
+			clusterClassTemplateContent: clusterClassYAML("shared", "dev"),
+			listVariablesOnly:           false,
+			wantClusterClassInTemplate:  false,
+			wantError:                   true,
+		},
+		{
+			name:               "should NOT add the cluster class to the template if cluster is initialized and cluster class is installed in the class namespace",
+			clusterInitialized: true,
+			objs: []client.Object{&clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dev",
+					Namespace: "shared",
+				},
+			}},
+			targetNamespace:             "ns6",
+			classNamespace:              "shared",
+			clusterClassTemplateContent: clusterClassYAML("shared", "dev"),
+			listVariablesOnly:           false,
+			wantClusterClassInTemplate:  false,
+			wantError:                   false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,7 +230,10 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 				fmt.Sprintf("  namespace: %s\n", tt.targetNamespace) +
 				"spec:\n" +
 				"  topology:\n" +
-				"    class: dev")
+				"    class: dev\n")
+			if tt.classNamespace != "" {
+				clusterWithTopology = append(clusterWithTopology, []byte(fmt.Sprintf("    classNamespace: %s\n", tt.classNamespace))...)
+			}
 
 			baseTemplate, err := repository.NewTemplate(repository.TemplateInput{
 				RawArtifact:           clusterWithTopology,
@@ -206,13 +248,17 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 
 			g := NewWithT(t)
 			template, err := addClusterClassIfMissing(baseTemplate, clusterClassClient, cluster, tt.targetNamespace, tt.listVariablesOnly)
+			classNamespace := tt.targetNamespace
+			if tt.classNamespace != "" {
+				classNamespace = tt.classNamespace
+			}
 			if tt.wantError {
 				g.Expect(err).To(HaveOccurred())
 			} else {
 				if tt.wantClusterClassInTemplate {
-					g.Expect(template.Objs()).To(ContainElement(MatchClusterClass("dev", tt.targetNamespace)))
+					g.Expect(template.Objs()).To(ContainElement(MatchClusterClass("dev", classNamespace)))
 				} else {
-					g.Expect(template.Objs()).NotTo(ContainElement(MatchClusterClass("dev", tt.targetNamespace)))
+					g.Expect(template.Objs()).NotTo(ContainElement(MatchClusterClass("dev", classNamespace)))
 				}
 			}
 		})
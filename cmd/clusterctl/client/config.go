@@ -45,6 +45,10 @@ func (c *clusterctlClient) GetProvidersConfig() ([]Provider, error) {
 	return rr, nil
 }
 
+func (c *clusterctlClient) CleanCache() error {
+	return repository.CleanCache(c.configClient)
+}
+
 func (c *clusterctlClient) GetProviderComponents(provider string, providerType clusterctlv1.ProviderType, options ComponentsOptions) (Components, error) {
 	components, err := c.getComponentsByName(provider, providerType, repository.ComponentsOptions(options))
 	if err != nil {
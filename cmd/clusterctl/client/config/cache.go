@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "time"
+
+// Cache defines the configuration of the on-disk cache for provider repository downloads.
+type Cache interface {
+	// Disabled returns true if the on-disk cache should not be used.
+	Disabled() bool
+
+	// Offline returns true if clusterctl should use only cached data, without accessing provider repositories.
+	Offline() bool
+
+	// Path returns the folder where cached data are stored.
+	// If empty, $HOME/.cluster-api/cache will be used.
+	Path() string
+
+	// TTL returns how long cached API responses, e.g. the list of releases, are used before revalidation.
+	// If empty, 1h will be used.
+	TTL() time.Duration
+
+	// AssetsTTL returns how long cached release assets are used before downloading them again.
+	// If empty, 720h will be used.
+	AssetsTTL() time.Duration
+}
+
+// cache implements Cache.
+type cache struct {
+	disabled  bool
+	offline   bool
+	path      string
+	ttl       time.Duration
+	assetsTTL time.Duration
+}
+
+// ensure cache implements Cache.
+var _ Cache = &cache{}
+
+func (c *cache) Disabled() bool {
+	return c.disabled
+}
+
+func (c *cache) Offline() bool {
+	return c.offline
+}
+
+func (c *cache) Path() string {
+	return c.path
+}
+
+func (c *cache) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *cache) AssetsTTL() time.Duration {
+	return c.assetsTTL
+}
+
+// NewCache creates a new Cache with the given configuration.
+func NewCache(path string, disabled, offline bool, ttl, assetsTTL time.Duration) Cache {
+	return &cache{
+		disabled:  disabled,
+		offline:   offline,
+		path:      path,
+		ttl:       ttl,
+		assetsTTL: assetsTTL,
+	}
+}
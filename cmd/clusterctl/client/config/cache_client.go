@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/homedir"
+)
+
+const (
+	// CacheConfigKey defines the name of the top level config key for the cache configuration.
+	CacheConfigKey = "cache"
+
+	// CacheFolder defines the name of the cache folder under ConfigFolder.
+	CacheFolder = "cache"
+
+	// CacheOfflineVariable defines a variable that, if set to true, forces clusterctl to use only cached data.
+	// It is set by the --offline flag.
+	CacheOfflineVariable = "CLUSTERCTL_OFFLINE"
+
+	// CacheDefaultTTL defines the default duration cached API responses are used before revalidation.
+	CacheDefaultTTL = 1 * time.Hour
+
+	// CacheDefaultAssetsTTL defines the default duration cached release assets are used before downloading them again.
+	CacheDefaultAssetsTTL = 30 * 24 * time.Hour
+)
+
+// CacheClient has methods to work with the cache configuration.
+type CacheClient interface {
+	// Get returns the cache configuration.
+	Get() (Cache, error)
+}
+
+// cacheClient implements CacheClient.
+type cacheClient struct {
+	reader Reader
+}
+
+// ensure cacheClient implements CacheClient.
+var _ CacheClient = &cacheClient{}
+
+func newCacheClient(reader Reader) *cacheClient {
+	return &cacheClient{
+		reader: reader,
+	}
+}
+
+// configCache mirrors config.Cache interface and allows serialization of the corresponding info.
+type configCache struct {
+	Disabled  bool   `json:"disabled,omitempty"`
+	Offline   bool   `json:"offline,omitempty"`
+	Folder    string `json:"folder,omitempty"`
+	TTL       string `json:"ttl,omitempty"`
+	AssetsTTL string `json:"assetsTTL,omitempty"`
+}
+
+func (p *cacheClient) Get() (Cache, error) {
+	path := filepath.Join(homedir.HomeDir(), ConfigFolder, CacheFolder)
+	ttl := CacheDefaultTTL
+	assetsTTL := CacheDefaultAssetsTTL
+
+	userCache := &configCache{}
+	if err := p.reader.UnmarshalKey(CacheConfigKey, &userCache); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal cache from the clusterctl configuration file")
+	}
+	if userCache.Folder != "" {
+		path = userCache.Folder
+	}
+	if userCache.TTL != "" {
+		d, err := time.ParseDuration(userCache.TTL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse cache ttl %q", userCache.TTL)
+		}
+		ttl = d
+	}
+	if userCache.AssetsTTL != "" {
+		d, err := time.ParseDuration(userCache.AssetsTTL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse cache assetsTTL %q", userCache.AssetsTTL)
+		}
+		assetsTTL = d
+	}
+
+	offline := userCache.Offline
+	if v, err := p.reader.Get(CacheOfflineVariable); err == nil && v == "true" {
+		offline = true
+	}
+
+	return NewCache(path, userCache.Disabled, offline, ttl, assetsTTL), nil
+}
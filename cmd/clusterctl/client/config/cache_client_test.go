@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/homedir"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func TestCacheGet(t *testing.T) {
+	defaultPath := filepath.Join(homedir.HomeDir(), ConfigFolder, CacheFolder)
+
+	type fields struct {
+		reader Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    Cache
+		wantErr bool
+	}{
+		{
+			name: "return defaults if no custom config is provided",
+			fields: fields{
+				reader: test.NewFakeReader(),
+			},
+			want:    NewCache(defaultPath, false, false, CacheDefaultTTL, CacheDefaultAssetsTTL),
+			wantErr: false,
+		},
+		{
+			name: "return custom config if defined",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(CacheConfigKey, "folder: /tmp/cache\nttl: 5m\nassetsTTL: 24h\ndisabled: true\noffline: true"),
+			},
+			want:    NewCache("/tmp/cache", true, true, 5*time.Minute, 24*time.Hour),
+			wantErr: false,
+		},
+		{
+			name: "return offline if the offline variable is set",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(CacheOfflineVariable, "true"),
+			},
+			want:    NewCache(defaultPath, false, true, CacheDefaultTTL, CacheDefaultAssetsTTL),
+			wantErr: false,
+		},
+		{
+			name: "fails if ttl is not a valid duration",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(CacheConfigKey, "ttl: foo"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := &cacheClient{
+				reader: tt.fields.reader,
+			}
+			got, err := p.Get()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -25,7 +25,10 @@ import (
 // 1. The cert manager configuration (URL of the repository)
 // 2. The configuration of the providers (name, type and URL of the provider repository)
 // 3. Variables used when installing providers/creating clusters. Variables can be read from the environment or from the config file
-// 4. The configuration about image overrides.
+// 4. The configuration about image overrides
+// 5. The configuration of the on-disk cache for provider repository downloads
+// 6. The configuration about verification of provider release assets
+// 7. The configuration of the template processor to be used for each provider.
 type Client interface {
 	// CertManager provide access to the cert-manager configurations.
 	CertManager() CertManagerClient
@@ -38,6 +41,15 @@ type Client interface {
 
 	// ImageMeta provide access to to image meta configurations.
 	ImageMeta() ImageMetaClient
+
+	// Cache provide access to the cache configurations.
+	Cache() CacheClient
+
+	// Verification provide access to the provider verification configurations.
+	Verification() VerificationClient
+
+	// TemplateProcessors provide access to the template processor configurations.
+	TemplateProcessors() TemplateProcessorsClient
 }
 
 // configClient implements Client.
@@ -64,6 +76,18 @@ func (c *configClient) ImageMeta() ImageMetaClient {
 	return newImageMetaClient(c.reader)
 }
 
+func (c *configClient) Cache() CacheClient {
+	return newCacheClient(c.reader)
+}
+
+func (c *configClient) Verification() VerificationClient {
+	return newVerificationClient(c.reader)
+}
+
+func (c *configClient) TemplateProcessors() TemplateProcessorsClient {
+	return newTemplateProcessorsClient(c.reader)
+}
+
 // Option is a configuration option supplied to New.
 type Option func(*configClient)
 
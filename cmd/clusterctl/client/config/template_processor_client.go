@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// TemplateProcessorsConfigKey defines the name of the top level config key for template processor configuration.
+	TemplateProcessorsConfigKey = "templateProcessors"
+
+	// SimpleTemplateProcessor defines the name of the envsubst based template processor, used by default.
+	SimpleTemplateProcessor = "simple"
+
+	// GoTemplateProcessor defines the name of the go text/template based template processor.
+	GoTemplateProcessor = "gotemplate"
+)
+
+// TemplateProcessorsClient has methods to work with template processor configurations.
+type TemplateProcessorsClient interface {
+	// Get returns the name of the template processor to be used for processing the templates of a provider.
+	Get(provider Provider) (string, error)
+}
+
+// templateProcessorsClient implements TemplateProcessorsClient.
+type templateProcessorsClient struct {
+	reader Reader
+}
+
+// ensure templateProcessorsClient implements TemplateProcessorsClient.
+var _ TemplateProcessorsClient = &templateProcessorsClient{}
+
+func newTemplateProcessorsClient(reader Reader) *templateProcessorsClient {
+	return &templateProcessorsClient{
+		reader: reader,
+	}
+}
+
+func (p *templateProcessorsClient) Get(provider Provider) (string, error) {
+	// Template processors are defined by provider, using the provider label as a key, e.g. infrastructure-aws.
+	var userTemplateProcessors map[string]string
+	if err := p.reader.UnmarshalKey(TemplateProcessorsConfigKey, &userTemplateProcessors); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal template processor configurations from the clusterctl configuration file")
+	}
+
+	switch name := userTemplateProcessors[provider.ManifestLabel()]; name {
+	case "":
+		return SimpleTemplateProcessor, nil
+	case SimpleTemplateProcessor, GoTemplateProcessor:
+		return name, nil
+	default:
+		return "", errors.Errorf("invalid template processor %q for %s: supported values are %q and %q", name, provider.ManifestLabel(), SimpleTemplateProcessor, GoTemplateProcessor)
+	}
+}
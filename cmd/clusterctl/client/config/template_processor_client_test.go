@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func TestTemplateProcessorsGet(t *testing.T) {
+	provider := NewProvider("foo", "", clusterctlv1.InfrastructureProviderType)
+
+	type fields struct {
+		reader Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "return the simple processor if no template processor is configured",
+			fields: fields{
+				reader: test.NewFakeReader(),
+			},
+			want:    SimpleTemplateProcessor,
+			wantErr: false,
+		},
+		{
+			name: "return the simple processor if the template processor is not configured for the provider",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(TemplateProcessorsConfigKey, "infrastructure-bar: gotemplate"),
+			},
+			want:    SimpleTemplateProcessor,
+			wantErr: false,
+		},
+		{
+			name: "return the template processor configured for the provider",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(TemplateProcessorsConfigKey, "infrastructure-foo: gotemplate"),
+			},
+			want:    GoTemplateProcessor,
+			wantErr: false,
+		},
+		{
+			name: "fails if the template processor is not supported",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(TemplateProcessorsConfigKey, "infrastructure-foo: jsonnet"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := &templateProcessorsClient{
+				reader: tt.fields.reader,
+			}
+			got, err := p.Get(provider)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -19,6 +19,22 @@ package config
 const (
 	// GitHubTokenVariable defines a variable hosting the GitHub access token.
 	GitHubTokenVariable = "github-token"
+
+	// OCIUsernameVariable defines a variable hosting the username used to authenticate to OCI registries.
+	OCIUsernameVariable = "oci-username"
+
+	// OCIPasswordVariable defines a variable hosting the password (or access token) used to authenticate to OCI registries.
+	OCIPasswordVariable = "oci-password"
+
+	// HTTPRepositoryUsernameVariable defines a variable hosting the username used for basic auth to HTTP repositories.
+	HTTPRepositoryUsernameVariable = "http-repository-username"
+
+	// HTTPRepositoryPasswordVariable defines a variable hosting the password used for basic auth to HTTP repositories.
+	HTTPRepositoryPasswordVariable = "http-repository-password"
+
+	// HTTPRepositoryTokenVariable defines a variable hosting the bearer token used to authenticate to HTTP repositories.
+	// If set, it takes precedence over basic auth.
+	HTTPRepositoryTokenVariable = "http-repository-token"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+// Verification defines how to verify the release assets of a provider.
+type Verification interface {
+	// ChecksumsFile returns the name of the file, published in the provider repository together with the
+	// release assets, listing the sha256 checksums of the release assets.
+	// If empty, "checksums.txt" will be used.
+	ChecksumsFile() string
+
+	// SignatureFile returns the name of the file, published in the provider repository together with the
+	// release assets, containing the detached signature of the checksums file.
+	// If empty and a public key is defined, ChecksumsFile + ".sig" will be used.
+	SignatureFile() string
+
+	// PublicKey returns the PEM encoded public key to be used for verifying the signature of the checksums file.
+	// If empty, the signature is not verified.
+	PublicKey() []byte
+}
+
+// verification implements Verification.
+type verification struct {
+	checksumsFile string
+	signatureFile string
+	publicKey     []byte
+}
+
+// ensure verification implements Verification.
+var _ Verification = &verification{}
+
+func (v *verification) ChecksumsFile() string {
+	return v.checksumsFile
+}
+
+func (v *verification) SignatureFile() string {
+	return v.signatureFile
+}
+
+func (v *verification) PublicKey() []byte {
+	return v.publicKey
+}
+
+// NewVerification creates a new Verification with the given configuration.
+func NewVerification(checksumsFile, signatureFile string, publicKey []byte) Verification {
+	return &verification{
+		checksumsFile: checksumsFile,
+		signatureFile: signatureFile,
+		publicKey:     publicKey,
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// VerificationConfigKey defines the name of the top level config key for provider verification configuration.
+	VerificationConfigKey = "verification"
+
+	// VerificationDefaultChecksumsFile defines the default name of the checksums file.
+	VerificationDefaultChecksumsFile = "checksums.txt"
+
+	// VerificationDefaultSignatureSuffix defines the default suffix appended to the checksums file name
+	// for getting the signature file name.
+	VerificationDefaultSignatureSuffix = ".sig"
+
+	pemPrefix = "-----BEGIN"
+)
+
+// VerificationClient has methods to work with provider verification configurations.
+type VerificationClient interface {
+	// Get returns the verification configuration for a provider, or nil if verification is not enabled for it.
+	Get(provider Provider) (Verification, error)
+}
+
+// verificationClient implements VerificationClient.
+type verificationClient struct {
+	reader Reader
+}
+
+// ensure verificationClient implements VerificationClient.
+var _ VerificationClient = &verificationClient{}
+
+func newVerificationClient(reader Reader) *verificationClient {
+	return &verificationClient{
+		reader: reader,
+	}
+}
+
+// configVerification mirrors config.Verification interface and allows serialization of the corresponding info.
+type configVerification struct {
+	ChecksumsFile string `json:"checksumsFile,omitempty"`
+	SignatureFile string `json:"signatureFile,omitempty"`
+	// PublicKey is either a PEM encoded public key or the path to a file containing it.
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
+func (p *verificationClient) Get(provider Provider) (Verification, error) {
+	// Verification configurations are defined by provider, using the provider label as a key, e.g. infrastructure-aws.
+	var userVerifications map[string]configVerification
+	if err := p.reader.UnmarshalKey(VerificationConfigKey, &userVerifications); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal verification configurations from the clusterctl configuration file")
+	}
+
+	userVerification, ok := userVerifications[provider.ManifestLabel()]
+	if !ok {
+		return nil, nil
+	}
+
+	checksumsFile := VerificationDefaultChecksumsFile
+	if userVerification.ChecksumsFile != "" {
+		checksumsFile = userVerification.ChecksumsFile
+	}
+
+	var publicKey []byte
+	switch key := strings.TrimSpace(userVerification.PublicKey); {
+	case key == "":
+	case strings.HasPrefix(key, pemPrefix):
+		publicKey = []byte(key)
+	default:
+		content, err := os.ReadFile(key) //nolint:gosec // No security issue: the path is defined by the user.
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the public key for verifying %s", provider.ManifestLabel())
+		}
+		publicKey = content
+	}
+
+	signatureFile := userVerification.SignatureFile
+	if signatureFile == "" && publicKey != nil {
+		signatureFile = checksumsFile + VerificationDefaultSignatureSuffix
+	}
+	if signatureFile != "" && publicKey == nil {
+		return nil, errors.Errorf("invalid verification configuration for %s: a public key is required for verifying the signature file", provider.ManifestLabel())
+	}
+
+	return NewVerification(checksumsFile, signatureFile, publicKey), nil
+}
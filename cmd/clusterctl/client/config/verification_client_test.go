@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func TestVerificationGet(t *testing.T) {
+	publicKey := "-----BEGIN PUBLIC KEY-----\nfoo\n-----END PUBLIC KEY-----"
+	publicKeyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	if err := os.WriteFile(publicKeyFile, []byte(publicKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewProvider("foo", "", clusterctlv1.InfrastructureProviderType)
+
+	type fields struct {
+		reader Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    Verification
+		wantErr bool
+	}{
+		{
+			name: "return nil if no verification is configured",
+			fields: fields{
+				reader: test.NewFakeReader(),
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "return nil if verification is not configured for the provider",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(VerificationConfigKey, "infrastructure-bar: {}"),
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "return checksums only verification with defaults",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(VerificationConfigKey, "infrastructure-foo: {}"),
+			},
+			want:    NewVerification(VerificationDefaultChecksumsFile, "", nil),
+			wantErr: false,
+		},
+		{
+			name: "return signature verification with inline public key",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(VerificationConfigKey, "infrastructure-foo:\n  checksumsFile: SHA256SUMS\n  publicKey: |\n    -----BEGIN PUBLIC KEY-----\n    foo\n    -----END PUBLIC KEY-----\n"),
+			},
+			want:    NewVerification("SHA256SUMS", "SHA256SUMS.sig", []byte(publicKey)),
+			wantErr: false,
+		},
+		{
+			name: "return signature verification with public key from file",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(VerificationConfigKey, "infrastructure-foo:\n  signatureFile: checksums.sig\n  publicKey: "+publicKeyFile),
+			},
+			want:    NewVerification(VerificationDefaultChecksumsFile, "checksums.sig", []byte(publicKey)),
+			wantErr: false,
+		},
+		{
+			name: "fails if the signature file is defined without a public key",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(VerificationConfigKey, "infrastructure-foo:\n  signatureFile: checksums.sig"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the public key file does not exist",
+			fields: fields{
+				reader: test.NewFakeReader().WithVar(VerificationConfigKey, "infrastructure-foo:\n  publicKey: /does/not/exist"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := &verificationClient{
+				reader: tt.fields.reader,
+			}
+			got, err := p.Get(provider)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.want == nil {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
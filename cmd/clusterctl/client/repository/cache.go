@@ -0,0 +1,295 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// errOfflineCacheMiss is returned when running in offline mode and the requested data are not in the cache.
+var errOfflineCacheMiss = errors.New("data not available in the clusterctl cache while running in offline mode")
+
+// diskCache implements a persistent cache for provider repository downloads, stored under the clusterctl config folder.
+//
+// The cache handles two type of data:
+//   - API responses, e.g. the list of releases, that are revalidated using ETag/Last-Modified once TTL has expired.
+//   - Release assets, that are considered immutable and thus downloaded again only once AssetsTTL has expired.
+//
+// When running in offline mode, cached data are always used regardless of TTLs, and cache misses are reported as errors.
+//
+// NOTE: a nil diskCache is valid and behaves as a disabled cache.
+type diskCache struct {
+	path      string
+	ttl       time.Duration
+	assetsTTL time.Duration
+	offline   bool
+}
+
+// diskCacheEntry defines the metadata stored for each cached item.
+type diskCacheEntry struct {
+	Key          string      `json:"key"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Header       http.Header `json:"header,omitempty"`
+	FetchedAt    time.Time   `json:"fetchedAt"`
+}
+
+// newDiskCache returns a diskCache according to the cache configuration; nil is returned if the cache is disabled.
+func newDiskCache(configClient config.Client) (*diskCache, error) {
+	cacheConfig, err := configClient.Cache().Get()
+	if err != nil {
+		return nil, err
+	}
+	if cacheConfig.Disabled() {
+		if cacheConfig.Offline() {
+			return nil, errors.New("offline mode requires the clusterctl cache to be enabled")
+		}
+		return nil, nil
+	}
+	return &diskCache{
+		path:      cacheConfig.Path(),
+		ttl:       cacheConfig.TTL(),
+		assetsTTL: cacheConfig.AssetsTTL(),
+		offline:   cacheConfig.Offline(),
+	}, nil
+}
+
+// CleanCache removes all the data stored in the clusterctl cache.
+func CleanCache(configClient config.Client) error {
+	cacheConfig, err := configClient.Cache().Get()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(cacheConfig.Path()); err != nil {
+		return errors.Wrapf(err, "failed to remove the clusterctl cache folder %q", cacheConfig.Path())
+	}
+	return nil
+}
+
+// getAsset returns a cached release asset, if it exists and is not expired.
+func (c *diskCache) getAsset(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, content, ok := c.read(key)
+	if !ok {
+		return nil, false
+	}
+	if !c.offline && time.Since(entry.FetchedAt) > c.assetsTTL {
+		return nil, false
+	}
+	return content, true
+}
+
+// setAsset stores a release asset in the cache.
+func (c *diskCache) setAsset(key string, content []byte) {
+	if c == nil {
+		return
+	}
+	c.write(&diskCacheEntry{Key: key, FetchedAt: time.Now()}, content)
+}
+
+// client returns an http.Client using the cache for GET requests; if base is nil, http.DefaultClient is used.
+func (c *diskCache) client(base *http.Client) *http.Client {
+	if base == nil {
+		base = http.DefaultClient
+	}
+	if c == nil {
+		return base
+	}
+
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client := *base
+	client.Transport = &diskCacheTransport{cache: c, base: transport}
+	return &client
+}
+
+// read returns a cached item, if any.
+func (c *diskCache) read(key string) (*diskCacheEntry, []byte, bool) {
+	name := c.fileName(key)
+
+	metadata, err := os.ReadFile(name + ".json") //nolint:gosec // No security issue: the file name is derived from a hash.
+	if err != nil {
+		return nil, nil, false
+	}
+	entry := &diskCacheEntry{}
+	if err := json.Unmarshal(metadata, entry); err != nil || entry.Key != key {
+		return nil, nil, false
+	}
+
+	content, err := os.ReadFile(name) //nolint:gosec // No security issue: the file name is derived from a hash.
+	if err != nil {
+		return nil, nil, false
+	}
+	return entry, content, true
+}
+
+// write stores an item in the cache. Errors are logged but otherwise ignored, given that
+// failing to write to the cache should not block clusterctl operations.
+func (c *diskCache) write(entry *diskCacheEntry, content []byte) {
+	log := logf.Log
+
+	if err := c.writeFiles(entry, content); err != nil {
+		log.V(5).Info("Failed to write to the clusterctl cache", "Key", entry.Key, "Error", err.Error())
+	}
+}
+
+func (c *diskCache) writeFiles(entry *diskCacheEntry, content []byte) error {
+	if err := os.MkdirAll(c.path, 0750); err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	name := c.fileName(entry.Key)
+	if content != nil {
+		if err := writeFileAtomically(name, content); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomically(name+".json", metadata)
+}
+
+func (c *diskCache) fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.path, hex.EncodeToString(sum[:]))
+}
+
+// writeFileAtomically writes a file using a temporary file and a rename, so concurrent
+// clusterctl processes never read partially written files.
+func writeFileAtomically(name string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// diskCacheTransport is an http.RoundTripper caching successful GET responses in a diskCache.
+type diskCacheTransport struct {
+	cache *diskCache
+	base  http.RoundTripper
+}
+
+func (t *diskCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := fmt.Sprintf("%s|%s", req.URL.String(), req.Header.Get("Accept"))
+	entry, content, cached := t.cache.read(key)
+
+	if cached && (t.cache.offline || time.Since(entry.FetchedAt) < t.cache.ttl) {
+		return cachedResponse(req, entry, content), nil
+	}
+	if t.cache.offline {
+		return nil, errors.Wrapf(errOfflineCacheMiss, "failed to get %q", req.URL.Redacted())
+	}
+
+	// If there is an expired entry, revalidate it using a conditional request.
+	if cached && (entry.ETag != "" || entry.LastModified != "") {
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cached && resp.StatusCode == http.StatusNotModified:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		entry.FetchedAt = time.Now()
+		t.cache.write(entry, nil)
+		return cachedResponse(req, entry, content), nil
+	case resp.StatusCode == http.StatusOK:
+		content, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		t.cache.write(&diskCacheEntry{
+			Key:          key,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Header:       resp.Header.Clone(),
+			FetchedAt:    time.Now(),
+		}, content)
+
+		resp.Body = io.NopCloser(bytes.NewReader(content))
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
+
+// cachedResponse builds a response for a request using cached data.
+func cachedResponse(req *http.Request, entry *diskCacheEntry, content []byte) *http.Response {
+	header := entry.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(content)),
+		ContentLength: int64(len(content)),
+		Request:       req,
+	}
+}
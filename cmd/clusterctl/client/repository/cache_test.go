@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_diskCache_client(t *testing.T) {
+	var requests, conditionalRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditionalRequests++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "releases")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	get := func(g *WithT, c *diskCache) (string, error) {
+		response, err := c.client(server.Client()).Get(server.URL + "/releases")
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+		g.Expect(response.StatusCode).To(Equal(http.StatusOK))
+		content, err := io.ReadAll(response.Body)
+		g.Expect(err).NotTo(HaveOccurred())
+		return string(content), nil
+	}
+
+	t.Run("responses are cached until ttl expires, then revalidated", func(t *testing.T) {
+		g := NewWithT(t)
+		requests, conditionalRequests = 0, 0
+
+		c := &diskCache{path: t.TempDir(), ttl: time.Hour}
+
+		// First request hits the server.
+		got, err := get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal("releases"))
+		g.Expect(requests).To(Equal(1))
+
+		// Second request is served from the cache.
+		got, err = get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal("releases"))
+		g.Expect(requests).To(Equal(1))
+
+		// Once ttl expires, the cached response is revalidated.
+		c.ttl = 0
+		got, err = get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal("releases"))
+		g.Expect(requests).To(Equal(2))
+		g.Expect(conditionalRequests).To(Equal(1))
+	})
+
+	t.Run("offline mode uses only cached responses", func(t *testing.T) {
+		g := NewWithT(t)
+		requests, conditionalRequests = 0, 0
+
+		c := &diskCache{path: t.TempDir(), ttl: 0, offline: true}
+
+		_, err := get(g, c)
+		g.Expect(err).To(MatchError(ContainSubstring(errOfflineCacheMiss.Error())))
+		g.Expect(requests).To(Equal(0))
+
+		// Populate the cache, then check expired responses are used while offline.
+		c.offline = false
+		_, err = get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(requests).To(Equal(1))
+
+		c.offline = true
+		got, err := get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal("releases"))
+		g.Expect(requests).To(Equal(1))
+	})
+
+	t.Run("nil cache does not cache responses", func(t *testing.T) {
+		g := NewWithT(t)
+		requests, conditionalRequests = 0, 0
+
+		var c *diskCache
+		_, err := get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = get(g, c)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(requests).To(Equal(2))
+	})
+}
+
+func Test_diskCache_assets(t *testing.T) {
+	g := NewWithT(t)
+
+	c := &diskCache{path: t.TempDir(), assetsTTL: time.Hour}
+
+	_, ok := c.getAsset("foo")
+	g.Expect(ok).To(BeFalse())
+
+	c.setAsset("foo", []byte("content"))
+	got, ok := c.getAsset("foo")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got).To(Equal([]byte("content")))
+
+	// Expired assets are not returned, unless running offline.
+	c.assetsTTL = 0
+	_, ok = c.getAsset("foo")
+	g.Expect(ok).To(BeFalse())
+
+	c.offline = true
+	got, ok = c.getAsset("foo")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got).To(Equal([]byte("content")))
+}
+
+func Test_CleanCache(t *testing.T) {
+	g := NewWithT(t)
+
+	cachePath := t.TempDir()
+	configClient, err := config.New("", config.InjectReader(test.NewFakeReader().WithVar(config.CacheConfigKey, "folder: "+cachePath)))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	c, err := newDiskCache(configClient)
+	g.Expect(err).NotTo(HaveOccurred())
+	c.setAsset("foo", []byte("content"))
+
+	g.Expect(CleanCache(configClient)).To(Succeed())
+	_, err = os.Stat(cachePath)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
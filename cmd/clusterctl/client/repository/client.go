@@ -95,8 +95,9 @@ func InjectRepository(repository Repository) Option {
 }
 
 // InjectYamlProcessor allows you to override the yaml processor that the
-// repository client uses. By default, the SimpleProcessor is used. This is
-// true even if a nil processor is injected.
+// repository client uses. By default, the processor configured for the provider
+// in the clusterctl configuration file is used, or the SimpleProcessor if none is
+// configured. This is true even if a nil processor is injected.
 func InjectYamlProcessor(p yaml.Processor) Option {
 	return func(c *repositoryClient) {
 		if p != nil {
@@ -114,21 +115,38 @@ func newRepositoryClient(provider config.Provider, configClient config.Client, o
 	client := &repositoryClient{
 		Provider:     provider,
 		configClient: configClient,
-		processor:    yaml.NewSimpleProcessor(),
 	}
 	for _, o := range options {
 		o(client)
 	}
 
+	// if there is an injected processor, use it, otherwise use the one configured for the provider
+	if client.processor == nil {
+		p, err := templateProcessorFactory(provider, configClient)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get template processor for the %s with name %s", provider.Type(), provider.Name())
+		}
+		client.processor = p
+	}
+
 	// if there is an injected repository, use it, otherwise use a default one
 	if client.repository == nil {
-		r, err := repositoryFactory(provider, configClient.Variables())
+		r, err := repositoryFactory(provider, configClient)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get repository client for the %s with name %s", provider.Type(), provider.Name())
 		}
 		client.repository = r
 	}
 
+	// if verification is enabled for the provider, ensure all the files read from the repository are verified
+	verification, err := configClient.Verification().Get(provider)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get verification configuration for the %s with name %s", provider.Type(), provider.Name())
+	}
+	if verification != nil {
+		client.repository = newVerifyingRepository(client.repository, provider, verification)
+	}
+
 	return client, nil
 }
 
@@ -157,18 +175,38 @@ type Repository interface {
 	GetVersions() ([]string, error)
 }
 
+// templateProcessorFactory returns the yaml processor configured for the provider.
+func templateProcessorFactory(providerConfig config.Provider, configClient config.Client) (yaml.Processor, error) {
+	name, err := configClient.TemplateProcessors().Get(providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	if name == config.GoTemplateProcessor {
+		return yaml.NewGoTemplateProcessor(), nil
+	}
+	return yaml.NewSimpleProcessor(), nil
+}
+
 // repositoryFactory returns the repository implementation corresponding to the provider URL.
-func repositoryFactory(providerConfig config.Provider, configVariablesClient config.VariablesClient) (Repository, error) {
+func repositoryFactory(providerConfig config.Provider, configClient config.Client) (Repository, error) {
+	configVariablesClient := configClient.Variables()
+
 	// parse the repository url
 	rURL, err := url.Parse(providerConfig.URL())
 	if err != nil {
 		return nil, errors.Errorf("failed to parse repository url %q", providerConfig.URL())
 	}
 
+	// get the on-disk cache for repository downloads, if enabled
+	cache, err := newDiskCache(configClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the clusterctl cache")
+	}
+
 	if rURL.Scheme == httpsScheme {
 		// if the url is a GitHub repository
 		if rURL.Host == githubDomain {
-			repo, err := NewGitHubRepository(providerConfig, configVariablesClient)
+			repo, err := NewGitHubRepository(providerConfig, configVariablesClient, withGithubCache(cache))
 			if err != nil {
 				return nil, errors.Wrap(err, "error creating the GitHub repository client")
 			}
@@ -187,6 +225,24 @@ func repositoryFactory(providerConfig config.Provider, configVariablesClient con
 		return nil, errors.Errorf("invalid provider url. Only GitHub and GitLab are supported for %q schema", rURL.Scheme)
 	}
 
+	// if the url is a generic HTTP repository
+	if rURL.Scheme == httpIndexScheme || rURL.Scheme == httpsIndexScheme {
+		repo, err := NewHTTPRepository(providerConfig, configVariablesClient, withHTTPCache(cache))
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the HTTP repository client")
+		}
+		return repo, err
+	}
+
+	// if the url is an OCI registry repository
+	if rURL.Scheme == ociScheme {
+		repo, err := NewOCIRepository(providerConfig, configVariablesClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the OCI repository client")
+		}
+		return repo, err
+	}
+
 	// if the url is a local filesystem repository
 	if rURL.Scheme == "file" || rURL.Scheme == "" {
 		repo, err := newLocalRepository(providerConfig, configVariablesClient)
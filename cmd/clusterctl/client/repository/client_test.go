@@ -76,6 +76,20 @@ func Test_newRepositoryClient_LocalFileSystemRepository(t *testing.T) {
 			},
 			expected: &gitLabRepository{},
 		},
+		{
+			name: "successfully creates repository client with OCI backend",
+			fields: fields{
+				provider: config.NewProvider("bar", "oci://registry.example.org/org/bootstrap-bar:v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			},
+			expected: &ociRepository{},
+		},
+		{
+			name: "successfully creates repository client with HTTP backend",
+			fields: fields{
+				provider: config.NewProvider("bar", "index+https://artifacts.example.org/providers/bootstrap-bar/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			},
+			expected: &httpRepository{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -91,9 +105,10 @@ func Test_newRepositoryClient_LocalFileSystemRepository(t *testing.T) {
 
 func Test_newRepositoryClient_YamlProcessor(t *testing.T) {
 	tests := []struct {
-		name   string
-		opts   []Option
-		assert func(*WithT, yaml.Processor)
+		name               string
+		templateProcessors string
+		opts               []Option
+		assert             func(*WithT, yaml.Processor)
 	}{
 		{
 			name: "it creates a repository client with simple yaml processor by default",
@@ -121,13 +136,34 @@ func Test_newRepositoryClient_YamlProcessor(t *testing.T) {
 				g.Expect(ok).To(BeTrue())
 			},
 		},
+		{
+			name:               "it creates a repository client with the yaml processor configured for the provider",
+			templateProcessors: "fakeProvider: gotemplate",
+			assert: func(g *WithT, p yaml.Processor) {
+				_, ok := (p).(*yaml.GoTemplateProcessor)
+				g.Expect(ok).To(BeTrue())
+			},
+		},
+		{
+			name:               "it creates a repository client with specified yaml processor even if a processor is configured for the provider",
+			templateProcessors: "fakeProvider: gotemplate",
+			opts:               []Option{InjectYamlProcessor(test.NewFakeProcessor())},
+			assert: func(g *WithT, p yaml.Processor) {
+				_, ok := (p).(*test.FakeProcessor)
+				g.Expect(ok).To(BeTrue())
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 			configProvider := config.NewProvider("fakeProvider", "", clusterctlv1.CoreProviderType)
-			configClient, err := config.New("", config.InjectReader(test.NewFakeReader()))
+			reader := test.NewFakeReader()
+			if tt.templateProcessors != "" {
+				reader = reader.WithVar(config.TemplateProcessorsConfigKey, tt.templateProcessors)
+			}
+			configClient, err := config.New("", config.InjectReader(reader))
 			g.Expect(err).NotTo(HaveOccurred())
 
 			tt.opts = append(tt.opts, InjectRepository(NewMemoryRepository()))
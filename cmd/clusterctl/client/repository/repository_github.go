@@ -68,6 +68,7 @@ type gitHubRepository struct {
 	rootPath                 string
 	componentsPath           string
 	injectClient             *github.Client
+	cache                    *diskCache
 }
 
 var _ Repository = &gitHubRepository{}
@@ -80,6 +81,12 @@ func injectGithubClient(c *github.Client) githubRepositoryOption {
 	}
 }
 
+func withGithubCache(c *diskCache) githubRepositoryOption {
+	return func(g *gitHubRepository) {
+		g.cache = c
+	}
+}
+
 // DefaultVersion returns defaultVersion field of gitHubRepository struct.
 func (g *gitHubRepository) DefaultVersion() string {
 	return g.defaultVersion
@@ -106,6 +113,12 @@ func (g *gitHubRepository) ComponentsPath() string {
 
 // GetFile returns a file for a given provider version.
 func (g *gitHubRepository) GetFile(version, path string) ([]byte, error) {
+	// release assets are immutable, so we can skip getting the release if the file is already in the cache.
+	assetCacheID := fmt.Sprintf("%s/%s/%s:%s:%s", githubDomain, g.owner, g.repository, version, filepath.Join(g.rootPath, path))
+	if content, ok := g.cache.getAsset(assetCacheID); ok {
+		return content, nil
+	}
+
 	release, err := g.getReleaseByTag(version)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get GitHub release %s", version)
@@ -117,6 +130,7 @@ func (g *gitHubRepository) GetFile(version, path string) ([]byte, error) {
 		return nil, errors.Wrapf(err, "failed to download files from GitHub release %s", version)
 	}
 
+	g.cache.setAsset(assetCacheID, files)
 	return files, nil
 }
 
@@ -198,7 +212,7 @@ func (g *gitHubRepository) getClient() *github.Client {
 	if g.injectClient != nil {
 		return g.injectClient
 	}
-	return github.NewClient(g.authenticatingHTTPClient)
+	return github.NewClient(g.cache.client(g.authenticatingHTTPClient))
 }
 
 // setClientToken sets authenticatingHTTPClient field of gitHubRepository struct.
@@ -227,8 +241,8 @@ func (g *gitHubRepository) getVersions() ([]string, error) {
 		releases, _, listReleasesErr = client.Repositories.ListReleases(context.TODO(), g.owner, g.repository, nil)
 		if listReleasesErr != nil {
 			retryError = g.handleGithubErr(listReleasesErr, "failed to get the list of releases")
-			// return immediately if we are rate limited
-			if _, ok := listReleasesErr.(*github.RateLimitError); ok {
+			// return immediately if we are rate limited or if data are not available while offline
+			if _, ok := listReleasesErr.(*github.RateLimitError); ok || errors.Is(listReleasesErr, errOfflineCacheMiss) {
 				return false, retryError
 			}
 			return false, nil
@@ -273,8 +287,8 @@ func (g *gitHubRepository) getReleaseByTag(tag string) (*github.RepositoryReleas
 		release, _, getReleasesErr = client.Repositories.GetReleaseByTag(context.TODO(), g.owner, g.repository, tag)
 		if getReleasesErr != nil {
 			retryError = g.handleGithubErr(getReleasesErr, "failed to read release %q", tag)
-			// return immediately if we are rate limited
-			if _, ok := getReleasesErr.(*github.RateLimitError); ok {
+			// return immediately if we are rate limited or if data are not available while offline
+			if _, ok := getReleasesErr.(*github.RateLimitError); ok || errors.Is(getReleasesErr, errOfflineCacheMiss) {
 				return false, retryError
 			}
 			return false, nil
@@ -322,8 +336,8 @@ func (g *gitHubRepository) downloadFilesFromRelease(release *github.RepositoryRe
 		reader, redirect, downloadReleaseError = client.Repositories.DownloadReleaseAsset(context.TODO(), g.owner, g.repository, *assetID, http.DefaultClient)
 		if downloadReleaseError != nil {
 			retryError = g.handleGithubErr(downloadReleaseError, "failed to download file %q from %q release", *release.TagName, fileName)
-			// return immediately if we are rate limited
-			if _, ok := downloadReleaseError.(*github.RateLimitError); ok {
+			// return immediately if we are rate limited or if data are not available while offline
+			if _, ok := downloadReleaseError.(*github.RateLimitError); ok || errors.Is(downloadReleaseError, errOfflineCacheMiss) {
 				return false, retryError
 			}
 			return false, nil
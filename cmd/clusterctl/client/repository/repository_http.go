@@ -0,0 +1,358 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	httpIndexSchemePrefix   = "index+"
+	httpIndexScheme         = httpIndexSchemePrefix + "http"
+	httpsIndexScheme        = httpIndexSchemePrefix + "https"
+	httpRepositoryIndexFile = "index.yaml"
+	httpLatestReleaseLabel  = "latest"
+	httpRequestTimeout      = 30 * time.Second
+)
+
+var (
+	// Caches used to limit the number of calls to HTTP repositories.
+
+	cacheHTTPIndexes = map[string]*httpRepositoryIndex{}
+)
+
+// httpRepository provides support for providers hosted on a generic HTTP(S) server, e.g. an artifact server.
+// In order to not change how existing http(s) provider urls are resolved, the repository must be selected explicitly
+// using the index+http or index+https scheme.
+//
+// The repository base URL must host an index.yaml file listing the available versions and the corresponding assets;
+// by default assets are expected to be stored under {baseURL}/{version}/{assetName}, but the index can override
+// the location of each asset and provide a sha256 checksum to be verified after download.
+type httpRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	baseURL               string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	username              string
+	password              string
+	token                 string
+	cache                 *diskCache
+}
+
+var _ Repository = &httpRepository{}
+
+type httpRepositoryOption func(*httpRepository)
+
+func injectHTTPClient(c *http.Client) httpRepositoryOption {
+	return func(h *httpRepository) {
+		h.httpClient = c
+	}
+}
+
+func withHTTPCache(c *diskCache) httpRepositoryOption {
+	return func(h *httpRepository) {
+		h.cache = c
+	}
+}
+
+// httpRepositoryIndex defines the content of the index.yaml file of an HTTP repository.
+type httpRepositoryIndex struct {
+	// Versions is the list of versions available in the repository.
+	Versions []httpRepositoryIndexVersion `json:"versions"`
+}
+
+// httpRepositoryIndexVersion defines a version in the index.yaml file of an HTTP repository.
+type httpRepositoryIndexVersion struct {
+	// Version is the version tag, e.g. v1.2.3.
+	Version string `json:"version"`
+
+	// Assets is the list of files available for this version.
+	Assets []httpRepositoryIndexAsset `json:"assets"`
+}
+
+// httpRepositoryIndexAsset defines an asset in the index.yaml file of an HTTP repository.
+type httpRepositoryIndexAsset struct {
+	// Name is the file name of the asset, e.g. infrastructure-components.yaml.
+	Name string `json:"name"`
+
+	// URL is the location of the asset; it can be either absolute or relative to the index file.
+	// If not set, the asset is expected to be at {version}/{name}, relative to the index file.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SHA256 is the hex encoded sha256 checksum of the asset; if set, it is verified after download.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// NewHTTPRepository returns an httpRepository implementation.
+func NewHTTPRepository(providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...httpRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the url is an http repository url, with at least a version and the components path.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if (rURL.Scheme != httpIndexScheme && rURL.Scheme != httpsIndexScheme) ||
+		rURL.Host == "" ||
+		len(urlSplit) < 2 ||
+		urlSplit[len(urlSplit)-2] == "" ||
+		urlSplit[len(urlSplit)-1] == "" {
+		return nil, errors.New("invalid url: an HTTP repository url should be in the form index+http(s)://{host}/{basePath}/{latest|version-tag}/{componentsPath}")
+	}
+
+	// Extract all the info from url split.
+	baseURL := *rURL
+	baseURL.Scheme = strings.TrimPrefix(rURL.Scheme, httpIndexSchemePrefix)
+	baseURL.Path = "/" + strings.Join(urlSplit[:len(urlSplit)-2], "/")
+	baseURL.RawPath = ""
+	baseURL.RawQuery = ""
+	baseURL.Fragment = ""
+	defaultVersion := urlSplit[len(urlSplit)-2]
+	componentsPath := urlSplit[len(urlSplit)-1]
+
+	repo := &httpRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		baseURL:               strings.TrimSuffix(baseURL.String(), "/"),
+		defaultVersion:        defaultVersion,
+		rootPath:              ".",
+		componentsPath:        componentsPath,
+	}
+
+	// process httpRepositoryOptions
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if username, err := configVariablesClient.Get(config.HTTPRepositoryUsernameVariable); err == nil {
+		repo.username = username
+	}
+	if password, err := configVariablesClient.Get(config.HTTPRepositoryPasswordVariable); err == nil {
+		repo.password = password
+	}
+	if token, err := configVariablesClient.Get(config.HTTPRepositoryTokenVariable); err == nil {
+		repo.token = token
+	}
+
+	if defaultVersion == httpLatestReleaseLabel {
+		repo.defaultVersion, err = latestContractRelease(repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get HTTP repository latest version")
+		}
+	}
+
+	return repo, nil
+}
+
+// BaseURL returns baseURL field of httpRepository struct.
+func (h *httpRepository) BaseURL() string {
+	return h.baseURL
+}
+
+// DefaultVersion returns defaultVersion field of httpRepository struct.
+func (h *httpRepository) DefaultVersion() string {
+	return h.defaultVersion
+}
+
+// RootPath returns rootPath field of httpRepository struct.
+func (h *httpRepository) RootPath() string {
+	return h.rootPath
+}
+
+// ComponentsPath returns componentsPath field of httpRepository struct.
+func (h *httpRepository) ComponentsPath() string {
+	return h.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository.
+func (h *httpRepository) GetVersions() ([]string, error) {
+	index, err := h.getIndex()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get repository versions")
+	}
+
+	versions := []string{}
+	for _, v := range index.Versions {
+		if _, err := version.ParseSemantic(v.Version); err != nil {
+			// Discard versions that are not a valid semantic versions (the user can point explicitly to such versions).
+			continue
+		}
+		versions = append(versions, v.Version)
+	}
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (h *httpRepository) GetFile(version, fileName string) ([]byte, error) {
+	cacheID := fmt.Sprintf("%s:%s:%s", h.baseURL, version, fileName)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	index, err := h.getIndex()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", fileName, version)
+	}
+
+	// search for the file into the version assets
+	var asset *httpRepositoryIndexAsset
+	for i := range index.Versions {
+		if index.Versions[i].Version != version {
+			continue
+		}
+		for j := range index.Versions[i].Assets {
+			if index.Versions[i].Assets[j].Name == path.Join(h.rootPath, fileName) {
+				asset = &index.Versions[i].Assets[j]
+				break
+			}
+		}
+		break
+	}
+	if asset == nil {
+		return nil, errors.Errorf("failed to get file %q with version %q: the file is not listed in %s", fileName, version, httpRepositoryIndexFile)
+	}
+
+	assetURL, err := h.assetURL(version, asset)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", fileName, version)
+	}
+
+	content, err := h.download(assetURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", fileName, version)
+	}
+
+	if asset.SHA256 != "" {
+		sum := sha256.Sum256(content)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, asset.SHA256) {
+			return nil, errors.Errorf("failed to verify file %q with version %q: expected sha256 checksum %s, got %s", fileName, version, asset.SHA256, got)
+		}
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// getIndex returns the index of the repository.
+func (h *httpRepository) getIndex() (*httpRepositoryIndex, error) {
+	indexURL := fmt.Sprintf("%s/%s", h.baseURL, httpRepositoryIndexFile)
+	if index, ok := cacheHTTPIndexes[indexURL]; ok {
+		return index, nil
+	}
+
+	content, err := h.download(indexURL)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &httpRepositoryIndex{}
+	if err := yaml.Unmarshal(content, index); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %q", indexURL)
+	}
+
+	cacheHTTPIndexes[indexURL] = index
+	return index, nil
+}
+
+// assetURL returns the absolute URL of an asset.
+func (h *httpRepository) assetURL(version string, asset *httpRepositoryIndexAsset) (string, error) {
+	ref := asset.URL
+	if ref == "" {
+		ref = fmt.Sprintf("%s/%s", url.PathEscape(version), asset.Name)
+	}
+
+	base, err := url.Parse(h.baseURL + "/")
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid base url %q", h.baseURL)
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid url %q for asset %q", ref, asset.Name)
+	}
+	return u.String(), nil
+}
+
+// download gets the content at the given URL, using the configured credentials, if any.
+func (h *httpRepository) download(rawURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), httpRequestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %q: failed to create request", rawURL)
+	}
+	// Credentials are sent only to the host of the repository, so they are not leaked to assets hosted elsewhere.
+	if h.isRepositoryHost(request.URL) {
+		switch {
+		case h.token != "":
+			request.Header.Set("Authorization", "Bearer "+h.token)
+		case h.username != "":
+			request.SetBasicAuth(h.username, h.password)
+		}
+	}
+
+	response, err := h.cache.client(h.httpClient).Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %q", rawURL)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to download %q, got %d", rawURL, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %q", rawURL)
+	}
+	return content, nil
+}
+
+// isRepositoryHost returns true if the given url has the same scheme and host of the repository base URL.
+func (h *httpRepository) isRepositoryHost(u *url.URL) bool {
+	base, err := url.Parse(h.baseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_httpRepository_newHTTPRepository(t *testing.T) {
+	type field struct {
+		providerConfig config.Provider
+		variableClient config.VariablesClient
+	}
+	tests := []struct {
+		name      string
+		field     field
+		want      *httpRepository
+		wantedErr string
+	}{
+		{
+			name: "can create a new HTTP repo",
+			field: field{
+				providerConfig: config.NewProvider("test", "index+https://artifacts.example.org/providers/infra/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", "index+https://artifacts.example.org/providers/infra/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient(),
+				httpClient:            http.DefaultClient,
+				baseURL:               "https://artifacts.example.org/providers/infra",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+			},
+		},
+		{
+			name: "can create a new HTTP repo with plain http, no base path and credentials",
+			field: field{
+				providerConfig: config.NewProvider("test", "index+http://artifacts.example.org:8080/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient().
+					WithVar(config.HTTPRepositoryUsernameVariable, "user").
+					WithVar(config.HTTPRepositoryPasswordVariable, "pass").
+					WithVar(config.HTTPRepositoryTokenVariable, "token"),
+			},
+			want: &httpRepository{
+				providerConfig: config.NewProvider("test", "index+http://artifacts.example.org:8080/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().
+					WithVar(config.HTTPRepositoryUsernameVariable, "user").
+					WithVar(config.HTTPRepositoryPasswordVariable, "pass").
+					WithVar(config.HTTPRepositoryTokenVariable, "token"),
+				httpClient:     http.DefaultClient,
+				baseURL:        "http://artifacts.example.org:8080",
+				defaultVersion: "v1.0.0",
+				rootPath:       ".",
+				componentsPath: "infrastructure-components.yaml",
+				username:       "user",
+				password:       "pass",
+				token:          "token",
+			},
+		},
+		{
+			name: "missing variableClient",
+			field: field{
+				providerConfig: config.NewProvider("test", "index+https://artifacts.example.org/providers/infra/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: nil,
+			},
+			wantedErr: "invalid arguments: configVariablesClient can't be nil",
+		},
+		{
+			name: "provider url should use an http scheme",
+			field: field{
+				providerConfig: config.NewProvider("test", "ftp://artifacts.example.org/providers/infra/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			wantedErr: "invalid url: an HTTP repository url should be in the form index+http(s)://{host}/{basePath}/{latest|version-tag}/{componentsPath}",
+		},
+		{
+			name: "provider url should use an explicit HTTP repository scheme",
+			field: field{
+				providerConfig: config.NewProvider("test", "https://artifacts.example.org/providers/infra/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			wantedErr: "invalid url: an HTTP repository url should be in the form index+http(s)://{host}/{basePath}/{latest|version-tag}/{componentsPath}",
+		},
+		{
+			name: "provider url should have a version",
+			field: field{
+				providerConfig: config.NewProvider("test", "index+https://artifacts.example.org/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			wantedErr: "invalid url: an HTTP repository url should be in the form index+http(s)://{host}/{basePath}/{latest|version-tag}/{componentsPath}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			h, err := NewHTTPRepository(tt.field.providerConfig, tt.field.variableClient)
+			if tt.wantedErr != "" {
+				g.Expect(err).To(MatchError(tt.wantedErr))
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(h).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_httpRepository_GetVersions(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/providers/infra/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `versions:
+- version: v0.4.0
+- version: v0.4.1
+- version: v0.5.0-rc.0
+- version: not-a-semver
+`)
+	})
+
+	tests := []struct {
+		name        string
+		url         string
+		wantVersion string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "Get versions from the index, ignoring versions that are not semantic versions",
+			url:         fmt.Sprintf("index+%s/providers/infra/v0.4.0/infrastructure-components.yaml", server.URL),
+			wantVersion: "v0.4.0",
+			want:        []string{"v0.4.0", "v0.4.1", "v0.5.0-rc.0"},
+		},
+		{
+			name:        "Latest resolves to the latest release",
+			url:         fmt.Sprintf("index+%s/providers/infra/latest/infrastructure-components.yaml", server.URL),
+			wantVersion: "v0.4.1",
+			want:        []string{"v0.4.0", "v0.4.1", "v0.5.0-rc.0"},
+		},
+		{
+			name:        "Fails if the index does not exist",
+			url:         fmt.Sprintf("index+%s/providers/missing/v0.4.0/infrastructure-components.yaml", server.URL),
+			wantVersion: "v0.4.0",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			providerConfig := config.NewProvider("test", tt.url, clusterctlv1.InfrastructureProviderType)
+			h, err := NewHTTPRepository(providerConfig, test.NewFakeVariableClient(), injectHTTPClient(server.Client()))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(h.DefaultVersion()).To(Equal(tt.wantVersion))
+
+			got, err := h.GetVersions()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_httpRepository_GetFile(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	// foreignServer hosts assets on a different host, which must not receive the repository credentials.
+	foreignMux := http.NewServeMux()
+	foreignServer := httptest.NewTLSServer(foreignMux)
+	defer foreignServer.Close()
+
+	mux.HandleFunc("/providers/infra/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `versions:
+- version: v0.4.1
+  assets:
+  - name: metadata.yaml
+  - name: infrastructure-components.yaml
+    url: %s/other/location/components.yaml
+  - name: cluster-template.yaml
+    # sha256 of "template"
+    sha256: 5cde0f1298f41f7d1c8b907a36992a7a513225a2615bd6e307bf1a9149b06b40
+  - name: cluster-template-tampered.yaml
+    sha256: 0000000000000000000000000000000000000000000000000000000000000000
+  - name: cluster-template-foreign.yaml
+    url: %s/cluster-template-foreign.yaml
+`, server.URL, foreignServer.URL)
+	})
+	mux.HandleFunc("/providers/infra/v0.4.1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/providers/infra/v0.4.1/metadata.yaml":
+			fmt.Fprint(w, "metadata")
+		case "/providers/infra/v0.4.1/cluster-template.yaml":
+			fmt.Fprint(w, "template")
+		case "/providers/infra/v0.4.1/cluster-template-tampered.yaml":
+			fmt.Fprint(w, "tampered")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/other/location/components.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "components")
+	})
+	foreignMux.HandleFunc("/cluster-template-foreign.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, "foreign")
+	})
+
+	providerURL := fmt.Sprintf("index+%s/providers/infra/v0.4.1/infrastructure-components.yaml", server.URL)
+	providerConfig := config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType)
+	configVariablesClient := test.NewFakeVariableClient().
+		WithVar(config.HTTPRepositoryUsernameVariable, "user").
+		WithVar(config.HTTPRepositoryPasswordVariable, "pass")
+
+	tests := []struct {
+		name     string
+		version  string
+		fileName string
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "File exists in the default location",
+			version:  "v0.4.1",
+			fileName: "metadata.yaml",
+			want:     []byte("metadata"),
+		},
+		{
+			name:     "File exists in the location defined in the index",
+			version:  "v0.4.1",
+			fileName: "infrastructure-components.yaml",
+			want:     []byte("components"),
+		},
+		{
+			name:     "File matches the checksum",
+			version:  "v0.4.1",
+			fileName: "cluster-template.yaml",
+			want:     []byte("template"),
+		},
+		{
+			name:     "File does not match the checksum",
+			version:  "v0.4.1",
+			fileName: "cluster-template-tampered.yaml",
+			wantErr:  true,
+		},
+		{
+			name:     "File hosted on a different host is downloaded without credentials",
+			version:  "v0.4.1",
+			fileName: "cluster-template-foreign.yaml",
+			want:     []byte("foreign"),
+		},
+		{
+			name:     "File is not listed in the index",
+			version:  "v0.4.1",
+			fileName: "cluster-template-foo.yaml",
+			wantErr:  true,
+		},
+		{
+			name:     "Version is not listed in the index",
+			version:  "v0.4.2",
+			fileName: "metadata.yaml",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			h, err := NewHTTPRepository(providerConfig, configVariablesClient, injectHTTPClient(server.Client()))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := h.GetFile(tt.version, tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	ociScheme                 = "oci"
+	ociLatestTag              = "latest"
+	ociManifestMediaType      = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType   = "application/vnd.docker.distribution.manifest.v2+json"
+	ociImageTitleAnnotation   = "org.opencontainers.image.title"
+	ociRequestTimeout         = 30 * time.Second
+	ociTagsListPageLinkHeader = "Link"
+)
+
+var (
+	// Caches used to limit the number of OCI registry API calls.
+
+	cacheOCIManifests = map[string]*ociManifest{}
+
+	ociAuthParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+	ociNextLinkRegex  = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+)
+
+// ociRepository provides support for providers hosted in an OCI registry.
+//
+// We support OCI artifacts pushed with oras-style layers, where each file of a release (components YAML, metadata YAML,
+// templates) is stored as a separate layer annotated with its file name (org.opencontainers.image.title).
+// Repositories must use versioned tags; tags that are not valid semantic versions are ignored when listing versions.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	registry              string
+	repository            string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	username              string
+	password              string
+	token                 string
+}
+
+var _ Repository = &ociRepository{}
+
+type ociRepositoryOption func(*ociRepository)
+
+func injectOCIHTTPClient(c *http.Client) ociRepositoryOption {
+	return func(o *ociRepository) {
+		o.httpClient = c
+	}
+}
+
+// ociManifest is the subset of the OCI image manifest used to locate release files.
+type ociManifest struct {
+	MediaType string          `json:"mediaType,omitempty"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor is the subset of the OCI content descriptor used to locate release files.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociTagList is the response of the OCI distribution tag list API.
+type ociTagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ociTokenResponse is the response of a registry token server.
+type ociTokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...ociRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	invalidURLErr := errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}:{latest|version-tag}/{componentsPath}")
+	if rURL.Scheme != ociScheme || rURL.Host == "" {
+		return nil, invalidURLErr
+	}
+
+	// Split the path in the repository reference (the part up to and including the tag) and the components path.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	tagIndex := -1
+	for i, s := range urlSplit {
+		if strings.Contains(s, ":") {
+			tagIndex = i
+			break
+		}
+	}
+	if tagIndex == -1 || tagIndex == len(urlSplit)-1 {
+		return nil, invalidURLErr
+	}
+
+	nameAndTag := strings.SplitN(urlSplit[tagIndex], ":", 2)
+	if nameAndTag[0] == "" || nameAndTag[1] == "" {
+		return nil, invalidURLErr
+	}
+
+	// Extract all the info from url split.
+	repository := strings.Join(append(urlSplit[:tagIndex:tagIndex], nameAndTag[0]), "/")
+	defaultVersion := nameAndTag[1]
+	path := strings.Join(urlSplit[tagIndex+1:], "/")
+
+	// use path's directory as a rootPath
+	rootPath := filepath.Dir(path)
+	// use the file name (if any) as componentsPath
+	componentsPath := getComponentsPath(path, rootPath)
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		registry:              rURL.Host,
+		repository:            repository,
+		defaultVersion:        defaultVersion,
+		rootPath:              rootPath,
+		componentsPath:        componentsPath,
+	}
+
+	// process ociRepositoryOptions
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if username, err := configVariablesClient.Get(config.OCIUsernameVariable); err == nil {
+		repo.username = username
+	}
+	if password, err := configVariablesClient.Get(config.OCIPasswordVariable); err == nil {
+		repo.password = password
+	}
+
+	if defaultVersion == ociLatestTag {
+		repo.defaultVersion, err = latestContractRelease(repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get OCI latest version")
+		}
+	}
+
+	return repo, nil
+}
+
+// Registry returns registry field of ociRepository struct.
+func (o *ociRepository) Registry() string {
+	return o.registry
+}
+
+// Repository returns repository field of ociRepository struct.
+func (o *ociRepository) Repository() string {
+	return o.repository
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (o *ociRepository) DefaultVersion() string {
+	return o.defaultVersion
+}
+
+// RootPath returns rootPath field of ociRepository struct.
+func (o *ociRepository) RootPath() string {
+	return o.rootPath
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (o *ociRepository) ComponentsPath() string {
+	return o.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository.
+func (o *ociRepository) GetVersions() ([]string, error) {
+	cacheID := fmt.Sprintf("%s://%s/%s", ociScheme, o.registry, o.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	versions := []string{}
+	next := fmt.Sprintf("https://%s/v2/%s/tags/list", o.registry, o.repository)
+	for next != "" {
+		response, err := o.get(next, "application/json")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the list of tags for %q", o.repository)
+		}
+
+		tagList := &ociTagList{}
+		err = json.NewDecoder(response.Body).Decode(tagList)
+		response.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the list of tags for %q", o.repository)
+		}
+
+		for _, tag := range tagList.Tags {
+			if _, err := version.ParseSemantic(tag); err != nil {
+				// Discard tags that are not a valid semantic versions (the user can point explicitly to such tags).
+				continue
+			}
+			versions = append(versions, tag)
+		}
+
+		next, err = o.nextPage(next, response.Header.Get(ociTagsListPageLinkHeader))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (o *ociRepository) GetFile(version, path string) ([]byte, error) {
+	cacheID := fmt.Sprintf("%s://%s/%s:%s:%s", ociScheme, o.registry, o.repository, version, path)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	manifest, err := o.getManifest(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get OCI artifact %s:%s", o.repository, version)
+	}
+
+	// search for the file into the artifact layers, retrieving the layer digest
+	absoluteFileName := filepath.Join(o.rootPath, path)
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].Annotations[ociImageTitleAnnotation] == absoluteFileName {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, errors.Errorf("failed to get file %q from OCI artifact %s:%s", path, o.repository, version)
+	}
+
+	content, err := o.getBlob(layer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download file %q from OCI artifact %s:%s", path, o.repository, version)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// getManifest returns the OCI manifest for a given tag.
+func (o *ociRepository) getManifest(tag string) (*ociManifest, error) {
+	cacheID := fmt.Sprintf("%s://%s/%s:%s", ociScheme, o.registry, o.repository, tag)
+	if manifest, ok := cacheOCIManifests[cacheID]; ok {
+		return manifest, nil
+	}
+
+	response, err := o.get(fmt.Sprintf("https://%s/v2/%s/manifests/%s", o.registry, o.repository, tag), ociManifestMediaType, dockerManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	manifest := &ociManifest{}
+	if err := json.NewDecoder(response.Body).Decode(manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode manifest for tag %q", tag)
+	}
+
+	cacheOCIManifests[cacheID] = manifest
+	return manifest, nil
+}
+
+// getBlob downloads the blob for a given layer, verifying its digest.
+func (o *ociRepository) getBlob(layer *ociDescriptor) ([]byte, error) {
+	digest := strings.SplitN(layer.Digest, ":", 2)
+	if len(digest) != 2 || digest[0] != "sha256" {
+		return nil, errors.Errorf("unsupported digest %q", layer.Digest)
+	}
+
+	response, err := o.get(fmt.Sprintf("https://%s/v2/%s/blobs/%s", o.registry, o.repository, layer.Digest), "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read blob %q", layer.Digest)
+	}
+
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != digest[1] {
+		return nil, errors.Errorf("digest mismatch for blob %q", layer.Digest)
+	}
+	return content, nil
+}
+
+// get executes a GET request against the registry, handling authentication challenges.
+// The caller is responsible for closing the response body.
+func (o *ociRepository) get(rawURL string, accept ...string) (*http.Response, error) {
+	response, err := o.do(rawURL, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the registry requires authentication, try to satisfy the challenge and retry once.
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+		if err := o.authenticate(challenge); err != nil {
+			return nil, errors.Wrapf(err, "failed to authenticate to %q", o.registry)
+		}
+		response, err = o.do(rawURL, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, errors.Errorf("failed to get %q, got %d", rawURL, response.StatusCode)
+	}
+	return response, nil
+}
+
+func (o *ociRepository) do(rawURL string, accept []string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), ociRequestTimeout)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q: failed to create request", rawURL)
+	}
+	request.Header.Set("Accept", strings.Join(accept, ", "))
+	switch {
+	case o.token != "":
+		request.Header.Set("Authorization", "Bearer "+o.token)
+	case o.username != "":
+		request.SetBasicAuth(o.username, o.password)
+	}
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q", rawURL)
+	}
+	response.Body = &cancelOnCloseReader{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// authenticate handles a WWW-Authenticate challenge returned by the registry.
+// Basic challenges are satisfied using the configured credentials, while Bearer challenges
+// require a token to be fetched from the token server indicated by the registry.
+func (o *ociRepository) authenticate(challenge string) error {
+	challengeSplit := strings.SplitN(challenge, " ", 2)
+	switch strings.ToLower(challengeSplit[0]) {
+	case "basic":
+		if o.username == "" {
+			return errors.Errorf("registry requires credentials, please set the %s and %s variables", config.OCIUsernameVariable, config.OCIPasswordVariable)
+		}
+		// Credentials have been already sent with the request, so they are not valid.
+		return errors.New("invalid credentials")
+	case "bearer":
+		if len(challengeSplit) != 2 {
+			return errors.Errorf("invalid bearer challenge %q", challenge)
+		}
+		token, err := o.fetchToken(challengeSplit[1])
+		if err != nil {
+			return err
+		}
+		o.token = token
+		return nil
+	default:
+		return errors.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+// fetchToken gets a bearer token from the token server described in the challenge parameters.
+func (o *ociRepository) fetchToken(challengeParams string) (string, error) {
+	params := map[string]string{}
+	for _, m := range ociAuthParamRegex.FindAllStringSubmatch(challengeParams, -1) {
+		params[m[1]] = m[2]
+	}
+
+	realm, ok := params["realm"]
+	if !ok {
+		return "", errors.New("invalid bearer challenge: missing realm")
+	}
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid bearer challenge: invalid realm %q", realm)
+	}
+	query := tokenURL.Query()
+	for _, p := range []string{"service", "scope"} {
+		if v, ok := params[p]; ok {
+			query.Set(p, v)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	// Ensure the token request doesn't carry a previous (invalid) token.
+	o.token = ""
+	response, err := o.do(tokenURL.String(), []string{"application/json"})
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to get token from %q, got %d", tokenURL.Redacted(), response.StatusCode)
+	}
+
+	tokenResponse := &ociTokenResponse{}
+	if err := json.NewDecoder(response.Body).Decode(tokenResponse); err != nil {
+		return "", errors.Wrapf(err, "failed to decode token from %q", tokenURL.Redacted())
+	}
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+	return "", errors.Errorf("empty token returned from %q", tokenURL.Redacted())
+}
+
+// nextPage returns the URL of the next page of a paginated response, if any.
+func (o *ociRepository) nextPage(current, linkHeader string) (string, error) {
+	m := ociNextLinkRegex.FindStringSubmatch(linkHeader)
+	if m == nil {
+		return "", nil
+	}
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid url %q", current)
+	}
+	next, err := base.Parse(m[1])
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid next page link %q", m[1])
+	}
+	return next.String(), nil
+}
+
+// cancelOnCloseReader releases the request context when the response body is closed.
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnCloseReader) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
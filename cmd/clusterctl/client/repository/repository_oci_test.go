@@ -0,0 +1,429 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	type field struct {
+		providerConfig config.Provider
+		variableClient config.VariablesClient
+	}
+	tests := []struct {
+		name      string
+		field     field
+		want      *ociRepository
+		wantedErr string
+	}{
+		{
+			name: "can create a new OCI repo",
+			field: field{
+				providerConfig: config.NewProvider("test", "oci://registry.example.org/org/infra-provider:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			want: &ociRepository{
+				providerConfig:        config.NewProvider("test", "oci://registry.example.org/org/infra-provider:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient(),
+				httpClient:            http.DefaultClient,
+				registry:              "registry.example.org",
+				repository:            "org/infra-provider",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+			},
+		},
+		{
+			name: "can create a new OCI repo with registry port, nested path and credentials",
+			field: field{
+				providerConfig: config.NewProvider("test", "oci://registry.example.org:5000/infra-provider:v1.0.0/path/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient().
+					WithVar(config.OCIUsernameVariable, "user").
+					WithVar(config.OCIPasswordVariable, "pass"),
+			},
+			want: &ociRepository{
+				providerConfig: config.NewProvider("test", "oci://registry.example.org:5000/infra-provider:v1.0.0/path/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().
+					WithVar(config.OCIUsernameVariable, "user").
+					WithVar(config.OCIPasswordVariable, "pass"),
+				httpClient:     http.DefaultClient,
+				registry:       "registry.example.org:5000",
+				repository:     "infra-provider",
+				defaultVersion: "v1.0.0",
+				rootPath:       "path",
+				componentsPath: "infrastructure-components.yaml",
+				username:       "user",
+				password:       "pass",
+			},
+		},
+		{
+			name: "missing variableClient",
+			field: field{
+				providerConfig: config.NewProvider("test", "oci://registry.example.org/org/infra-provider:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: nil,
+			},
+			wantedErr: "invalid arguments: configVariablesClient can't be nil",
+		},
+		{
+			name: "provider url should use the oci scheme",
+			field: field{
+				providerConfig: config.NewProvider("test", "https://registry.example.org/org/infra-provider:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			wantedErr: "invalid url: an OCI repository url should be in the form oci://{registry}/{repository}:{latest|version-tag}/{componentsPath}",
+		},
+		{
+			name: "provider url should have a tag",
+			field: field{
+				providerConfig: config.NewProvider("test", "oci://registry.example.org/org/infra-provider/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			wantedErr: "invalid url: an OCI repository url should be in the form oci://{registry}/{repository}:{latest|version-tag}/{componentsPath}",
+		},
+		{
+			name: "provider url should have a components path",
+			field: field{
+				providerConfig: config.NewProvider("test", "oci://registry.example.org/org/infra-provider:v1.0.0", clusterctlv1.InfrastructureProviderType),
+				variableClient: test.NewFakeVariableClient(),
+			},
+			wantedErr: "invalid url: an OCI repository url should be in the form oci://{registry}/{repository}:{latest|version-tag}/{componentsPath}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			oci, err := NewOCIRepository(tt.field.providerConfig, tt.field.variableClient)
+			if tt.wantedErr != "" {
+				g.Expect(err).To(MatchError(tt.wantedErr))
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(oci).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_GetVersions(t *testing.T) {
+	registry := newFakeOCIRegistry(t, "org/infra-provider")
+	registry.push("v0.4.0", map[string]string{"metadata.yaml": "v0.4.0"})
+	registry.push("v0.4.1", map[string]string{"metadata.yaml": "v0.4.1"})
+	registry.push("v0.5.0-rc.0", map[string]string{"metadata.yaml": "v0.5.0-rc.0"})
+	registry.push("not-a-semver", map[string]string{"metadata.yaml": "not-a-semver"})
+	registry.tagsPageSize = 2
+	defer registry.Close()
+
+	tests := []struct {
+		name        string
+		url         string
+		wantVersion string
+		want        []string
+	}{
+		{
+			name:        "Get versions across multiple pages, ignoring tags that are not semantic versions",
+			url:         fmt.Sprintf("oci://%s/org/infra-provider:v0.4.0/infrastructure-components.yaml", registry.host()),
+			wantVersion: "v0.4.0",
+			want:        []string{"v0.4.0", "v0.4.1", "v0.5.0-rc.0"},
+		},
+		{
+			name:        "Latest resolves to the latest release",
+			url:         fmt.Sprintf("oci://%s/org/infra-provider:latest/infrastructure-components.yaml", registry.host()),
+			wantVersion: "v0.4.1",
+			want:        []string{"v0.4.0", "v0.4.1", "v0.5.0-rc.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			providerConfig := config.NewProvider("test", tt.url, clusterctlv1.InfrastructureProviderType)
+			oci, err := NewOCIRepository(providerConfig, test.NewFakeVariableClient(), injectOCIHTTPClient(registry.Client()))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(oci.DefaultVersion()).To(Equal(tt.wantVersion))
+
+			got, err := oci.GetVersions()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_GetFile(t *testing.T) {
+	registry := newFakeOCIRegistry(t, "org/infra-provider")
+	registry.push("v0.4.1", map[string]string{
+		"infrastructure-components.yaml": "components",
+		"metadata.yaml":                  "metadata",
+	})
+	registry.blobs["sha256:"+strings.Repeat("0", 64)] = []byte("tampered")
+	registry.manifests["corrupted"] = ociManifest{
+		MediaType: ociManifestMediaType,
+		Layers: []ociDescriptor{{
+			MediaType:   "application/vnd.cncf.cluster-api.file",
+			Digest:      "sha256:" + strings.Repeat("0", 64),
+			Annotations: map[string]string{ociImageTitleAnnotation: "metadata.yaml"},
+		}},
+	}
+	defer registry.Close()
+
+	providerURL := fmt.Sprintf("oci://%s/org/infra-provider:v0.4.1/infrastructure-components.yaml", registry.host())
+	providerConfig := config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType)
+
+	tests := []struct {
+		name     string
+		version  string
+		fileName string
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "Tag and file exist",
+			version:  "v0.4.1",
+			fileName: "metadata.yaml",
+			want:     []byte("metadata"),
+			wantErr:  false,
+		},
+		{
+			name:     "Tag does not exist",
+			version:  "v0.4.2",
+			fileName: "metadata.yaml",
+			wantErr:  true,
+		},
+		{
+			name:     "File does not exist",
+			version:  "v0.4.1",
+			fileName: "cluster-template.yaml",
+			wantErr:  true,
+		},
+		{
+			name:     "File does not match the layer digest",
+			version:  "corrupted",
+			fileName: "metadata.yaml",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			oci, err := NewOCIRepository(providerConfig, test.NewFakeVariableClient(), injectOCIHTTPClient(registry.Client()))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := oci.GetFile(tt.version, tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_authentication(t *testing.T) {
+	registry := newFakeOCIRegistry(t, "org/infra-provider")
+	registry.push("v0.4.1", map[string]string{"metadata.yaml": "metadata"})
+	registry.username = "user"
+	registry.password = "pass"
+	defer registry.Close()
+
+	providerURL := fmt.Sprintf("oci://%s/org/infra-provider:v0.4.1/infrastructure-components.yaml", registry.host())
+	providerConfig := config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType)
+
+	tests := []struct {
+		name           string
+		variableClient config.VariablesClient
+		wantErr        bool
+	}{
+		{
+			name: "Valid credentials",
+			variableClient: test.NewFakeVariableClient().
+				WithVar(config.OCIUsernameVariable, "user").
+				WithVar(config.OCIPasswordVariable, "pass"),
+			wantErr: false,
+		},
+		{
+			name: "Invalid credentials",
+			variableClient: test.NewFakeVariableClient().
+				WithVar(config.OCIUsernameVariable, "user").
+				WithVar(config.OCIPasswordVariable, "wrong"),
+			wantErr: true,
+		},
+		{
+			name:           "Missing credentials",
+			variableClient: test.NewFakeVariableClient(),
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			oci, err := NewOCIRepository(providerConfig, tt.variableClient, injectOCIHTTPClient(registry.Client()))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := oci.GetFile("v0.4.1", "metadata.yaml")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal([]byte("metadata")))
+		})
+	}
+}
+
+// fakeOCIRegistry is an in-process registry implementing the subset of the OCI distribution API used by ociRepository.
+// If username and password are set, the registry requires a bearer token obtained from its token endpoint.
+type fakeOCIRegistry struct {
+	*httptest.Server
+	t            *testing.T
+	repository   string
+	tags         []string
+	manifests    map[string]ociManifest
+	blobs        map[string][]byte
+	tagsPageSize int
+	username     string
+	password     string
+}
+
+const fakeOCIRegistryToken = "fake-token"
+
+func newFakeOCIRegistry(t *testing.T, repository string) *fakeOCIRegistry {
+	t.Helper()
+
+	r := &fakeOCIRegistry{
+		t:          t,
+		repository: repository,
+		manifests:  map[string]ociManifest{},
+		blobs:      map[string][]byte{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", r.serveToken)
+	mux.HandleFunc("/v2/", r.serveDistribution)
+	r.Server = httptest.NewTLSServer(mux)
+	return r
+}
+
+func (r *fakeOCIRegistry) host() string {
+	return strings.TrimPrefix(r.URL, "https://")
+}
+
+// push stores an artifact with one layer for each file.
+func (r *fakeOCIRegistry) push(tag string, files map[string]string) {
+	manifest := ociManifest{MediaType: ociManifestMediaType}
+	for name, content := range files {
+		sum := sha256.Sum256([]byte(content))
+		digest := "sha256:" + hex.EncodeToString(sum[:])
+		r.blobs[digest] = []byte(content)
+		manifest.Layers = append(manifest.Layers, ociDescriptor{
+			MediaType:   "application/vnd.cncf.cluster-api.file",
+			Digest:      digest,
+			Size:        int64(len(content)),
+			Annotations: map[string]string{ociImageTitleAnnotation: name},
+		})
+	}
+	r.tags = append(r.tags, tag)
+	r.manifests[tag] = manifest
+}
+
+func (r *fakeOCIRegistry) serveToken(w http.ResponseWriter, req *http.Request) {
+	testMethod(r.t, req, "GET")
+	if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(ociTokenResponse{Token: fakeOCIRegistryToken})
+}
+
+func (r *fakeOCIRegistry) serveDistribution(w http.ResponseWriter, req *http.Request) {
+	testMethod(r.t, req, "GET")
+	if r.username != "" && req.Header.Get("Authorization") != "Bearer "+fakeOCIRegistryToken {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake",scope="repository:%s:pull"`, r.URL, r.repository))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	prefix := fmt.Sprintf("/v2/%s/", r.repository)
+	if !strings.HasPrefix(req.URL.Path, prefix) {
+		http.NotFound(w, req)
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, prefix)
+
+	switch {
+	case path == "tags/list":
+		r.serveTags(w, req)
+	case strings.HasPrefix(path, "manifests/"):
+		manifest, ok := r.manifests[strings.TrimPrefix(path, "manifests/")]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		_ = json.NewEncoder(w).Encode(manifest)
+	case strings.HasPrefix(path, "blobs/"):
+		blob, ok := r.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		_, _ = w.Write(blob)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func (r *fakeOCIRegistry) serveTags(w http.ResponseWriter, req *http.Request) {
+	tags := r.tags
+	if last := req.URL.Query().Get("last"); last != "" {
+		for i, tag := range tags {
+			if tag == last {
+				tags = tags[i+1:]
+				break
+			}
+		}
+	}
+	if r.tagsPageSize > 0 && len(tags) > r.tagsPageSize {
+		tags = tags[:r.tagsPageSize]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=%d&last=%s>; rel="next"`, r.repository, r.tagsPageSize, tags[len(tags)-1]))
+	}
+	_ = json.NewEncoder(w).Encode(ociTagList{Name: r.repository, Tags: tags})
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+// verifyingRepository wraps a Repository, verifying each file read from the repository against the checksums file
+// published together with the release assets; if a public key is configured, the checksums file itself is verified
+// against a detached signature, compatible with keyed signatures generated by `cosign sign-blob`.
+type verifyingRepository struct {
+	Repository
+	provider     config.Provider
+	verification config.Verification
+
+	// checksums caches the verified checksums for each version.
+	checksums map[string]map[string]string
+}
+
+var _ Repository = &verifyingRepository{}
+
+func newVerifyingRepository(repository Repository, provider config.Provider, verification config.Verification) *verifyingRepository {
+	return &verifyingRepository{
+		Repository:   repository,
+		provider:     provider,
+		verification: verification,
+		checksums:    map[string]map[string]string{},
+	}
+}
+
+// GetFile returns a file for a given provider version, failing if the file can't be verified.
+func (r *verifyingRepository) GetFile(version, filePath string) ([]byte, error) {
+	content, err := r.Repository.GetFile(version, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := r.getChecksums(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to verify file %q for provider %s version %s", filePath, r.provider.ManifestLabel(), version)
+	}
+
+	expected, ok := checksums[path.Clean(filePath)]
+	if !ok {
+		return nil, errors.Errorf("failed to verify file %q for provider %s version %s: the file is not listed in %s", filePath, r.provider.ManifestLabel(), version, r.verification.ChecksumsFile())
+	}
+
+	sum := sha256.Sum256(content)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, expected) {
+		return nil, errors.Errorf("failed to verify file %q for provider %s version %s: expected sha256 checksum %s, got %s", filePath, r.provider.ManifestLabel(), version, expected, got)
+	}
+	return content, nil
+}
+
+// getChecksums returns the checksums for a given version, after verifying the signature of the checksums file, if required.
+func (r *verifyingRepository) getChecksums(version string) (map[string]string, error) {
+	if checksums, ok := r.checksums[version]; ok {
+		return checksums, nil
+	}
+
+	content, err := r.Repository.GetFile(version, r.verification.ChecksumsFile())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the checksums file %q", r.verification.ChecksumsFile())
+	}
+
+	if r.verification.SignatureFile() != "" {
+		signature, err := r.Repository.GetFile(version, r.verification.SignatureFile())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the signature file %q", r.verification.SignatureFile())
+		}
+		if err := verifySignature(r.verification.PublicKey(), content, signature); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify the signature of the checksums file %q", r.verification.ChecksumsFile())
+		}
+	}
+
+	checksums, err := parseChecksums(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the checksums file %q", r.verification.ChecksumsFile())
+	}
+
+	r.checksums[version] = checksums
+	return checksums, nil
+}
+
+// parseChecksums parses a checksums file in the format generated by sha256sum, e.g.
+// 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08  infrastructure-components.yaml.
+func parseChecksums(content []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid line %q", line)
+		}
+		if _, err := hex.DecodeString(fields[0]); err != nil || len(fields[0]) != sha256.Size*2 {
+			return nil, errors.Errorf("invalid sha256 checksum in line %q", line)
+		}
+
+		// sha256sum prefixes file names with * when running in binary mode.
+		name := path.Clean(strings.TrimPrefix(fields[1], "*"))
+		checksums[name] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// verifySignature verifies a detached signature of content using a PEM encoded public key.
+// The signature is expected to be base64 encoded, as generated by `cosign sign-blob`; raw signatures are accepted as well.
+// ECDSA, RSA (PKCS #1 v1.5) and Ed25519 keys are supported.
+func verifySignature(publicKey, content, signature []byte) error {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return errors.New("invalid public key: failed to decode PEM block")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+
+	// NOTE: raw signatures are used as is, given that trimming could alter them.
+	sig := signature
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature))); err == nil {
+		sig = decoded
+	}
+
+	digest := sha256.Sum256(content)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], sig) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig); err != nil {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, content, sig) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_verifyingRepository_GetFile(t *testing.T) {
+	g := NewWithT(t)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	ecdsaPublicKey := encodePublicKey(g, &ecdsaKey.PublicKey)
+
+	ed25519PublicKey, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	components := []byte("components")
+	checksums := []byte(fmt.Sprintf("%s  infrastructure-components.yaml\n%s *metadata.yaml\n", sha256Hex(components), sha256Hex([]byte("metadata"))))
+
+	checksumsDigest := sha256.Sum256(checksums)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, checksumsDigest[:])
+	g.Expect(err).NotTo(HaveOccurred())
+	otherSignature, err := ecdsa.SignASN1(rand.Reader, otherKey, checksumsDigest[:])
+	g.Expect(err).NotTo(HaveOccurred())
+	ed25519Signature := ed25519.Sign(ed25519Key, checksums)
+
+	repository := NewMemoryRepository().
+		WithPaths("root", "infrastructure-components.yaml").
+		WithDefaultVersion("v1.0.0").
+		WithFile("v1.0.0", "infrastructure-components.yaml", components).
+		WithFile("v1.0.0", "metadata.yaml", []byte("tampered")).
+		WithFile("v1.0.0", "cluster-template.yaml", []byte("template")).
+		WithFile("v1.0.0", "checksums.txt", checksums).
+		WithFile("v1.0.0", "checksums.txt.sig", []byte(base64.StdEncoding.EncodeToString(ecdsaSignature))).
+		WithFile("v1.0.0", "checksums.txt.other.sig", []byte(base64.StdEncoding.EncodeToString(otherSignature))).
+		WithFile("v1.0.0", "checksums.txt.ed25519.sig", ed25519Signature)
+
+	tests := []struct {
+		name         string
+		verification config.Verification
+		fileName     string
+		want         []byte
+		wantErr      bool
+	}{
+		{
+			name:         "File matches the checksum",
+			verification: config.NewVerification("checksums.txt", "", nil),
+			fileName:     "infrastructure-components.yaml",
+			want:         components,
+		},
+		{
+			name:         "File does not match the checksum",
+			verification: config.NewVerification("checksums.txt", "", nil),
+			fileName:     "metadata.yaml",
+			wantErr:      true,
+		},
+		{
+			name:         "File is not listed in the checksums file",
+			verification: config.NewVerification("checksums.txt", "", nil),
+			fileName:     "cluster-template.yaml",
+			wantErr:      true,
+		},
+		{
+			name:         "Checksums file does not exist",
+			verification: config.NewVerification("SHA256SUMS", "", nil),
+			fileName:     "infrastructure-components.yaml",
+			wantErr:      true,
+		},
+		{
+			name:         "Checksums file matches the ECDSA signature",
+			verification: config.NewVerification("checksums.txt", "checksums.txt.sig", ecdsaPublicKey),
+			fileName:     "infrastructure-components.yaml",
+			want:         components,
+		},
+		{
+			name:         "Checksums file matches the Ed25519 signature",
+			verification: config.NewVerification("checksums.txt", "checksums.txt.ed25519.sig", encodePublicKey(g, ed25519PublicKey)),
+			fileName:     "infrastructure-components.yaml",
+			want:         components,
+		},
+		{
+			name:         "Checksums file is signed with another key",
+			verification: config.NewVerification("checksums.txt", "checksums.txt.other.sig", ecdsaPublicKey),
+			fileName:     "infrastructure-components.yaml",
+			wantErr:      true,
+		},
+		{
+			name:         "Signature file does not exist",
+			verification: config.NewVerification("checksums.txt", "checksums.txt.missing.sig", ecdsaPublicKey),
+			fileName:     "infrastructure-components.yaml",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			provider := config.NewProvider("foo", "", clusterctlv1.InfrastructureProviderType)
+			r := newVerifyingRepository(repository, provider, tt.verification)
+
+			got, err := r.GetFile("v1.0.0", tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_newRepositoryClient_Verification(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := config.NewProvider("foo", "", clusterctlv1.InfrastructureProviderType)
+
+	configClient, err := config.New("", config.InjectReader(test.NewFakeReader()))
+	g.Expect(err).NotTo(HaveOccurred())
+	repoClient, err := newRepositoryClient(provider, configClient, InjectRepository(NewMemoryRepository()))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repoClient.repository).To(BeAssignableToTypeOf(&MemoryRepository{}))
+
+	configClient, err = config.New("", config.InjectReader(test.NewFakeReader().WithVar(config.VerificationConfigKey, "infrastructure-foo: {}")))
+	g.Expect(err).NotTo(HaveOccurred())
+	repoClient, err = newRepositoryClient(provider, configClient, InjectRepository(NewMemoryRepository()))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repoClient.repository).To(BeAssignableToTypeOf(&verifyingRepository{}))
+}
+
+func encodePublicKey(g *WithT, key interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	g.Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -60,3 +60,46 @@ func (c *clusterctlClient) TopologyPlan(options TopologyPlanOptions) (*TopologyP
 
 	return out, err
 }
+
+// TopologyRebaseOptions define options for TopologyRebase.
+type TopologyRebaseOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Cluster is the name of the Cluster to rebase.
+	Cluster string
+
+	// Namespace is the namespace of the Cluster. If empty, the current namespace is used.
+	Namespace string
+
+	// ClusterClass is the name of the ClusterClass the Cluster should be rebased to.
+	ClusterClass string
+
+	// ClusterClassNamespace is the namespace of the ClusterClass the Cluster should be rebased to.
+	// If empty, the namespace of the ClusterClass currently used by the Cluster is used.
+	ClusterClassNamespace string
+
+	// Apply defines if the rebase should be applied to the Cluster after the compatibility checks and the preview.
+	Apply bool
+}
+
+// TopologyRebaseOutput defines the output of the topology rebase operation.
+type TopologyRebaseOutput = cluster.TopologyRebaseOutput
+
+// TopologyRebase checks if a Cluster can be rebased to a different ClusterClass and previews the changes
+// by performing a dry run execution of the topology reconciler; if requested, the rebase is applied.
+func (c *clusterctlClient) TopologyRebase(options TopologyRebaseOptions) (*TopologyRebaseOutput, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterClient.Topology().Rebase(&cluster.TopologyRebaseInput{
+		ClusterName:           options.Cluster,
+		Namespace:             options.Namespace,
+		ClusterClass:          options.ClusterClass,
+		ClusterClassNamespace: options.ClusterClassNamespace,
+		Apply:                 options.Apply,
+	})
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+)
+
+// GoTemplateProcessor is a yaml processor that uses Go text/template to process
+// templates, thus allowing conditionals and loops; variables are referenced in the
+// format {{ .VAR }}, and the sprig hermetic functions are available, e.g.
+// default values can be specified in the format {{ .VAR | default "value" }}.
+// See https://pkg.go.dev/text/template and http://masterminds.github.io/sprig/ for more details.
+//
+// NOTE: all the variables are passed to the template as strings; sprig functions like
+// atoi or splitList can be used to convert them e.g. for using them in a range loop.
+type GoTemplateProcessor struct{}
+
+var _ Processor = &GoTemplateProcessor{}
+
+// NewGoTemplateProcessor returns a new go template processor.
+func NewGoTemplateProcessor() *GoTemplateProcessor {
+	return &GoTemplateProcessor{}
+}
+
+// GetTemplateName returns the name of the template that the go template processor
+// uses. It follows the same cluster template naming convention of the simple processor.
+func (tp *GoTemplateProcessor) GetTemplateName(version, flavor string) string {
+	return NewSimpleProcessor().GetTemplateName(version, flavor)
+}
+
+// GetClusterClassTemplateName returns the name of the cluster class template
+// that the go template processor uses. It follows the same cluster class template
+// naming convention of the simple processor.
+func (tp *GoTemplateProcessor) GetClusterClassTemplateName(version, name string) string {
+	return NewSimpleProcessor().GetClusterClassTemplateName(version, name)
+}
+
+// GetVariables returns a list of the variables specified in the yaml.
+func (tp *GoTemplateProcessor) GetVariables(rawArtifact []byte) ([]string, error) {
+	variables, err := tp.GetVariableMap(rawArtifact)
+	if err != nil {
+		return nil, err
+	}
+	varNames := make([]string, 0, len(variables))
+	for k := range variables {
+		varNames = append(varNames, k)
+	}
+	sort.Strings(varNames)
+	return varNames, nil
+}
+
+// GetVariableMap returns a map of the variables specified in the yaml.
+//
+// Variables are detected by static analysis of the template; a variable is considered
+// required unless all its usages provide a default value, or it is used only in conditions
+// (if/with/range pipelines), in which case it defaults to an empty value.
+func (tp *GoTemplateProcessor) GetVariableMap(rawArtifact []byte) (map[string]*string, error) {
+	t, err := parseGoTemplate(rawArtifact)
+	if err != nil {
+		return nil, err
+	}
+	return inspectGoTemplateVariables(t), nil
+}
+
+// Process returns the final yaml with the template executed using values for the
+// variables retrieved from the variables client. If there are required variables without
+// corresponding values, it will return the raw yaml along with an error.
+func (tp *GoTemplateProcessor) Process(rawArtifact []byte, variablesClient func(string) (string, error)) ([]byte, error) {
+	t, err := parseGoTemplate(rawArtifact)
+	if err != nil {
+		return rawArtifact, err
+	}
+
+	var missingVariables []string
+	data := map[string]interface{}{}
+	for name, defaultValue := range inspectGoTemplateVariables(t) {
+		value, err := variablesClient(name)
+		if err != nil {
+			// add to missingVariables list if the variable does not exist in the
+			// variablesClient AND it does not have a default value
+			if defaultValue == nil {
+				missingVariables = append(missingVariables, name)
+			}
+			// Optional variables are set to an empty value, so default and
+			// conditions in the template behaves as expected.
+			value = ""
+		}
+		data[name] = value
+	}
+
+	if len(missingVariables) > 0 {
+		return rawArtifact, &errMissingVariables{missingVariables}
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return rawArtifact, errors.Wrap(err, "failed to execute template")
+	}
+	return out.Bytes(), nil
+}
+
+// parseGoTemplate parses a go template, making the sprig hermetic functions available.
+func parseGoTemplate(rawArtifact []byte) (*template.Template, error) {
+	t, err := template.New("template").
+		Funcs(sprig.HermeticTxtFuncMap()).
+		Option("missingkey=error").
+		Parse(string(rawArtifact))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse template")
+	}
+	return t, nil
+}
+
+// goTemplateVariable tracks the usages of a variable in a go template.
+type goTemplateVariable struct {
+	// required is true if the variable is used at least once without a default value and outside of a condition.
+	required bool
+	// defaultValue is the first default value found for the variable, if any.
+	defaultValue *string
+}
+
+// inspectGoTemplateVariables walks all the trees of a go template and returns a map of the
+// variable names with their default values, or nil if the variable is required.
+func inspectGoTemplateVariables(t *template.Template) map[string]*string {
+	variables := map[string]*goTemplateVariable{}
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree == nil || tmpl.Tree.Root == nil {
+			continue
+		}
+		// NOTE: defined templates are assumed to be invoked with the top level data as dot.
+		walkGoTemplateNode(tmpl.Tree.Root, variables, true, false)
+	}
+
+	varMap := make(map[string]*string, len(variables))
+	for name, v := range variables {
+		switch {
+		case v.required:
+			varMap[name] = nil
+		case v.defaultValue != nil:
+			varMap[name] = v.defaultValue
+		default:
+			empty := ""
+			varMap[name] = &empty
+		}
+	}
+	return varMap
+}
+
+// walkGoTemplateNode recursively walks down a node of a go template parse tree tracking variables.
+// topLevelDot is true when dot refers to the top level data, i.e. outside of range and with blocks;
+// condition is true when walking the pipeline of if, with and range actions.
+func walkGoTemplateNode(node parse.Node, variables map[string]*goTemplateVariable, topLevelDot, condition bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, ln := range n.Nodes {
+			walkGoTemplateNode(ln, variables, topLevelDot, condition)
+		}
+	case *parse.ActionNode:
+		walkGoTemplatePipe(n.Pipe, variables, topLevelDot, condition)
+	case *parse.IfNode:
+		walkGoTemplatePipe(n.Pipe, variables, topLevelDot, true)
+		walkGoTemplateNode(n.List, variables, topLevelDot, condition)
+		walkGoTemplateNode(n.ElseList, variables, topLevelDot, condition)
+	case *parse.WithNode:
+		walkGoTemplatePipe(n.Pipe, variables, topLevelDot, true)
+		walkGoTemplateNode(n.List, variables, false, condition)
+		walkGoTemplateNode(n.ElseList, variables, topLevelDot, condition)
+	case *parse.RangeNode:
+		walkGoTemplatePipe(n.Pipe, variables, topLevelDot, true)
+		walkGoTemplateNode(n.List, variables, false, condition)
+		walkGoTemplateNode(n.ElseList, variables, topLevelDot, condition)
+	case *parse.TemplateNode:
+		walkGoTemplatePipe(n.Pipe, variables, topLevelDot, condition)
+	case *parse.PipeNode:
+		walkGoTemplatePipe(n, variables, topLevelDot, condition)
+	}
+}
+
+// walkGoTemplatePipe walks a pipeline tracking variables and their default values,
+// e.g. {{ .VAR | default "value" }} or {{ default "value" .VAR }}.
+func walkGoTemplatePipe(pipe *parse.PipeNode, variables map[string]*goTemplateVariable, topLevelDot, condition bool) {
+	if pipe == nil {
+		return
+	}
+	for i, cmd := range pipe.Cmds {
+		// A command like `default "value"` provides a default value for the output of the previous command, if it is a variable.
+		if defaultValue, ok := goTemplateDefaultValue(cmd.Args); ok && len(cmd.Args) == 2 && i > 0 {
+			if prev := pipe.Cmds[i-1]; len(prev.Args) == 1 {
+				if name, ok := goTemplateVariableName(prev.Args[0], topLevelDot); ok {
+					trackGoTemplateVariable(variables, name, false, &defaultValue)
+				}
+			}
+		}
+
+		// A command like `default "value" .VAR` provides a default value for the variable.
+		var withDefault *string
+		if defaultValue, ok := goTemplateDefaultValue(cmd.Args); ok {
+			withDefault = &defaultValue
+		}
+
+		// The output of a command piped into `default "value"` is not required.
+		pipedToDefault := false
+		if i+1 < len(pipe.Cmds) {
+			_, pipedToDefault = goTemplateDefaultValue(pipe.Cmds[i+1].Args)
+			pipedToDefault = pipedToDefault && len(pipe.Cmds[i+1].Args) == 2 && len(cmd.Args) == 1
+		}
+
+		for _, arg := range cmd.Args {
+			if name, ok := goTemplateVariableName(arg, topLevelDot); ok {
+				switch {
+				case withDefault != nil:
+					trackGoTemplateVariable(variables, name, false, withDefault)
+				case pipedToDefault:
+					// default value already tracked when processing the next command.
+					trackGoTemplateVariable(variables, name, false, nil)
+				default:
+					trackGoTemplateVariable(variables, name, !condition, nil)
+				}
+				continue
+			}
+			walkGoTemplateNode(arg, variables, topLevelDot, condition)
+		}
+	}
+}
+
+// goTemplateVariableName returns the name of the variable referenced by a node, if any;
+// both .VAR (only if dot is the top level data) and $.VAR are supported.
+func goTemplateVariableName(node parse.Node, topLevelDot bool) (string, bool) {
+	switch n := node.(type) {
+	case *parse.FieldNode:
+		if topLevelDot && len(n.Ident) > 0 {
+			return n.Ident[0], true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			return n.Ident[1], true
+		}
+	}
+	return "", false
+}
+
+// goTemplateDefaultValue returns the default value if the args are in the form `default <literal> ...`.
+func goTemplateDefaultValue(args []parse.Node) (string, bool) {
+	if len(args) < 2 {
+		return "", false
+	}
+	if id, ok := args[0].(*parse.IdentifierNode); !ok || id.Ident != "default" {
+		return "", false
+	}
+	switch v := args[1].(type) {
+	case *parse.StringNode:
+		return v.Text, true
+	case *parse.NumberNode:
+		return v.Text, true
+	case *parse.BoolNode:
+		return fmt.Sprintf("%t", v.True), true
+	}
+	return "", false
+}
+
+func trackGoTemplateVariable(variables map[string]*goTemplateVariable, name string, required bool, defaultValue *string) {
+	v, ok := variables[name]
+	if !ok {
+		v = &goTemplateVariable{}
+		variables[name] = v
+	}
+	v.required = v.required || required
+	if v.defaultValue == nil && defaultValue != nil {
+		v.defaultValue = defaultValue
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yamlprocessor
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func TestGoTemplateProcessor_GetTemplateName(t *testing.T) {
+	g := NewWithT(t)
+	p := NewGoTemplateProcessor()
+	g.Expect(p.GetTemplateName("some-version", "some-flavor")).To(Equal("cluster-template-some-flavor.yaml"))
+	g.Expect(p.GetTemplateName("", "")).To(Equal("cluster-template.yaml"))
+	g.Expect(p.GetClusterClassTemplateName("some-version", "some-name")).To(Equal("clusterclass-some-name.yaml"))
+}
+
+func TestGoTemplateProcessor_GetVariables(t *testing.T) {
+	g := NewWithT(t)
+	p := NewGoTemplateProcessor()
+
+	got, err := p.GetVariables([]byte("yaml with {{ .C }}\n{{ .B }}\n{{ .A }} {{ .A }}"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal([]string{"A", "B", "C"}))
+
+	_, err = p.GetVariables([]byte("yaml with {{ .A "))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGoTemplateProcessor_GetVariablesMap(t *testing.T) {
+	def := "default"
+	three := "3"
+	empty := ""
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]*string
+		wantErr bool
+	}{
+		{
+			name: "variables without defaults are required",
+			data: "yaml with {{ .A }} {{.B}} {{ $.C }}",
+			want: map[string]*string{"A": nil, "B": nil, "C": nil},
+		},
+		{
+			name: "variables with defaults are properly parsed",
+			data: `yaml with {{ .A | default "default" }} {{ default 3 .B }} {{ .C | default "default" | quote }}`,
+			want: map[string]*string{"A": &def, "B": &three, "C": &def},
+		},
+		{
+			name: "variables used only in conditions are optional",
+			data: "{{ if .A }}{{ .B }}{{ end }}{{ range until (atoi .C) }}{{ . }}{{ end }}{{ with .D }}{{ .Field }}{{ end }}",
+			want: map[string]*string{"A": &empty, "B": nil, "C": &empty, "D": &empty},
+		},
+		{
+			name: "variables are required if used at least once without a default value",
+			data: `{{ if .A }}{{ .A }}{{ end }} {{ .B | default "default" }} {{ .B }}`,
+			want: map[string]*string{"A": nil, "B": nil},
+		},
+		{
+			name: "top level variables are detected in range and with blocks",
+			data: "{{ range $i, $e := until 3 }}{{ $.A }}-{{ $i }}{{ end }}{{ with .B }}{{ $.C }}{{ end }}",
+			want: map[string]*string{"A": nil, "B": &empty, "C": nil},
+		},
+		{
+			name: "variables in defined templates are detected",
+			data: `{{ define "foo" }}{{ .A }}{{ end }}{{ template "foo" . }}`,
+			want: map[string]*string{"A": nil},
+		},
+		{
+			name:    "returns error for invalid templates",
+			data:    "yaml with {{ .A ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := NewGoTemplateProcessor()
+			actual, err := p.GetVariableMap([]byte(tt.data))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(actual).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGoTemplateProcessor_Process(t *testing.T) {
+	tests := []struct {
+		name                  string
+		yaml                  []byte
+		configVariablesClient config.VariablesClient
+		want                  []byte
+		wantErr               bool
+		missingVariables      []string
+	}{
+		{
+			name:                  "replaces variables",
+			yaml:                  []byte("foo {{ .BAR }}, {{ $.BAR }}"),
+			configVariablesClient: test.NewFakeVariableClient().WithVar("BAR", "ba$r"),
+			want:                  []byte("foo ba$r, ba$r"),
+		},
+		{
+			name:                  "uses default values if variable doesn't exist in variables client",
+			yaml:                  []byte(`foo {{ .BAR | default "default_bar" }} {{ default "default_baz" .BAZ }} {{ .CAR | default "default_car" }}`),
+			configVariablesClient: test.NewFakeVariableClient().WithVar("BAR", "bar").WithVar("CAR", ""),
+			want:                  []byte("foo bar default_baz default_car"),
+		},
+		{
+			name:                  "supports conditionals",
+			yaml:                  []byte("{{ if .BASTION_ENABLED }}bastion: {{ .BASTION_IMAGE }}{{ else }}no bastion{{ end }}"),
+			configVariablesClient: test.NewFakeVariableClient().WithVar("BASTION_IMAGE", "image"),
+			want:                  []byte("no bastion"),
+		},
+		{
+			name: "supports loops",
+			yaml: []byte(`{{ range $i := until (atoi .MD_COUNT) }}
+- name: {{ $.CLUSTER_NAME }}-md-{{ $i }}{{ end }}`),
+			configVariablesClient: test.NewFakeVariableClient().WithVar("MD_COUNT", "2").WithVar("CLUSTER_NAME", "foo"),
+			want: []byte(`
+- name: foo-md-0
+- name: foo-md-1`),
+		},
+		{
+			name:                  "returns error with missing template variables listed (for better ux)",
+			yaml:                  []byte("foo {{ .BAR }} {{ .BAZ }} {{ .CAR }} {{ .DAR | default 1 }}"),
+			configVariablesClient: test.NewFakeVariableClient().WithVar("CAR", "car"),
+			wantErr:               true,
+			missingVariables:      []string{"BAR", "BAZ"},
+		},
+		{
+			name:                  "returns error when the template fails to execute",
+			yaml:                  []byte("foo {{ atoi .BAR | add1 | required }}"),
+			configVariablesClient: test.NewFakeVariableClient().WithVar("BAR", "1"),
+			wantErr:               true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := NewGoTemplateProcessor()
+
+			got, err := p.Process(tt.yaml, tt.configVariablesClient.Get)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				if len(tt.missingVariables) != 0 {
+					e, ok := err.(*errMissingVariables)
+					g.Expect(ok).To(BeTrue())
+					g.Expect(e.Missing).To(ConsistOf(tt.missingVariables))
+				}
+				// we want to ensure that we keep returning the original yaml
+				// as per the intended behavior of Process
+				g.Expect(got).To(Equal(tt.yaml))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+var configCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the clusterctl cache for provider repository downloads",
+	Long: LongDesc(`
+		Manage the clusterctl cache for provider repository downloads.
+
+		clusterctl caches the list of releases and the release assets downloaded from provider
+		repositories under $HOME/.cluster-api/cache; the cache can be configured using the cache
+		section of the $HOME/.cluster-api/clusterctl.yaml file.`),
+}
+
+var configCacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Args:  cobra.NoArgs,
+	Short: "Remove all the data stored in the clusterctl cache",
+	Long: LongDesc(`
+		Remove all the data stored in the clusterctl cache.`),
+
+	Example: Examples(`
+		# Removes all the data stored in the clusterctl cache.
+		clusterctl config cache clean`),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigCacheClean()
+	},
+}
+
+func init() {
+	configCacheCmd.AddCommand(configCacheCleanCmd)
+	configCmd.AddCommand(configCacheCmd)
+}
+
+func runConfigCacheClean() error {
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.CleanCache()
+}
@@ -40,6 +40,7 @@ type stackTracer interface {
 var (
 	cfgFile   string
 	verbosity *int
+	offline   bool
 )
 
 // RootCmd is clusterctl root CLI command.
@@ -58,6 +59,15 @@ var RootCmd = &cobra.Command{
 				return errors.Wrapf(err, "failed to create the clusterctl config directory: %s", configFolderPath)
 			}
 		}
+
+		// If requested, force clusterctl to use only data from the cache for provider repositories.
+		if offline {
+			configClient, err := config.New(cfgFile)
+			if err != nil {
+				return err
+			}
+			configClient.Variables().Set(config.CacheOfflineVariable, "true")
+		}
 		return nil
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
@@ -72,6 +82,10 @@ var RootCmd = &cobra.Command{
 			// version check is disabled. Return early.
 			return nil
 		}
+		if o, err := configClient.Variables().Get(config.CacheOfflineVariable); err == nil && o == "true" {
+			// version check requires access to GitHub, that is not allowed in offline mode. Return early.
+			return nil
+		}
 		output, err := newVersionChecker(configClient.Variables()).Check()
 		if err != nil {
 			return errors.Wrap(err, "unable to verify clusterctl version")
@@ -117,6 +131,8 @@ func init() {
 	RootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "",
 		"Path to clusterctl configuration (default is `$HOME/.cluster-api/clusterctl.yaml`) or to a remote location (i.e. https://example.com/clusterctl.yaml)")
+	RootCmd.PersistentFlags().BoolVar(&offline, "offline", false,
+		"Use only data from the clusterctl cache when reading from provider repositories. This is equivalent to setting the CLUSTERCTL_OFFLINE environment variable to true.")
 
 	cobra.OnInitialize(initConfig, registerCompletionFuncForCommonFlags)
 }
@@ -17,17 +17,23 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sort"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/google/go-cmp/cmp"
 	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/spf13/cobra"
+	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
@@ -41,8 +47,18 @@ type topologyPlanOptions struct {
 	cluster           string
 	namespace         string
 	outDir            string
+	output            string
+	diff              bool
 }
 
+const (
+	// TopologyPlanOutputJSON is the JSON output format for the topology plan.
+	TopologyPlanOutputJSON = "json"
+
+	// TopologyPlanOutputYAML is the YAML output format for the topology plan.
+	TopologyPlanOutputYAML = "yaml"
+)
+
 var tp = &topologyPlanOptions{}
 
 var topologyPlanCmd = &cobra.Command{
@@ -52,6 +68,10 @@ var topologyPlanCmd = &cobra.Command{
 		Provide the list of objects that would be created, modified and deleted when an input file is applied.
 		The input can be a file with a new/modified cluster, new/modified ClusterClass, new/modified templates.
 		Details about the objects that will be created and modified will be stored in a path passed using --output-directory.
+		Use --diff to print a unified diff of the changes, or --output-format to print the complete plan in JSON or YAML format.
+
+		Note: Differently from other commands, the output format is set with --output-format and not with -o, because -o
+		is the shorthand of --output-directory and it is kept for backward compatibility.
 
 		This command can also be run without a real cluster. In such cases, the input should contain all the objects needed.
 
@@ -60,22 +80,28 @@ var topologyPlanCmd = &cobra.Command{
 	`),
 	Example: Examples(`
 		# List all the objects that will be created and modified when creating a new cluster.
-		clusterctl alpha topology plan -f new-cluster.yaml -o output/
+		clusterctl alpha topology plan -f new-cluster.yaml --output-directory output/
 	    
 		# List the changes when modifying a cluster.
-		clusterctl alpha topology plan -f modified-cluster.yaml -o output/
+		clusterctl alpha topology plan -f modified-cluster.yaml --output-directory output/
 
 		# List all the objects that will be created and modified when creating a new cluster along with a new ClusterClass.
-		clusterctl alpha topology plan -f new-cluster-and-cluster-class.yaml -o output/
+		clusterctl alpha topology plan -f new-cluster-and-cluster-class.yaml --output-directory output/
 
 		# List the clusters impacted by a ClusterClass change.
-		clusterctl alpha topology plan -f modified-cluster-class.yaml -o output/
+		clusterctl alpha topology plan -f modified-cluster-class.yaml --output-directory output/
 	
 		# List the changes to "cluster1" when a ClusterClass is changed.
-		clusterctl alpha topology plan -f modified-cluster-class.yaml --cluster "cluster1" -o output/
+		clusterctl alpha topology plan -f modified-cluster-class.yaml --cluster "cluster1" --output-directory output/
 
 		# List the clusters and ClusterClasses impacted by a template change.
-		clusterctl alpha topology plan -f modified-template.yaml -o output/
+		clusterctl alpha topology plan -f modified-template.yaml --output-directory output/
+
+		# Print a unified diff of the changes to "cluster1" when a ClusterClass is changed.
+		clusterctl alpha topology plan -f modified-cluster-class.yaml --cluster "cluster1" --diff
+
+		# Print the complete plan in JSON format, e.g. to be consumed by CI tooling.
+		clusterctl alpha topology plan -f modified-cluster-class.yaml --cluster "cluster1" --output-format json
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -93,18 +119,27 @@ func init() {
 	topologyPlanCmd.Flags().StringVarP(&tp.cluster, "cluster", "c", "", "name of the target cluster; this parameter is required when more than one cluster is affected")
 	topologyPlanCmd.Flags().StringVarP(&tp.namespace, "namespace", "n", "", "target namespace for the operation. If specified, it is used as default namespace for objects with missing namespace")
 	topologyPlanCmd.Flags().StringVarP(&tp.outDir, "output-directory", "o", "", "output directory to write details about created/modified objects")
+	topologyPlanCmd.Flags().StringVar(&tp.output, "output-format", "", "output format for the complete plan; available options are 'json' and 'yaml'. If unspecified, a summary of the changes is printed")
+	topologyPlanCmd.Flags().BoolVar(&tp.diff, "diff", false, "print a unified diff of the objects that will be created, modified and deleted")
 
 	if err := topologyPlanCmd.MarkFlagRequired("file"); err != nil {
 		panic(err)
 	}
-	if err := topologyPlanCmd.MarkFlagRequired("output-directory"); err != nil {
-		panic(err)
-	}
 
 	topologyCmd.AddCommand(topologyPlanCmd)
 }
 
 func runTopologyPlan() error {
+	switch tp.output {
+	case "":
+	case TopologyPlanOutputJSON, TopologyPlanOutputYAML:
+		if tp.outDir != "" || tp.diff {
+			return errors.New("--output-format can't be used together with --output-directory or --diff")
+		}
+	default:
+		return errors.Errorf("invalid output format %q; available options are %q and %q. Use --output-directory to write details about created/modified objects to a directory", tp.output, TopologyPlanOutputJSON, TopologyPlanOutputYAML)
+	}
+
 	c, err := client.New(cfgFile)
 	if err != nil {
 		return err
@@ -132,10 +167,13 @@ func runTopologyPlan() error {
 	if err != nil {
 		return err
 	}
-	return printTopologyPlanOutput(out, tp.outDir)
+	if tp.output != "" {
+		return writeTopologyPlan(os.Stdout, out, tp.output)
+	}
+	return printTopologyPlanOutput(out, tp.outDir, tp.diff)
 }
 
-func printTopologyPlanOutput(out *cluster.TopologyPlanOutput, outdir string) error {
+func printTopologyPlanOutput(out *cluster.TopologyPlanOutput, outdir string, diff bool) error {
 	printAffectedClusterClasses(out)
 	printAffectedClusters(out)
 	if len(out.Clusters) == 0 {
@@ -146,8 +184,15 @@ func printTopologyPlanOutput(out *cluster.TopologyPlanOutput, outdir string) err
 		fmt.Printf("No target cluster identified. Use --cluster to specify a target cluster to get detailed changes.")
 	} else {
 		printChangeSummary(out)
-		if err := writeOutputFiles(out, outdir); err != nil {
-			return errors.Wrap(err, "failed to write output files of target cluster changes")
+		if outdir != "" {
+			if err := writeOutputFiles(out, outdir); err != nil {
+				return errors.Wrap(err, "failed to write output files of target cluster changes")
+			}
+		}
+		if diff {
+			if err := writeTopologyPlanDiff(os.Stdout, out); err != nil {
+				return errors.Wrap(err, "failed to write diff of target cluster changes")
+			}
 		}
 	}
 	fmt.Printf("\n")
@@ -196,20 +241,19 @@ func printChangeSummary(out *cluster.TopologyPlanOutput) {
 	table.SetHeaderLine(false)
 	table.SetBorder(false)
 
+	sortChangeSummary(out.ChangeSummary)
+
 	// Add the created rows.
-	sort.Slice(out.Created, func(i, j int) bool { return lessByKindAndName(out.Created[i], out.Created[j]) })
 	for _, c := range out.Created {
 		addRow(table, c, "created", tablewriter.FgGreenColor)
 	}
 
 	// Add the modified rows.
-	sort.Slice(out.Modified, func(i, j int) bool { return lessByKindAndName(out.Modified[i].After, out.Modified[j].After) })
 	for _, m := range out.Modified {
 		addRow(table, m.After, "modified", tablewriter.FgYellowColor)
 	}
 
 	// Add the deleted rows.
-	sort.Slice(out.Deleted, func(i, j int) bool { return lessByKindAndName(out.Deleted[i], out.Deleted[j]) })
 	for _, d := range out.Deleted {
 		addRow(table, d, "deleted", tablewriter.FgRedColor)
 	}
@@ -264,10 +308,9 @@ func writeOutputFiles(out *cluster.TopologyPlanOutput, outDir string) error {
 		}
 
 		// Calculate the jsonpatch and write to a file.
-		patch := crclient.MergeFrom(m.Before)
-		jsonPatch, err := patch.Data(m.After)
+		jsonPatch, err := calculateJSONPatch(m)
 		if err != nil {
-			return errors.Wrapf(err, "failed to calculate jsonpatch of modified object %s/%s", m.After.GetNamespace(), m.After.GetName())
+			return err
 		}
 		patchFileName := fmt.Sprintf("%s_%s_%s.jsonpatch", m.After.GetKind(), m.After.GetNamespace(), m.After.GetName())
 		patchFilePath := path.Join(modifiedDir, patchFileName)
@@ -290,6 +333,202 @@ func writeOutputFiles(out *cluster.TopologyPlanOutput, outDir string) error {
 	return nil
 }
 
+// topologyPlan is the machine-readable representation of a TopologyPlanOutput.
+type topologyPlan struct {
+	Clusters          []objectKey                  `json:"clusters"`
+	ClusterClasses    []objectKey                  `json:"clusterClasses"`
+	ReconciledCluster *objectKey                   `json:"reconciledCluster,omitempty"`
+	Created           []*unstructured.Unstructured `json:"created,omitempty"`
+	Modified          []topologyPlanModifiedObject `json:"modified,omitempty"`
+	Deleted           []*unstructured.Unstructured `json:"deleted,omitempty"`
+}
+
+// topologyPlanModifiedObject is the machine-readable representation of a modified object.
+type topologyPlanModifiedObject struct {
+	Before    *unstructured.Unstructured `json:"before"`
+	After     *unstructured.Unstructured `json:"after"`
+	JSONPatch json.RawMessage            `json:"jsonPatch"`
+}
+
+type objectKey struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// writeTopologyPlan writes the complete topology plan in the given format.
+func writeTopologyPlan(w io.Writer, out *cluster.TopologyPlanOutput, format string) error {
+	plan := topologyPlan{
+		Clusters:       []objectKey{},
+		ClusterClasses: []objectKey{},
+	}
+	for _, c := range out.Clusters {
+		plan.Clusters = append(plan.Clusters, objectKey{Namespace: c.Namespace, Name: c.Name})
+	}
+	for _, cc := range out.ClusterClasses {
+		plan.ClusterClasses = append(plan.ClusterClasses, objectKey{Namespace: cc.Namespace, Name: cc.Name})
+	}
+	if out.ReconciledCluster != nil {
+		plan.ReconciledCluster = &objectKey{Namespace: out.ReconciledCluster.Namespace, Name: out.ReconciledCluster.Name}
+	}
+	if out.ChangeSummary != nil {
+		sortChangeSummary(out.ChangeSummary)
+		plan.Created = out.Created
+		plan.Deleted = out.Deleted
+		for _, m := range out.Modified {
+			jsonPatch, err := calculateJSONPatch(m)
+			if err != nil {
+				return err
+			}
+			plan.Modified = append(plan.Modified, topologyPlanModifiedObject{
+				Before:    m.Before,
+				After:     m.After,
+				JSONPatch: jsonPatch,
+			})
+		}
+	}
+
+	var raw []byte
+	var err error
+	switch format {
+	case TopologyPlanOutputJSON:
+		raw, err = json.MarshalIndent(plan, "", "  ")
+		raw = append(raw, '\n')
+	case TopologyPlanOutputYAML:
+		raw, err = yaml.Marshal(plan)
+	default:
+		return errors.Errorf("invalid output format %q", format)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal topology plan to %s", format)
+	}
+	_, err = w.Write(raw)
+	return err
+}
+
+// writeTopologyPlanDiff writes a unified diff of the YAML representation of the objects
+// created, modified and deleted on the ReconciledCluster.
+func writeTopologyPlanDiff(w io.Writer, out *cluster.TopologyPlanOutput) error {
+	if out.ChangeSummary == nil {
+		return nil
+	}
+	sortChangeSummary(out.ChangeSummary)
+
+	for _, c := range out.Created {
+		if err := writeObjectDiff(w, nil, c); err != nil {
+			return err
+		}
+	}
+	for _, m := range out.Modified {
+		if err := writeObjectDiff(w, m.Before, m.After); err != nil {
+			return err
+		}
+	}
+	for _, d := range out.Deleted {
+		if err := writeObjectDiff(w, d, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeObjectDiff writes a colorized unified diff between the YAML representation of two objects;
+// a nil object is represented as /dev/null.
+func writeObjectDiff(w io.Writer, before, after *unstructured.Unstructured) error {
+	fromFile, beforeYAML, err := diffFile("a", before)
+	if err != nil {
+		return err
+	}
+	toFile, afterYAML, err := diffFile("b", after)
+	if err != nil {
+		return err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(beforeYAML),
+		B:        splitLines(afterYAML),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate diff")
+	}
+
+	for _, line := range splitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			_, err = color.New(color.Bold).Fprint(w, line)
+		case strings.HasPrefix(line, "@@"):
+			_, err = cyan.Fprint(w, line)
+		case strings.HasPrefix(line, "+"):
+			_, err = green.Fprint(w, line)
+		case strings.HasPrefix(line, "-"):
+			_, err = red.Fprint(w, line)
+		default:
+			_, err = fmt.Fprint(w, line)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitLines splits a string into lines, keeping the trailing newlines.
+// NOTE: difflib.SplitLines is not used because it adds an empty line for strings
+// ending with a newline, including empty strings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffFile returns the file name and the YAML representation of an object to be used in a diff.
+func diffFile(prefix string, obj *unstructured.Unstructured) (string, string, error) {
+	if obj == nil {
+		return "/dev/null", "", nil
+	}
+	raw, err := utilyaml.FromUnstructured([]unstructured.Unstructured{*obj})
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to convert object to yaml")
+	}
+	content := string(raw)
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return path.Join(prefix, obj.GetKind(), obj.GetNamespace(), obj.GetName()), content, nil
+}
+
+// calculateJSONPatch calculates the JSON patch (RFC 6902) between the original and the modified object.
+func calculateJSONPatch(m *cluster.PatchSummary) ([]byte, error) {
+	before, err := json.Marshal(m.Before)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal original object %s/%s", m.Before.GetNamespace(), m.Before.GetName())
+	}
+	after, err := json.Marshal(m.After)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal modified object %s/%s", m.After.GetNamespace(), m.After.GetName())
+	}
+	operations, err := jsonpatch.CreatePatch(before, after)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate jsonpatch of modified object %s/%s", m.After.GetNamespace(), m.After.GetName())
+	}
+	jsonPatch, err := json.Marshal(operations)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal jsonpatch of modified object %s/%s", m.After.GetNamespace(), m.After.GetName())
+	}
+	return jsonPatch, nil
+}
+
+// sortChangeSummary sorts the objects in a ChangeSummary by kind and name.
+func sortChangeSummary(changes *cluster.ChangeSummary) {
+	sort.Slice(changes.Created, func(i, j int) bool { return lessByKindAndName(changes.Created[i], changes.Created[j]) })
+	sort.Slice(changes.Modified, func(i, j int) bool { return lessByKindAndName(changes.Modified[i].After, changes.Modified[j].After) })
+	sort.Slice(changes.Deleted, func(i, j int) bool { return lessByKindAndName(changes.Deleted[i], changes.Deleted[j]) })
+}
+
 func writeObjectToFile(filePath string, obj *unstructured.Unstructured) error {
 	yaml, err := utilyaml.FromUnstructured([]unstructured.Unstructured{*obj})
 	if err != nil {
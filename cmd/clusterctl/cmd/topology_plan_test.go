@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func TestWriteTopologyPlan(t *testing.T) {
+	out := fakeTopologyPlanOutput()
+
+	t.Run("json", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(writeTopologyPlan(&buf, out, TopologyPlanOutputJSON)).To(Succeed())
+		g.Expect(buf.String()).To(MatchJSON(`{
+  "clusters": [{"namespace": "default", "name": "cluster1"}],
+  "clusterClasses": [],
+  "reconciledCluster": {"namespace": "default", "name": "cluster1"},
+  "created": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "created", "namespace": "default"}}],
+  "modified": [{
+    "before": {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "modified", "namespace": "default"}, "data": {"foo": "bar"}},
+    "after": {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "modified", "namespace": "default"}, "data": {"foo": "baz"}},
+    "jsonPatch": [{"op": "replace", "path": "/data/foo", "value": "baz"}]
+  }],
+  "deleted": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "deleted", "namespace": "default"}}]
+}`))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(writeTopologyPlan(&buf, out, TopologyPlanOutputYAML)).To(Succeed())
+
+		var jsonBuf bytes.Buffer
+		g.Expect(writeTopologyPlan(&jsonBuf, out, TopologyPlanOutputJSON)).To(Succeed())
+		expected, err := yaml.JSONToYAML(jsonBuf.Bytes())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(buf.String()).To(MatchYAML(string(expected)))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		g := NewWithT(t)
+
+		var buf bytes.Buffer
+		g.Expect(writeTopologyPlan(&buf, out, "table")).ToNot(Succeed())
+	})
+}
+
+func TestWriteTopologyPlanDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	var buf bytes.Buffer
+	g.Expect(writeTopologyPlanDiff(&buf, fakeTopologyPlanOutput())).To(Succeed())
+	g.Expect(buf.String()).To(Equal(`--- /dev/null
++++ b/ConfigMap/default/created
+@@ -0,0 +1,5 @@
++apiVersion: v1
++kind: ConfigMap
++metadata:
++  name: created
++  namespace: default
+--- a/ConfigMap/default/modified
++++ b/ConfigMap/default/modified
+@@ -1,6 +1,6 @@
+ apiVersion: v1
+ data:
+-  foo: bar
++  foo: baz
+ kind: ConfigMap
+ metadata:
+   name: modified
+--- a/ConfigMap/default/deleted
++++ /dev/null
+@@ -1,5 +0,0 @@
+-apiVersion: v1
+-kind: ConfigMap
+-metadata:
+-  name: deleted
+-  namespace: default
+`))
+}
+
+func TestCalculateJSONPatch(t *testing.T) {
+	g := NewWithT(t)
+
+	before := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "cm",
+			"namespace": "default",
+			"labels":    map[string]interface{}{"removed": "true"},
+		},
+		"data": map[string]interface{}{"foo": "bar"},
+		"list": []interface{}{"a", "b", "c"},
+	}}
+	after := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "cm",
+			"namespace": "default",
+		},
+		"data": map[string]interface{}{"foo": "baz", "added": "true"},
+		"list": []interface{}{"a", "c"},
+	}}
+
+	patch, err := calculateJSONPatch(&cluster.PatchSummary{Before: before, After: after})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The patch must be a list of RFC 6902 operations transforming the original object in the modified object.
+	decoded, err := jsonpatch.DecodePatch(patch)
+	g.Expect(err).ToNot(HaveOccurred())
+	original, err := json.Marshal(before)
+	g.Expect(err).ToNot(HaveOccurred())
+	patched, err := decoded.Apply(original)
+	g.Expect(err).ToNot(HaveOccurred())
+	modified, err := json.Marshal(after)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(patched).To(MatchJSON(modified))
+}
+
+func fakeTopologyPlanOutput() *cluster.TopologyPlanOutput {
+	configMap := func(name string, data map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+			},
+		}}
+		if data != nil {
+			obj.Object["data"] = data
+		}
+		return obj
+	}
+
+	clusterKey := crclient.ObjectKey{Namespace: "default", Name: "cluster1"}
+	return &cluster.TopologyPlanOutput{
+		Clusters:          []crclient.ObjectKey{clusterKey},
+		ReconciledCluster: &clusterKey,
+		ChangeSummary: &cluster.ChangeSummary{
+			Created: []*unstructured.Unstructured{configMap("created", nil)},
+			Modified: []*cluster.PatchSummary{
+				{
+					Before: configMap("modified", map[string]interface{}{"foo": "bar"}),
+					After:  configMap("modified", map[string]interface{}{"foo": "baz"}),
+				},
+			},
+			Deleted: []*unstructured.Unstructured{configMap("deleted", nil)},
+		},
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+type topologyRebaseOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	cluster           string
+	namespace         string
+	toClass           string
+	toClassNamespace  string
+	diff              bool
+	apply             bool
+}
+
+var tr = &topologyRebaseOptions{}
+
+var topologyRebaseCmd = &cobra.Command{
+	Use:   "rebase",
+	Short: "Rebase a Cluster that uses a managed topology to a different ClusterClass",
+	Long: LongDesc(`
+		Rebase a Cluster that uses a managed topology to a different ClusterClass.
+
+		The command checks that the target ClusterClass allows the namespace of the Cluster, that the current and
+		the target ClusterClass are compatible and that the Cluster variables are valid for the target ClusterClass,
+		running the same checks executed by the Cluster webhooks against the objects in the management cluster, and
+		then previews the templates that will be created, modified and deleted and the objects that will roll out
+		their Machines.
+
+		The Cluster is rebased only if --apply is set.
+	`),
+	Example: Examples(`
+		# Check if the Cluster "cluster1" can be rebased to the ClusterClass "new-class" and preview the changes.
+		clusterctl alpha topology rebase --cluster cluster1 --to-class new-class
+
+		# Check if the Cluster "cluster1" can be rebased to the ClusterClass "new-class" in the namespace "shared".
+		clusterctl alpha topology rebase --cluster cluster1 --to-class new-class --to-class-namespace shared
+
+		# Preview the changes with a unified diff.
+		clusterctl alpha topology rebase --cluster cluster1 --to-class new-class --diff
+
+		# Rebase the Cluster "cluster1" to the ClusterClass "new-class".
+		clusterctl alpha topology rebase --cluster cluster1 --to-class new-class --apply
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTopologyRebase()
+	},
+}
+
+func init() {
+	topologyRebaseCmd.Flags().StringVar(&tr.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig for the management cluster. If unspecified, default discovery rules apply.")
+	topologyRebaseCmd.Flags().StringVar(&tr.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+
+	topologyRebaseCmd.Flags().StringVarP(&tr.cluster, "cluster", "c", "", "name of the Cluster to rebase")
+	topologyRebaseCmd.Flags().StringVarP(&tr.namespace, "namespace", "n", "", "namespace of the Cluster. If unspecified, the current namespace will be used")
+	topologyRebaseCmd.Flags().StringVar(&tr.toClass, "to-class", "", "name of the ClusterClass the Cluster should be rebased to")
+	topologyRebaseCmd.Flags().StringVar(&tr.toClassNamespace, "to-class-namespace", "", "namespace of the ClusterClass the Cluster should be rebased to. If unspecified, the namespace of the current ClusterClass will be used")
+	topologyRebaseCmd.Flags().BoolVar(&tr.diff, "diff", false, "print a unified diff of the objects that will be created, modified and deleted")
+	topologyRebaseCmd.Flags().BoolVar(&tr.apply, "apply", false, "rebase the Cluster after the compatibility checks and the preview")
+
+	if err := topologyRebaseCmd.MarkFlagRequired("cluster"); err != nil {
+		panic(err)
+	}
+	if err := topologyRebaseCmd.MarkFlagRequired("to-class"); err != nil {
+		panic(err)
+	}
+
+	topologyCmd.AddCommand(topologyRebaseCmd)
+}
+
+func runTopologyRebase() error {
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	out, err := c.TopologyRebase(client.TopologyRebaseOptions{
+		Kubeconfig:            client.Kubeconfig{Path: tr.kubeconfig, Context: tr.kubeconfigContext},
+		Cluster:               tr.cluster,
+		Namespace:             tr.namespace,
+		ClusterClass:          tr.toClass,
+		ClusterClassNamespace: tr.toClassNamespace,
+		Apply:                 tr.apply,
+	})
+	if err != nil {
+		return err
+	}
+	return printTopologyRebaseOutput(os.Stdout, out, tr.diff)
+}
+
+// printTopologyRebaseOutput prints the result of the compatibility checks, the preview of the changes and
+// the objects that will roll out their Machines; it returns an error if the Cluster can't be rebased.
+func printTopologyRebaseOutput(w io.Writer, out *cluster.TopologyRebaseOutput, diff bool) error {
+	if len(out.Errors) > 0 {
+		fmt.Fprintf(w, "ClusterClass %q is not compatible with ClusterClass %q used by Cluster %q:\n", out.ToClass, out.FromClass, out.Cluster.String())
+		for _, e := range out.Errors {
+			fmt.Fprintf(w, " ＊ %s\n", e.Error())
+		}
+		return errors.Errorf("Cluster %q can't be rebased to ClusterClass %q", out.Cluster.String(), out.ToClass)
+	}
+	fmt.Fprintf(w, "ClusterClass %q is compatible with ClusterClass %q used by Cluster %q.\n\n", out.ToClass, out.FromClass, out.Cluster.String())
+
+	plan := &cluster.TopologyPlanOutput{
+		ReconciledCluster: &out.Cluster,
+		ChangeSummary:     out.ChangeSummary,
+	}
+	printChangeSummary(plan)
+	if diff {
+		if err := writeTopologyPlanDiff(w, plan); err != nil {
+			return errors.Wrap(err, "failed to write diff of Cluster changes")
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	if len(out.Rollouts) == 0 {
+		fmt.Fprintf(w, "No Machines will be rolled out.\n")
+	} else {
+		fmt.Fprintf(w, "The following objects will roll out their Machines:\n")
+		for _, o := range out.Rollouts {
+			fmt.Fprintf(w, " ＊ %s %s/%s\n", o.GetKind(), o.GetNamespace(), o.GetName())
+		}
+	}
+	fmt.Fprintf(w, "\n")
+
+	if out.Applied {
+		fmt.Fprintf(w, "Cluster %q rebased to ClusterClass %q.\n", out.Cluster.String(), out.ToClass)
+	} else {
+		fmt.Fprintf(w, "Use --apply to rebase Cluster %q to ClusterClass %q.\n", out.Cluster.String(), out.ToClass)
+	}
+	return nil
+}
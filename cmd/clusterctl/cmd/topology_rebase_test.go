@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func TestPrintTopologyRebaseOutput(t *testing.T) {
+	clusterKey := crclient.ObjectKey{Namespace: "default", Name: "cluster1"}
+
+	t.Run("incompatible ClusterClass", func(t *testing.T) {
+		g := NewWithT(t)
+
+		out := &cluster.TopologyRebaseOutput{
+			Cluster:   clusterKey,
+			FromClass: "old-class",
+			ToClass:   "new-class",
+			Errors: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "infrastructure", "ref"), "kind cannot be changed"),
+			},
+		}
+
+		var buf bytes.Buffer
+		g.Expect(printTopologyRebaseOutput(&buf, out, false)).ToNot(Succeed())
+		g.Expect(buf.String()).To(ContainSubstring(`ClusterClass "new-class" is not compatible with ClusterClass "old-class" used by Cluster "default/cluster1"`))
+		g.Expect(buf.String()).To(ContainSubstring("kind cannot be changed"))
+	})
+
+	t.Run("compatible ClusterClass with diff", func(t *testing.T) {
+		g := NewWithT(t)
+
+		plan := fakeTopologyPlanOutput()
+		out := &cluster.TopologyRebaseOutput{
+			Cluster:       clusterKey,
+			FromClass:     "old-class",
+			ToClass:       "new-class",
+			ChangeSummary: plan.ChangeSummary,
+			Rollouts:      []*unstructured.Unstructured{plan.Modified[0].After},
+		}
+
+		var buf bytes.Buffer
+		g.Expect(printTopologyRebaseOutput(&buf, out, true)).To(Succeed())
+		g.Expect(buf.String()).To(ContainSubstring(`ClusterClass "new-class" is compatible with ClusterClass "old-class" used by Cluster "default/cluster1"`))
+		g.Expect(buf.String()).To(ContainSubstring("+++ b/ConfigMap/default/created"))
+		g.Expect(buf.String()).To(ContainSubstring(" ＊ ConfigMap default/modified"))
+		g.Expect(buf.String()).To(ContainSubstring(`Use --apply to rebase Cluster "default/cluster1" to ClusterClass "new-class"`))
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		g := NewWithT(t)
+
+		out := &cluster.TopologyRebaseOutput{
+			Cluster:       clusterKey,
+			FromClass:     "old-class",
+			ToClass:       "new-class",
+			ChangeSummary: &cluster.ChangeSummary{},
+			Applied:       true,
+		}
+
+		var buf bytes.Buffer
+		g.Expect(printTopologyRebaseOutput(&buf, out, false)).To(Succeed())
+		g.Expect(buf.String()).To(ContainSubstring("No Machines will be rolled out."))
+		g.Expect(buf.String()).To(ContainSubstring(`Cluster "default/cluster1" rebased to ClusterClass "new-class".`))
+	})
+}
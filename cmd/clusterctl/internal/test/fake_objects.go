@@ -41,16 +41,17 @@ import (
 )
 
 type FakeCluster struct {
-	namespace             string
-	name                  string
-	controlPlane          *FakeControlPlane
-	machinePools          []*FakeMachinePool
-	machineDeployments    []*FakeMachineDeployment
-	machineSets           []*FakeMachineSet
-	machines              []*FakeMachine
-	withCloudConfigSecret bool
-	withCredentialSecret  bool
-	topologyClass         *string
+	namespace              string
+	name                   string
+	controlPlane           *FakeControlPlane
+	machinePools           []*FakeMachinePool
+	machineDeployments     []*FakeMachineDeployment
+	machineSets            []*FakeMachineSet
+	machines               []*FakeMachine
+	withCloudConfigSecret  bool
+	withCredentialSecret   bool
+	topologyClass          *string
+	topologyClassNamespace string
 }
 
 // NewFakeCluster return a FakeCluster that can generate a cluster object, all its own ancillary objects:
@@ -106,6 +107,11 @@ func (f *FakeCluster) WithTopologyClass(class string) *FakeCluster {
 	return f
 }
 
+func (f *FakeCluster) WithTopologyClassNamespace(namespace string) *FakeCluster {
+	f.topologyClassNamespace = namespace
+	return f
+}
+
 func (f *FakeCluster) Objs() []client.Object {
 	clusterInfrastructure := &fakeinfrastructure.GenericInfrastructureCluster{
 		TypeMeta: metav1.TypeMeta{
@@ -141,7 +147,7 @@ func (f *FakeCluster) Objs() []client.Object {
 	}
 
 	if f.topologyClass != nil {
-		cluster.Spec.Topology = &clusterv1.Topology{Class: *f.topologyClass}
+		cluster.Spec.Topology = &clusterv1.Topology{Class: *f.topologyClass, ClassNamespace: f.topologyClassNamespace}
 	}
 
 	// Ensure the cluster gets a UID to be used by dependant objects for creating OwnerReferences.
@@ -136,6 +136,9 @@ type ClusterTopologyReconciler struct {
 
 	RuntimeClient runtimeclient.Client
 
+	// Tracker is used to apply the add-ons defined in the ClusterClass to the workload cluster.
+	Tracker *remote.ClusterCacheTracker
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -149,6 +152,7 @@ func (r *ClusterTopologyReconciler) SetupWithManager(ctx context.Context, mgr ct
 		Client:                    r.Client,
 		APIReader:                 r.APIReader,
 		RuntimeClient:             r.RuntimeClient,
+		Tracker:                   r.Tracker,
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
 		WatchFilterValue:          r.WatchFilterValue,
 	}).SetupWithManager(ctx, mgr, options)
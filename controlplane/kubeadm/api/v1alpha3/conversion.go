@@ -67,6 +67,11 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.KubeadmConfigSpec.Ignition = restored.Spec.KubeadmConfigSpec.Ignition
+	dst.Spec.KubeadmConfigSpec.BootstrapData = restored.Spec.KubeadmConfigSpec.BootstrapData
+	dst.Spec.KubeadmConfigSpec.Containerd = restored.Spec.KubeadmConfigSpec.Containerd
+	dst.Spec.KubeadmConfigSpec.SystemdUnits = restored.Spec.KubeadmConfigSpec.SystemdUnits
+	dst.Spec.KubeadmConfigSpec.TokenRotation = restored.Spec.KubeadmConfigSpec.TokenRotation
+	dst.Spec.KubeadmConfigSpec.External = restored.Spec.KubeadmConfigSpec.External
 	if restored.Spec.KubeadmConfigSpec.InitConfiguration != nil {
 		if dst.Spec.KubeadmConfigSpec.InitConfiguration == nil {
 			dst.Spec.KubeadmConfigSpec.InitConfiguration = &bootstrapv1.InitConfiguration{}
@@ -82,6 +87,9 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases
 	}
 
+	dst.Spec.RolloutBefore = restored.Spec.RolloutBefore
+	dst.Status.CertificateAuthoritiesRotation = restored.Status.CertificateAuthoritiesRotation
+
 	return nil
 }
 
@@ -120,7 +128,7 @@ func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha3_KubeadmControlPlaneSpec
 }
 
 func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apiconversion.Scope) error {
-	// NOTE: custom conversion func is required because status.Version does not exist in v1alpha3.
+	// NOTE: custom conversion func is required because status.Version and status.CertificateAuthoritiesRotation do not exist in v1alpha3.
 	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPlaneStatus(in, out, s)
 }
 
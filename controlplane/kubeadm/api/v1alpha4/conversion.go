@@ -51,6 +51,11 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.KubeadmConfigSpec.Ignition = restored.Spec.KubeadmConfigSpec.Ignition
+	dst.Spec.KubeadmConfigSpec.BootstrapData = restored.Spec.KubeadmConfigSpec.BootstrapData
+	dst.Spec.KubeadmConfigSpec.Containerd = restored.Spec.KubeadmConfigSpec.Containerd
+	dst.Spec.KubeadmConfigSpec.SystemdUnits = restored.Spec.KubeadmConfigSpec.SystemdUnits
+	dst.Spec.KubeadmConfigSpec.TokenRotation = restored.Spec.KubeadmConfigSpec.TokenRotation
+	dst.Spec.KubeadmConfigSpec.External = restored.Spec.KubeadmConfigSpec.External
 	if restored.Spec.KubeadmConfigSpec.InitConfiguration != nil {
 		if dst.Spec.KubeadmConfigSpec.InitConfiguration == nil {
 			dst.Spec.KubeadmConfigSpec.InitConfiguration = &bootstrapv1.InitConfiguration{}
@@ -67,6 +72,8 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.MachineTemplate.NodeDeletionTimeout
+	dst.Spec.RolloutBefore = restored.Spec.RolloutBefore
+	dst.Status.CertificateAuthoritiesRotation = restored.Status.CertificateAuthoritiesRotation
 
 	return nil
 }
@@ -110,6 +117,11 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.Spec.KubeadmConfigSpec.Files = restored.Spec.Template.Spec.KubeadmConfigSpec.Files
 	dst.Spec.Template.Spec.KubeadmConfigSpec.Users = restored.Spec.Template.Spec.KubeadmConfigSpec.Users
 	dst.Spec.Template.Spec.KubeadmConfigSpec.Ignition = restored.Spec.Template.Spec.KubeadmConfigSpec.Ignition
+	dst.Spec.Template.Spec.KubeadmConfigSpec.BootstrapData = restored.Spec.Template.Spec.KubeadmConfigSpec.BootstrapData
+	dst.Spec.Template.Spec.KubeadmConfigSpec.Containerd = restored.Spec.Template.Spec.KubeadmConfigSpec.Containerd
+	dst.Spec.Template.Spec.KubeadmConfigSpec.SystemdUnits = restored.Spec.Template.Spec.KubeadmConfigSpec.SystemdUnits
+	dst.Spec.Template.Spec.KubeadmConfigSpec.TokenRotation = restored.Spec.Template.Spec.KubeadmConfigSpec.TokenRotation
+	dst.Spec.Template.Spec.KubeadmConfigSpec.External = restored.Spec.Template.Spec.KubeadmConfigSpec.External
 	dst.Spec.Template.Spec.MachineTemplate = restored.Spec.Template.Spec.MachineTemplate
 
 	if restored.Spec.Template.Spec.KubeadmConfigSpec.Users != nil {
@@ -139,6 +151,7 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	} else if restored.Spec.Template.Spec.MachineTemplate != nil {
 		dst.Spec.Template.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.Template.Spec.MachineTemplate.NodeDeletionTimeout
 	}
+	dst.Spec.Template.Spec.RolloutBefore = restored.Spec.Template.Spec.RolloutBefore
 
 	return nil
 }
@@ -222,6 +235,16 @@ func Convert_v1beta1_KubeadmControlPlaneTemplateResourceSpec_To_v1alpha4_Kubeadm
 	return nil
 }
 
+func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in *controlplanev1.KubeadmControlPlaneSpec, out *KubeadmControlPlaneSpec, s apiconversion.Scope) error {
+	// .RolloutBefore was added in v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in, out, s)
+}
+
+func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apiconversion.Scope) error {
+	// .CertificateAuthoritiesRotation was added in v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in, out, s)
+}
+
 func Convert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in *controlplanev1.KubeadmControlPlaneMachineTemplate, out *KubeadmControlPlaneMachineTemplate, s apiconversion.Scope) error {
 	// .NodeDrainTimeout was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in, out, s)
@@ -49,6 +49,16 @@ const (
 	// KubeadmClusterConfigurationAnnotation is a machine annotation that stores the json-marshalled string of KCP ClusterConfiguration.
 	// This annotation is used to detect any changes in ClusterConfiguration and trigger machine rollout in KCP.
 	KubeadmClusterConfigurationAnnotation = "controlplane.cluster.x-k8s.io/kubeadm-cluster-configuration"
+
+	// RotateCertificateAuthoritiesAnnotation triggers the rotation of the certificate authorities and of the
+	// service account keys of the cluster; a new rotation is started every time the value of the annotation changes
+	// and no other rotation is in progress.
+	RotateCertificateAuthoritiesAnnotation = "controlplane.cluster.x-k8s.io/rotate-certificate-authorities"
+
+	// CertificateAuthoritiesRotationAnnotation is set on control plane Machines and on the machine template of
+	// MachineDeployments to record the phase of the certificate authorities rotation they have been created for.
+	// This annotation is used to roll out machines at each phase of the rotation.
+	CertificateAuthoritiesRotationAnnotation = "controlplane.cluster.x-k8s.io/certificate-authorities-rotation"
 )
 
 // KubeadmControlPlaneSpec defines the desired state of KubeadmControlPlane.
@@ -70,6 +80,11 @@ type KubeadmControlPlaneSpec struct {
 	// to use for initializing and joining machines to the control plane.
 	KubeadmConfigSpec bootstrapv1.KubeadmConfigSpec `json:"kubeadmConfigSpec"`
 
+	// RolloutBefore is a field to indicate a rollout should be performed
+	// if the specified criteria is met.
+	// +optional
+	RolloutBefore *RolloutBefore `json:"rolloutBefore,omitempty"`
+
 	// RolloutAfter is a field to indicate a rollout should be performed
 	// after the specified time even if no changes have been made to the
 	// KubeadmControlPlane.
@@ -109,6 +124,15 @@ type KubeadmControlPlaneMachineTemplate struct {
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
 }
 
+// RolloutBefore describes when a rollout should be performed on the KCP machines.
+type RolloutBefore struct {
+	// CertificatesExpiryDays indicates a rollout needs to be performed if the
+	// certificates of the control plane will expire within the specified days.
+	// +kubebuilder:validation:Minimum=7
+	// +optional
+	CertificatesExpiryDays *int32 `json:"certificatesExpiryDays,omitempty"`
+}
+
 // RolloutStrategy describes how to replace existing machines
 // with new ones.
 type RolloutStrategy struct {
@@ -201,6 +225,45 @@ type KubeadmControlPlaneStatus struct {
 	// Conditions defines current service state of the KubeadmControlPlane.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// CertificateAuthoritiesRotation reports the progress of the rotation of the certificate authorities
+	// and of the service account keys of the cluster.
+	// +optional
+	CertificateAuthoritiesRotation *CertificateAuthoritiesRotationStatus `json:"certificateAuthoritiesRotation,omitempty"`
+}
+
+// CertificateAuthoritiesRotationPhase is a phase of the rotation of the certificate authorities.
+type CertificateAuthoritiesRotationPhase string
+
+const (
+	// TrustNewCertificateAuthoritiesPhase is the phase where new certificate authorities and service account keys are
+	// added to the trust bundles, and all the machines are rolled out so they trust both the old and the new ones.
+	TrustNewCertificateAuthoritiesPhase = CertificateAuthoritiesRotationPhase("TrustNewCertificateAuthorities")
+
+	// SignWithNewCertificateAuthoritiesPhase is the phase where the new certificate authorities and service account keys
+	// are used for signing, and all the machines are rolled out so they get certificates signed by the new ones.
+	SignWithNewCertificateAuthoritiesPhase = CertificateAuthoritiesRotationPhase("SignWithNewCertificateAuthorities")
+
+	// RemoveOldCertificateAuthoritiesPhase is the phase where the old certificate authorities and service account keys
+	// are removed from the trust bundles, and all the machines are rolled out so they trust only the new ones.
+	RemoveOldCertificateAuthoritiesPhase = CertificateAuthoritiesRotationPhase("RemoveOldCertificateAuthorities")
+
+	// CertificateAuthoritiesRotationCompletedPhase is the phase of a completed rotation.
+	CertificateAuthoritiesRotationCompletedPhase = CertificateAuthoritiesRotationPhase("Completed")
+)
+
+// CertificateAuthoritiesRotationStatus reports the progress of the rotation of the certificate authorities.
+type CertificateAuthoritiesRotationStatus struct {
+	// Trigger is the value of the rotate-certificate-authorities annotation that triggered the rotation.
+	Trigger string `json:"trigger"`
+
+	// Phase is the current phase of the rotation.
+	// +kubebuilder:validation:Enum=TrustNewCertificateAuthorities;SignWithNewCertificateAuthorities;RemoveOldCertificateAuthorities;Completed
+	Phase CertificateAuthoritiesRotationPhase `json:"phase"`
+
+	// LastPhaseTransitionTime is the time the rotation entered the current phase.
+	// +optional
+	LastPhaseTransitionTime *metav1.Time `json:"lastPhaseTransitionTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
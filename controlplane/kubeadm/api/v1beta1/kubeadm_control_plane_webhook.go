@@ -126,6 +126,9 @@ const (
 	scheduler            = "scheduler"
 	ntp                  = "ntp"
 	ignition             = "ignition"
+	bootstrapData        = "bootstrapData"
+	containerd           = "containerd"
+	systemdUnits         = "systemdUnits"
 )
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -154,6 +157,9 @@ func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
 		{spec, kubeadmConfigSpec, users},
 		{spec, kubeadmConfigSpec, ntp, "*"},
 		{spec, kubeadmConfigSpec, ignition, "*"},
+		{spec, kubeadmConfigSpec, bootstrapData, "*"},
+		{spec, kubeadmConfigSpec, containerd, "*"},
+		{spec, kubeadmConfigSpec, systemdUnits},
 		{spec, "machineTemplate", "metadata", "*"},
 		{spec, "machineTemplate", "infrastructureRef", "apiVersion"},
 		{spec, "machineTemplate", "infrastructureRef", "name"},
@@ -163,6 +169,7 @@ func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
 		{spec, "replicas"},
 		{spec, "version"},
 		{spec, "rolloutAfter"},
+		{spec, "rolloutBefore", "*"},
 		{spec, "rolloutStrategy", "*"},
 	}
 
@@ -309,15 +316,47 @@ func validateKubeadmControlPlaneSpec(s KubeadmControlPlaneSpec, namespace string
 		)
 	}
 
+	if s.KubeadmConfigSpec.Format == bootstrapv1.Windows {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix.Child("kubeadmConfigSpec", "format"),
+				"cannot be windows, Windows machines can only join the cluster as worker nodes",
+			),
+		)
+	}
+
 	if !version.KubeSemver.MatchString(s.Version) {
 		allErrs = append(allErrs, field.Invalid(pathPrefix.Child("version"), s.Version, "must be a valid semantic version"))
 	}
 
+	allErrs = append(allErrs, validateRolloutBefore(s.RolloutBefore, pathPrefix.Child("rolloutBefore"))...)
 	allErrs = append(allErrs, validateRolloutStrategy(s.RolloutStrategy, s.Replicas, pathPrefix.Child("rolloutStrategy"))...)
 
 	return allErrs
 }
 
+func validateRolloutBefore(rolloutBefore *RolloutBefore, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rolloutBefore == nil {
+		return allErrs
+	}
+
+	if rolloutBefore.CertificatesExpiryDays != nil && *rolloutBefore.CertificatesExpiryDays < 7 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("certificatesExpiryDays"),
+				*rolloutBefore.CertificatesExpiryDays,
+				"must be greater than or equal to 7",
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func validateRolloutStrategy(rolloutStrategy *RolloutStrategy, replicas *int32, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -143,9 +143,19 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	validIgnitionConfiguration.Spec.KubeadmConfigSpec.Format = bootstrapv1.Ignition
 	validIgnitionConfiguration.Spec.KubeadmConfigSpec.Ignition = &bootstrapv1.IgnitionSpec{}
 
+	windowsFormat := valid.DeepCopy()
+	windowsFormat.Spec.KubeadmConfigSpec.Format = bootstrapv1.Windows
+
+	validCertificatesExpiryDays := valid.DeepCopy()
+	validCertificatesExpiryDays.Spec.RolloutBefore = &RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(21)}
+
+	invalidCertificatesExpiryDays := valid.DeepCopy()
+	invalidCertificatesExpiryDays.Spec.RolloutBefore = &RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(5)}
+
 	tests := []struct {
 		name                  string
 		enableIgnitionFeature bool
+		enableWindowsFeature  bool
 		expectErr             bool
 		kcp                   *KubeadmControlPlane
 	}{
@@ -216,6 +226,22 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr:             false,
 			kcp:                   validIgnitionConfiguration,
 		},
+		{
+			name:                 "should return error when format is windows",
+			enableWindowsFeature: true,
+			expectErr:            true,
+			kcp:                  windowsFormat,
+		},
+		{
+			name:      "should succeed when certificatesExpiryDays is at least 7",
+			expectErr: false,
+			kcp:       validCertificatesExpiryDays,
+		},
+		{
+			name:      "should return error when certificatesExpiryDays is less than 7",
+			expectErr: true,
+			kcp:       invalidCertificatesExpiryDays,
+		},
 	}
 
 	for _, tt := range tests {
@@ -225,6 +251,11 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 				// Enabling the feature flag temporarily for this test.
 				defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatIgnition, true)()
 			}
+			if tt.enableWindowsFeature {
+				// NOTE: KubeadmBootstrapFormatWindows feature flag is disabled by default.
+				// Enabling the feature flag temporarily for this test.
+				defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatWindows, true)()
+			}
 
 			g := NewWithT(t)
 
@@ -382,6 +413,7 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	validUpdate.Spec.Replicas = pointer.Int32Ptr(5)
 	now := metav1.NewTime(time.Now())
 	validUpdate.Spec.RolloutAfter = &now
+	validUpdate.Spec.RolloutBefore = &RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(14)}
 	validUpdate.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
 
 	scaleToZero := before.DeepCopy()
@@ -611,6 +643,25 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	validIgnitionConfigurationAfter := validIgnitionConfigurationBefore.DeepCopy()
 	validIgnitionConfigurationAfter.Spec.KubeadmConfigSpec.Ignition.ContainerLinuxConfig.AdditionalConfig = "foo: bar"
 
+	updateBootstrapData := before.DeepCopy()
+	updateBootstrapData.Spec.KubeadmConfigSpec.BootstrapData = &bootstrapv1.BootstrapDataSpec{
+		Compression: bootstrapv1.GzipBootstrapDataCompression,
+		MaxSize:     pointer.Int32(16 * 1024),
+	}
+
+	updateContainerd := before.DeepCopy()
+	updateContainerd.Spec.KubeadmConfigSpec.Containerd = &bootstrapv1.ContainerdConfig{
+		SandboxImage: "registry.k8s.io/pause:3.6",
+		RegistryMirrors: []bootstrapv1.ContainerdRegistryMirror{
+			{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}},
+		},
+	}
+
+	updateSystemdUnits := before.DeepCopy()
+	updateSystemdUnits.Spec.KubeadmConfigSpec.SystemdUnits = []bootstrapv1.SystemdUnit{
+		{Name: "my-agent.service", Enabled: pointer.Bool(true), Content: "[Service]"},
+	}
+
 	updateInitConfigurationPatches := before.DeepCopy()
 	updateInitConfigurationPatches.Spec.KubeadmConfigSpec.InitConfiguration.Patches = &bootstrapv1.Patches{
 		Directory: "/tmp/patches",
@@ -959,6 +1010,24 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:                validIgnitionConfigurationBefore,
 			kcp:                   validIgnitionConfigurationAfter,
 		},
+		{
+			name:      "should succeed when bootstrap data configuration is modified",
+			expectErr: false,
+			before:    before,
+			kcp:       updateBootstrapData,
+		},
+		{
+			name:      "should succeed when containerd configuration is modified",
+			expectErr: false,
+			before:    before,
+			kcp:       updateContainerd,
+		},
+		{
+			name:      "should succeed when systemd units are modified",
+			expectErr: false,
+			before:    before,
+			kcp:       updateSystemdUnits,
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,6 +18,7 @@ package internal
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -121,6 +122,36 @@ func TestHasUnhealthyMachine(t *testing.T) {
 	g.Expect(c.HasUnhealthyMachine()).To(BeTrue())
 }
 
+func TestMachinesNeedingRollout(t *testing.T) {
+	reconciliationTime := metav1.Now()
+	expiringSoon := metav1.NewTime(reconciliationTime.Add(5 * 24 * time.Hour))
+	expiringLater := metav1.NewTime(reconciliationTime.Add(50 * 24 * time.Hour))
+
+	machineExpiringSoon := machine("machine-1", withVersion("v1.24.0"), withCertificatesExpiryDate(expiringSoon))
+	machineExpiringLater := machine("machine-2", withVersion("v1.24.0"), withCertificatesExpiryDate(expiringLater))
+
+	c := ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.24.0",
+				RolloutBefore: &controlplanev1.RolloutBefore{
+					CertificatesExpiryDays: pointer.Int32(21),
+				},
+			},
+		},
+		Machines:           collections.FromMachines(machineExpiringSoon, machineExpiringLater),
+		reconciliationTime: reconciliationTime,
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.MachinesNeedingRollout().Names()).To(ConsistOf("machine-1"))
+	g.Expect(c.UpToDateMachines().Names()).To(ConsistOf("machine-2"))
+
+	// Without RolloutBefore, no machine needs rollout.
+	c.KCP.Spec.RolloutBefore = nil
+	g.Expect(c.MachinesNeedingRollout()).To(BeEmpty())
+}
+
 type machineOpt func(*clusterv1.Machine)
 
 func failureDomain(controlPlane bool) clusterv1.FailureDomainSpec {
@@ -135,6 +166,18 @@ func withFailureDomain(fd string) machineOpt {
 	}
 }
 
+func withVersion(version string) machineOpt {
+	return func(m *clusterv1.Machine) {
+		m.Spec.Version = &version
+	}
+}
+
+func withCertificatesExpiryDate(expiryDate metav1.Time) machineOpt {
+	return func(m *clusterv1.Machine) {
+		m.Status.CertificatesExpiryDate = &expiryDate
+	}
+}
+
 func machine(name string, opts ...machineOpt) *clusterv1.Machine {
 	m := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
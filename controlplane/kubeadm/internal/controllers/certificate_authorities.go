@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// certificateAuthoritiesRotationRequeueAfter is how long to wait before checking again if the machines of the
+	// MachineDeployments have been rolled out during a rotation of the certificate authorities.
+	certificateAuthoritiesRotationRequeueAfter = 20 * time.Second
+)
+
+// reconcileCertificateAuthoritiesRotation rotates the certificate authorities and the service account keys of the
+// cluster when the rotate-certificate-authorities annotation is set on the KubeadmControlPlane with a new value.
+//
+// The rotation goes through the following phases; in each phase the secrets storing the certificate authorities are
+// updated, and then all the control plane machines and the machines of the MachineDeployments are rolled out, so
+// they pick up the updated secrets:
+//   - TrustNewCertificateAuthorities: new certificate authorities are added to the trust bundles.
+//   - SignWithNewCertificateAuthorities: the new certificate authorities are used for signing.
+//   - RemoveOldCertificateAuthorities: the old certificate authorities are removed from the trust bundles.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthoritiesRotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "cluster", controlPlane.Cluster.Name)
+	kcp := controlPlane.KCP
+
+	// Start a new rotation if the annotation is set with a value that has not been used yet.
+	trigger := kcp.GetAnnotations()[controlplanev1.RotateCertificateAuthoritiesAnnotation]
+	rotation := kcp.Status.CertificateAuthoritiesRotation
+	if trigger != "" && (rotation == nil || (rotation.Phase == controlplanev1.CertificateAuthoritiesRotationCompletedPhase && rotation.Trigger != trigger)) {
+		// Wait for the control plane to be initialized, so there are certificate authorities to rotate.
+		if !kcp.Status.Initialized {
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("Starting rotation of the certificate authorities", "trigger", trigger)
+		kcp.Status.CertificateAuthoritiesRotation = &controlplanev1.CertificateAuthoritiesRotationStatus{Trigger: trigger}
+		setCertificateAuthoritiesRotationPhase(kcp, controlplanev1.TrustNewCertificateAuthoritiesPhase)
+		return ctrl.Result{}, r.reconcileCertificateAuthoritiesSecrets(ctx, controlPlane)
+	}
+
+	if rotation == nil || rotation.Phase == controlplanev1.CertificateAuthoritiesRotationCompletedPhase {
+		return ctrl.Result{}, nil
+	}
+
+	// Make sure the secrets are up to date with the current phase.
+	if err := r.reconcileCertificateAuthoritiesSecrets(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Wait for all the control plane machines to be rolled out.
+	// NOTE: The rollout is performed as part of the usual rollout of machines needing it, because machines created
+	// before the current phase of the rotation are not considered up to date.
+	if replicas := int(*kcp.Spec.Replicas); len(controlPlane.Machines) != replicas || len(controlPlane.UpToDateMachines()) != replicas {
+		log.Info("Waiting for control plane machines to be rolled out for the rotation of the certificate authorities", "phase", rotation.Phase)
+		return ctrl.Result{}, nil
+	}
+
+	// Roll out the machines of the MachineDeployments, and wait for the rollout to complete.
+	rolledOut, err := r.rolloutMachineDeploymentsForCertificateAuthoritiesRotation(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !rolledOut {
+		log.Info("Waiting for MachineDeployments to be rolled out for the rotation of the certificate authorities", "phase", rotation.Phase)
+		return ctrl.Result{RequeueAfter: certificateAuthoritiesRotationRequeueAfter}, nil
+	}
+
+	switch rotation.Phase {
+	case controlplanev1.TrustNewCertificateAuthoritiesPhase:
+		setCertificateAuthoritiesRotationPhase(kcp, controlplanev1.SignWithNewCertificateAuthoritiesPhase)
+	case controlplanev1.SignWithNewCertificateAuthoritiesPhase:
+		setCertificateAuthoritiesRotationPhase(kcp, controlplanev1.RemoveOldCertificateAuthoritiesPhase)
+	case controlplanev1.RemoveOldCertificateAuthoritiesPhase:
+		setCertificateAuthoritiesRotationPhase(kcp, controlplanev1.CertificateAuthoritiesRotationCompletedPhase)
+		log.Info("Rotation of the certificate authorities completed", "trigger", rotation.Trigger)
+		return ctrl.Result{}, nil
+	}
+	log.Info("Rotation of the certificate authorities moved to the next phase", "phase", rotation.Phase)
+
+	// Update the secrets before any machine is created for the new phase.
+	return ctrl.Result{}, r.reconcileCertificateAuthoritiesSecrets(ctx, controlPlane)
+}
+
+func setCertificateAuthoritiesRotationPhase(kcp *controlplanev1.KubeadmControlPlane, phase controlplanev1.CertificateAuthoritiesRotationPhase) {
+	now := metav1.Now()
+	kcp.Status.CertificateAuthoritiesRotation.Phase = phase
+	kcp.Status.CertificateAuthoritiesRotation.LastPhaseTransitionTime = &now
+}
+
+// reconcileCertificateAuthoritiesSecrets updates the secrets storing the certificate authorities and the service
+// account keys according to the current phase of the rotation, and then publishes the cluster certificate
+// authorities in the cluster-info ConfigMap used by the nodes joining the cluster.
+// NOTE: Secrets not generated by the KubeadmControlPlane, e.g. user-provided certificate authorities or
+// certificate authorities of an external etcd, are not rotated.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthoritiesSecrets(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	log := ctrl.LoggerFrom(ctx, "cluster", controlPlane.Cluster.Name)
+	kcp := controlPlane.KCP
+	phase := kcp.Status.CertificateAuthoritiesRotation.Phase
+
+	clusterConfiguration := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if clusterConfiguration == nil {
+		clusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+	}
+
+	var clusterCAData []byte
+	for _, certificate := range secret.NewCertificatesForInitialControlPlane(clusterConfiguration) {
+		if certificate.External {
+			continue
+		}
+
+		s, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(controlPlane.Cluster), certificate.Purpose)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the %s secret", certificate.Purpose)
+		}
+		if !util.IsControlledBy(s, kcp) {
+			log.V(4).Info("Skipping rotation of a secret not generated by the KubeadmControlPlane", "secret", s.Name)
+			continue
+		}
+
+		if err := r.rotateCertificateAuthoritySecret(ctx, s, certificate.Purpose, phase); err != nil {
+			return err
+		}
+		if certificate.Purpose == secret.ClusterCA {
+			clusterCAData = s.Data[secret.TLSCrtDataName]
+		}
+	}
+
+	if clusterCAData == nil {
+		return nil
+	}
+	workloadCluster, err := r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+	if err != nil {
+		return errors.Wrap(err, "cannot get remote client to workload cluster")
+	}
+	return workloadCluster.UpdateClusterInfoCertificateAuthorityData(ctx, clusterCAData)
+}
+
+func (r *KubeadmControlPlaneReconciler) rotateCertificateAuthoritySecret(ctx context.Context, s *corev1.Secret, purpose secret.Purpose, phase controlplanev1.CertificateAuthoritiesRotationPhase) error {
+	original := s.DeepCopy()
+
+	var err error
+	switch phase {
+	case controlplanev1.TrustNewCertificateAuthoritiesPhase:
+		err = secret.AddNextCertificateAuthority(s, purpose)
+	case controlplanev1.SignWithNewCertificateAuthoritiesPhase:
+		err = secret.SwitchToNextCertificateAuthority(s)
+	case controlplanev1.RemoveOldCertificateAuthoritiesPhase:
+		err = secret.RemovePreviousCertificateAuthorities(s)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to rotate the %s secret", s.Name)
+	}
+
+	if reflect.DeepEqual(original.Data, s.Data) {
+		return nil
+	}
+	if err := r.Client.Update(ctx, s); err != nil {
+		return errors.Wrapf(err, "failed to update the %s secret", s.Name)
+	}
+	return nil
+}
+
+// rolloutMachineDeploymentsForCertificateAuthoritiesRotation sets the certificate-authorities-rotation annotation in
+// the machine template of the MachineDeployments of the cluster, thus triggering a rollout of their machines, and
+// returns true when all the MachineDeployments have been rolled out.
+// NOTE: Machines of MachinePools are not rolled out; they have to be replaced by the users.
+func (r *KubeadmControlPlaneReconciler) rolloutMachineDeploymentsForCertificateAuthoritiesRotation(ctx context.Context, controlPlane *internal.ControlPlane) (bool, error) {
+	value := internal.CertificateAuthoritiesRotationAnnotationValue(controlPlane.KCP)
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments,
+		client.InNamespace(controlPlane.Cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: controlPlane.Cluster.Name},
+	); err != nil {
+		return false, errors.Wrap(err, "failed to list MachineDeployments")
+	}
+
+	rolledOut := true
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.Spec.Template.Annotations[controlplanev1.CertificateAuthoritiesRotationAnnotation] != value {
+			patchHelper, err := patch.NewHelper(md, r.Client)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to create patch helper for MachineDeployment %s", md.Name)
+			}
+			if md.Spec.Template.Annotations == nil {
+				md.Spec.Template.Annotations = map[string]string{}
+			}
+			md.Spec.Template.Annotations[controlplanev1.CertificateAuthoritiesRotationAnnotation] = value
+			if err := patchHelper.Patch(ctx, md); err != nil {
+				return false, errors.Wrapf(err, "failed to patch MachineDeployment %s", md.Name)
+			}
+			rolledOut = false
+			continue
+		}
+
+		if !isMachineDeploymentRolledOut(md) {
+			rolledOut = false
+		}
+	}
+	return rolledOut, nil
+}
+
+// isMachineDeploymentRolledOut returns true if all the machines of a MachineDeployment have been created from the
+// current machine template.
+func isMachineDeploymentRolledOut(md *clusterv1.MachineDeployment) bool {
+	if md.Status.ObservedGeneration < md.Generation {
+		return false
+	}
+	if md.Spec.Replicas != nil && md.Status.Replicas != *md.Spec.Replicas {
+		return false
+	}
+	return md.Status.UpdatedReplicas == md.Status.Replicas
+}
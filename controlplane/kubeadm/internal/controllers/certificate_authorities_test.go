@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/pem"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilpointer "k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcileCertificateAuthoritiesRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: cluster.Namespace,
+			UID:       "kcp-uid",
+			Annotations: map[string]string{
+				controlplanev1.RotateCertificateAuthoritiesAnnotation: "1",
+			},
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: utilpointer.Int32(1),
+			Version:  "v1.24.0",
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{Initialized: true},
+	}
+
+	objs := []client.Object{}
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(certificates.Generate()).To(Succeed())
+	for _, certificate := range certificates {
+		objs = append(objs, certificate.AsSecret(client.ObjectKeyFromObject(cluster), *metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane"))))
+	}
+
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "md",
+			Namespace:  cluster.Namespace,
+			Labels:     map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+			Generation: 1,
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: cluster.Name,
+			Replicas:    utilpointer.Int32(1),
+		},
+	}
+	objs = append(objs, md)
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			Version: utilpointer.String("v1.24.0"),
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machine-infra",
+			},
+		},
+	}
+
+	fakeClient := newFakeClient(objs...)
+	r := &KubeadmControlPlaneReconciler{
+		Client:            fakeClient,
+		managementCluster: &fakeManagementCluster{Workload: fakeWorkloadCluster{}},
+	}
+	reconcile := func() ctrl.Result {
+		controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.FromMachines(machine))
+		g.Expect(err).ToNot(HaveOccurred())
+		result, err := r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+	getSecret := func(purpose secret.Purpose) *corev1.Secret {
+		s, err := secret.GetFromNamespacedName(ctx, fakeClient, client.ObjectKeyFromObject(cluster), purpose)
+		g.Expect(err).ToNot(HaveOccurred())
+		return s
+	}
+
+	// The rotation starts by adding the new certificate authorities to the trust bundles.
+	g.Expect(reconcile()).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation).ToNot(BeNil())
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Trigger).To(Equal("1"))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesPhase))
+	for _, purpose := range []secret.Purpose{secret.ClusterCA, secret.EtcdCA, secret.FrontProxyCA, secret.ServiceAccount} {
+		s := getSecret(purpose)
+		g.Expect(countPEMBlocks(s.Data[secret.TLSCrtDataName])).To(Equal(2))
+		g.Expect(s.Data).To(HaveKey(secret.TLSNextKeyDataName))
+	}
+	nextKey := getSecret(secret.ClusterCA).Data[secret.TLSNextKeyDataName]
+
+	// The rotation waits for control plane machines created before the current phase to be rolled out.
+	g.Expect(internal.MatchesCertificateAuthoritiesRotation(kcp)(machine)).To(BeFalse())
+	g.Expect(reconcile()).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesPhase))
+
+	// Once the control plane is rolled out, the MachineDeployments are rolled out.
+	machine.SetAnnotations(map[string]string{controlplanev1.CertificateAuthoritiesRotationAnnotation: internal.CertificateAuthoritiesRotationAnnotationValue(kcp)})
+	g.Expect(reconcile()).To(Equal(ctrl.Result{RequeueAfter: certificateAuthoritiesRotationRequeueAfter}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesPhase))
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+	g.Expect(md.Spec.Template.Annotations).To(HaveKeyWithValue(controlplanev1.CertificateAuthoritiesRotationAnnotation, "1/TrustNewCertificateAuthorities"))
+
+	// Once the MachineDeployments are rolled out, the new certificate authorities are used for signing.
+	md.Status = clusterv1.MachineDeploymentStatus{ObservedGeneration: md.Generation, Replicas: 1, UpdatedReplicas: 1}
+	g.Expect(fakeClient.Status().Update(ctx, md)).To(Succeed())
+	g.Expect(reconcile()).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.SignWithNewCertificateAuthoritiesPhase))
+	clusterCA := getSecret(secret.ClusterCA)
+	g.Expect(clusterCA.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+	g.Expect(clusterCA.Data).ToNot(HaveKey(secret.TLSNextKeyDataName))
+	g.Expect(countPEMBlocks(clusterCA.Data[secret.TLSCrtDataName])).To(Equal(2))
+
+	// Control plane machines created in the previous phase must be rolled out again.
+	g.Expect(internal.MatchesCertificateAuthoritiesRotation(kcp)(machine)).To(BeFalse())
+
+	// The rotation completes by removing the old certificate authorities from the trust bundles.
+	kcp.Status.CertificateAuthoritiesRotation.Phase = controlplanev1.RemoveOldCertificateAuthoritiesPhase
+	machine.SetAnnotations(map[string]string{controlplanev1.CertificateAuthoritiesRotationAnnotation: internal.CertificateAuthoritiesRotationAnnotationValue(kcp)})
+	md.Spec.Template.Annotations[controlplanev1.CertificateAuthoritiesRotationAnnotation] = internal.CertificateAuthoritiesRotationAnnotationValue(kcp)
+	g.Expect(fakeClient.Update(ctx, md)).To(Succeed())
+	g.Expect(reconcile()).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationCompletedPhase))
+	g.Expect(countPEMBlocks(getSecret(secret.ClusterCA).Data[secret.TLSCrtDataName])).To(Equal(1))
+
+	// A completed rotation is not started again until the annotation is set with a new value.
+	g.Expect(reconcile()).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationCompletedPhase))
+
+	kcp.Annotations[controlplanev1.RotateCertificateAuthoritiesAnnotation] = "2"
+	g.Expect(reconcile()).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Trigger).To(Equal("2"))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesPhase))
+}
+
+func TestReconcileCertificateAuthoritiesRotationSkipsUserProvidedSecrets(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: cluster.Namespace,
+			UID:       "kcp-uid",
+			Annotations: map[string]string{
+				controlplanev1.RotateCertificateAuthoritiesAnnotation: "1",
+			},
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: utilpointer.Int32(1),
+			Version:  "v1.24.0",
+		},
+		Status: controlplanev1.KubeadmControlPlaneStatus{Initialized: true},
+	}
+
+	objs := []client.Object{}
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(certificates.Generate()).To(Succeed())
+	for _, certificate := range certificates {
+		// User-provided secrets are not owned by the KubeadmControlPlane.
+		certificate.Generated = false
+		objs = append(objs, certificate.AsSecret(client.ObjectKeyFromObject(cluster), metav1.OwnerReference{}))
+	}
+
+	fakeClient := newFakeClient(objs...)
+	r := &KubeadmControlPlaneReconciler{
+		Client:            fakeClient,
+		managementCluster: &fakeManagementCluster{Workload: fakeWorkloadCluster{}},
+	}
+	controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.Machines{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesPhase))
+
+	s, err := secret.GetFromNamespacedName(ctx, fakeClient, client.ObjectKeyFromObject(cluster), secret.ClusterCA)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(countPEMBlocks(s.Data[secret.TLSCrtDataName])).To(Equal(1))
+	g.Expect(s.Data).ToNot(HaveKey(secret.TLSNextKeyDataName))
+}
+
+func countPEMBlocks(data []byte) int {
+	count := 0
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return count
+		}
+		count++
+	}
+}
@@ -57,6 +57,7 @@ import (
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
@@ -325,12 +326,24 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return result, err
 	}
 
+	// Reconcile certificate expiry for machines that don't have the expiry annotation on KubeadmConfig yet.
+	if result, err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Reconcile unhealthy machines by triggering deletion and requeue if it is considered safe to remediate,
 	// otherwise continue with the other KCP operations.
 	if result, err := r.reconcileUnhealthyMachines(ctx, controlPlane); err != nil || !result.IsZero() {
 		return result, err
 	}
 
+	// Reconcile the rotation of the certificate authorities, if any; the rotation relies on the rollout of the control
+	// plane machines below, so the result of this step is returned only at the end of the reconcile.
+	rotationResult, err := r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Control plane machines rollout due to configuration changes (e.g. upgrades) takes precedence over other operations.
 	needRollout := controlPlane.MachinesNeedingRollout()
 	switch {
@@ -400,7 +413,7 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return ctrl.Result{}, errors.Wrap(err, "failed to update CoreDNS deployment")
 	}
 
-	return ctrl.Result{}, nil
+	return rotationResult, nil
 }
 
 // reconcileDelete handles KubeadmControlPlane deletion.
@@ -576,6 +589,78 @@ func (r *KubeadmControlPlaneReconciler) reconcileEtcdMembers(ctx context.Context
 	return ctrl.Result{}, nil
 }
 
+// reconcileCertificateExpiries reads the expiry date of the certificates of each control plane machine from the
+// workload cluster and stores it as an annotation on the KubeadmConfig of the machine; the Machine controller
+// surfaces it in the Machine status, which is used to roll out machines before the certificates expire.
+// NOTE: The expiry date is read only once per machine; deleting the annotation forces KCP to read it again.
+// Machines with an unhealthy kube-apiserver, or whose certificates can't be read, are skipped until the next reconcile.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateExpiries(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "cluster", controlPlane.Cluster.Name)
+
+	// If the control plane is not initialized yet, there is no kube-apiserver to read the certificates from.
+	if !controlPlane.KCP.Status.Initialized {
+		return ctrl.Result{}, nil
+	}
+
+	var workloadCluster internal.WorkloadCluster
+	for _, machine := range controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp)) {
+		// Skip machines without a node yet.
+		if machine.Status.NodeRef == nil {
+			continue
+		}
+
+		kubeadmConfig, ok := controlPlane.GetKubeadmConfig(machine.Name)
+		if !ok {
+			continue
+		}
+
+		// Skip machines for which the expiry date is already known.
+		if _, ok := kubeadmConfig.GetAnnotations()[clusterv1.MachineCertificatesExpiryDateAnnotation]; ok {
+			continue
+		}
+
+		// Skip machines whose kube-apiserver is not healthy, because the certificates can't be read from it.
+		if !conditions.IsTrue(machine, controlplanev1.MachineAPIServerPodHealthyCondition) {
+			continue
+		}
+
+		if workloadCluster == nil {
+			var err error
+			workloadCluster, err = r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+			if err != nil {
+				return ctrl.Result{}, errors.Wrap(err, "cannot get remote client to workload cluster")
+			}
+		}
+
+		nodeName := machine.Status.NodeRef.Name
+		certificateExpiry, err := workloadCluster.GetAPIServerCertificateExpiry(ctx, kubeadmConfig, nodeName)
+		if err != nil {
+			// Failing to read the certificates of a single machine must not block the reconcile of the control plane;
+			// the expiry date is read again at the next reconcile.
+			log.Error(err, "Failed to get certificates expiry", "machine", machine.Name)
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(kubeadmConfig, r.Client)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to create patch helper for KubeadmConfig %s", kubeadmConfig.Name)
+		}
+		annotations := kubeadmConfig.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[clusterv1.MachineCertificatesExpiryDateAnnotation] = certificateExpiry.Format(time.RFC3339)
+		kubeadmConfig.SetAnnotations(annotations)
+
+		log.V(2).Info("Setting certificates expiry on KubeadmConfig", "machine", machine.Name, "expiry", annotations[clusterv1.MachineCertificatesExpiryDateAnnotation])
+		if err := patchHelper.Patch(ctx, kubeadmConfig); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to patch KubeadmConfig %s", kubeadmConfig.Name)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func (r *KubeadmControlPlaneReconciler) adoptMachines(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, machines collections.Machines, cluster *clusterv1.Cluster) error {
 	// We do an uncached full quorum read against the KCP to avoid re-adopting Machines the garbage collector just intentionally orphaned
 	// See https://github.com/kubernetes/kubernetes/issues/42639
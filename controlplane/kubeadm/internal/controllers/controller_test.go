@@ -25,6 +25,7 @@ import (
 
 	"github.com/blang/semver"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -453,6 +454,241 @@ func TestReconcileClusterNoEndpoints(t *testing.T) {
 	g.Expect(machineList.Items).To(BeEmpty())
 }
 
+func TestReconcileCertificateExpiries(t *testing.T) {
+	g := NewWithT(t)
+
+	preExistingExpiry := time.Now().Add(5 * 24 * time.Hour)
+	detectedExpiry := time.Now().Add(25 * 24 * time.Hour)
+
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Status: controlplanev1.KubeadmControlPlaneStatus{Initialized: true},
+	}
+
+	machineWithoutExpiryAnnotationKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutExpiryAnnotation-config",
+			Namespace: cluster.Namespace,
+		},
+	}
+	machineWithoutExpiryAnnotation := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutExpiryAnnotation",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithoutExpiryAnnotation-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       machineWithoutExpiryAnnotationKubeadmConfig.Name,
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithoutExpiryAnnotation",
+			},
+			Conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(controlplanev1.MachineAPIServerPodHealthyCondition),
+			},
+		},
+	}
+
+	machineWithExpiryAnnotationKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithExpiryAnnotation-config",
+			Namespace: cluster.Namespace,
+			Annotations: map[string]string{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: preExistingExpiry.Format(time.RFC3339),
+			},
+		},
+	}
+	machineWithExpiryAnnotation := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithExpiryAnnotation",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithExpiryAnnotation-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       machineWithExpiryAnnotationKubeadmConfig.Name,
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithExpiryAnnotation",
+			},
+			Conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(controlplanev1.MachineAPIServerPodHealthyCondition),
+			},
+		},
+	}
+
+	machineWithoutNodeRefKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutNodeRef-config",
+			Namespace: cluster.Namespace,
+		},
+	}
+	machineWithoutNodeRef := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutNodeRef",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithoutNodeRef-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       machineWithoutNodeRefKubeadmConfig.Name,
+				},
+			},
+		},
+	}
+
+	machineWithUnhealthyAPIServerKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithUnhealthyAPIServer-config",
+			Namespace: cluster.Namespace,
+		},
+	}
+	machineWithUnhealthyAPIServer := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithUnhealthyAPIServer",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithUnhealthyAPIServer-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       machineWithUnhealthyAPIServerKubeadmConfig.Name,
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithUnhealthyAPIServer",
+			},
+			Conditions: clusterv1.Conditions{
+				*conditions.FalseCondition(controlplanev1.MachineAPIServerPodHealthyCondition, controlplanev1.PodFailedReason, clusterv1.ConditionSeverityError, ""),
+			},
+		},
+	}
+
+	machineWithUnreachableNodeKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithUnreachableNode-config",
+			Namespace: cluster.Namespace,
+		},
+	}
+	machineWithUnreachableNode := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithUnreachableNode",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithUnreachableNode-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       machineWithUnreachableNodeKubeadmConfig.Name,
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithUnreachableNode",
+			},
+			Conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(controlplanev1.MachineAPIServerPodHealthyCondition),
+			},
+		},
+	}
+
+	ownedMachines := collections.FromMachines(machineWithoutExpiryAnnotation, machineWithExpiryAnnotation, machineWithoutNodeRef, machineWithUnhealthyAPIServer, machineWithUnreachableNode)
+
+	fakeClient := newFakeClient(
+		machineWithoutExpiryAnnotationKubeadmConfig,
+		machineWithExpiryAnnotationKubeadmConfig,
+		machineWithoutNodeRefKubeadmConfig,
+		machineWithUnhealthyAPIServerKubeadmConfig,
+		machineWithUnreachableNodeKubeadmConfig,
+	)
+
+	controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, ownedMachines)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &KubeadmControlPlaneReconciler{
+		Client: fakeClient,
+		managementCluster: &fakeManagementCluster{
+			Workload: fakeWorkloadCluster{
+				APIServerCertificateExpiry: &detectedExpiry,
+				APIServerCertificateExpiryErrors: map[string]error{
+					machineWithUnreachableNode.Status.NodeRef.Name: errors.New("failed to dial"),
+				},
+			},
+		},
+	}
+
+	_, err = r.reconcileCertificateExpiries(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Verify the annotation has been set for the machine without the expiry annotation.
+	actualKubeadmConfig := bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithoutExpiryAnnotationKubeadmConfig), &actualKubeadmConfig)).To(Succeed())
+	g.Expect(actualKubeadmConfig.Annotations).To(HaveKeyWithValue(clusterv1.MachineCertificatesExpiryDateAnnotation, detectedExpiry.Format(time.RFC3339)))
+
+	// Verify the annotation has not been changed for the machine with the expiry annotation.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithExpiryAnnotationKubeadmConfig), &actualKubeadmConfig)).To(Succeed())
+	g.Expect(actualKubeadmConfig.Annotations).To(HaveKeyWithValue(clusterv1.MachineCertificatesExpiryDateAnnotation, preExistingExpiry.Format(time.RFC3339)))
+
+	// Verify the annotation has not been set for the machine without a node.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithoutNodeRefKubeadmConfig), &actualKubeadmConfig)).To(Succeed())
+	g.Expect(actualKubeadmConfig.Annotations).ToNot(HaveKey(clusterv1.MachineCertificatesExpiryDateAnnotation))
+
+	// Verify the annotation has not been set for the machine with an unhealthy kube-apiserver.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithUnhealthyAPIServerKubeadmConfig), &actualKubeadmConfig)).To(Succeed())
+	g.Expect(actualKubeadmConfig.Annotations).ToNot(HaveKey(clusterv1.MachineCertificatesExpiryDateAnnotation))
+
+	// Verify the annotation has not been set for the machine whose certificates can't be read.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithUnreachableNodeKubeadmConfig), &actualKubeadmConfig)).To(Succeed())
+	g.Expect(actualKubeadmConfig.Annotations).ToNot(HaveKey(clusterv1.MachineCertificatesExpiryDateAnnotation))
+}
+
 func TestKubeadmControlPlaneReconciler_adoption(t *testing.T) {
 	version := "v2.0.0"
 	t.Run("adopts existing Machines", func(t *testing.T) {
@@ -18,11 +18,13 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/blang/semver"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/collections"
@@ -65,8 +67,11 @@ func (f *fakeManagementCluster) GetMachinePoolsForCluster(c context.Context, clu
 
 type fakeWorkloadCluster struct {
 	*internal.Workload
-	Status            internal.ClusterStatus
-	EtcdMembersResult []string
+	Status                     internal.ClusterStatus
+	EtcdMembersResult          []string
+	APIServerCertificateExpiry *time.Time
+	// APIServerCertificateExpiryErrors are the errors returned when reading the certificates expiry, by node name.
+	APIServerCertificateExpiryErrors map[string]error
 }
 
 func (f fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, _ *clusterv1.Machine) error {
@@ -77,6 +82,17 @@ func (f fakeWorkloadCluster) ReconcileEtcdMembers(ctx context.Context, nodeNames
 	return nil, nil
 }
 
+func (f fakeWorkloadCluster) GetAPIServerCertificateExpiry(_ context.Context, _ *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error) {
+	if err, ok := f.APIServerCertificateExpiryErrors[nodeName]; ok {
+		return nil, err
+	}
+	return f.APIServerCertificateExpiry, nil
+}
+
+func (f fakeWorkloadCluster) UpdateClusterInfoCertificateAuthorityData(_ context.Context, _ []byte) error {
+	return nil
+}
+
 func (f fakeWorkloadCluster) ClusterStatus(_ context.Context) (internal.ClusterStatus, error) {
 	return f.Status, nil
 }
@@ -91,6 +91,21 @@ func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	// Regenerate the kubeconfig secret when the cluster CA changes, e.g. during a rotation of the certificate authorities.
+	if !needsRotation {
+		caSecret, err := secret.GetFromNamespacedName(ctx, r.Client, clusterName, secret.ClusterCA)
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return ctrl.Result{}, errors.Wrap(err, "failed to retrieve cluster CA Secret")
+		default:
+			needsRotation, err = kubeconfig.NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
 	if needsRotation {
 		log.Info("rotating kubeconfig secret")
 		if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret); err != nil {
@@ -307,6 +322,12 @@ func (r *KubeadmControlPlaneReconciler) generateMachine(ctx context.Context, kcp
 	}
 	machine.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation] = string(clusterConfig)
 
+	// Machines created during a rotation of the certificate authorities record the phase of the rotation,
+	// so they are not rolled out again until the rotation moves to the next phase.
+	if value := internal.CertificateAuthoritiesRotationAnnotationValue(kcp); value != "" {
+		machine.Annotations[controlplanev1.CertificateAuthoritiesRotationAnnotation] = value
+	}
+
 	if err := r.Client.Create(ctx, machine); err != nil {
 		return errors.Wrap(err, "failed to create machine")
 	}
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	utilpointer "k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -321,6 +322,21 @@ func TestKubeadmControlPlaneReconciler_reconcileKubeconfig(t *testing.T) {
 	g.Expect(kubeconfigSecret.OwnerReferences).NotTo(BeEmpty())
 	g.Expect(kubeconfigSecret.OwnerReferences).To(ContainElement(*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane"))))
 	g.Expect(kubeconfigSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, cluster.Name))
+
+	// The kubeconfig is regenerated when a new certificate authority is added to the cluster CA secret.
+	caCertSecret := &corev1.Secret{}
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(existingCACertSecret), caCertSecret)).To(Succeed())
+	g.Expect(secret.AddNextCertificateAuthority(caCertSecret, secret.ClusterCA)).To(Succeed())
+	g.Expect(r.Client.Update(ctx, caCertSecret)).To(Succeed())
+
+	result, err = r.reconcileKubeconfig(ctx, cluster, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+
+	g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+	config, err := clientcmd.Load(kubeconfigSecret.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Clusters[cluster.Name].CertificateAuthorityData).To(Equal(caCertSecret.Data[secret.TLSCrtDataName]))
 }
 
 func TestCloneConfigsAndGenerateMachine(t *testing.T) {
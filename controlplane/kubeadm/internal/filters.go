@@ -18,6 +18,7 @@ package internal
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -39,9 +40,36 @@ func MatchesMachineSpec(infraConfigs map[string]*unstructured.Unstructured, mach
 		collections.MatchesKubernetesVersion(kcp.Spec.Version),
 		MatchesKubeadmBootstrapConfig(machineConfigs, kcp),
 		MatchesTemplateClonedFrom(infraConfigs, kcp),
+		MatchesCertificateAuthoritiesRotation(kcp),
 	)
 }
 
+// MatchesCertificateAuthoritiesRotation returns a filter to find all machines that have been created in the current
+// phase of the rotation of the certificate authorities, if a rotation is in progress.
+func MatchesCertificateAuthoritiesRotation(kcp *controlplanev1.KubeadmControlPlane) collections.Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		value := CertificateAuthoritiesRotationAnnotationValue(kcp)
+		if value == "" {
+			return true
+		}
+		return machine.GetAnnotations()[controlplanev1.CertificateAuthoritiesRotationAnnotation] == value
+	}
+}
+
+// CertificateAuthoritiesRotationAnnotationValue returns the value of the certificate-authorities-rotation annotation
+// for the machines created in the current phase of the rotation of the certificate authorities, or an empty string
+// if no rotation is in progress.
+func CertificateAuthoritiesRotationAnnotationValue(kcp *controlplanev1.KubeadmControlPlane) string {
+	rotation := kcp.Status.CertificateAuthoritiesRotation
+	if rotation == nil || rotation.Phase == controlplanev1.CertificateAuthoritiesRotationCompletedPhase {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", rotation.Trigger, rotation.Phase)
+}
+
 // MatchesTemplateClonedFrom returns a filter to find all machines that match a given KCP infra template.
 func MatchesTemplateClonedFrom(infraConfigs map[string]*unstructured.Unstructured, kcp *controlplanev1.KubeadmControlPlane) collections.Func {
 	return func(machine *clusterv1.Machine) bool {
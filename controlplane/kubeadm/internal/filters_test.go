@@ -1177,3 +1177,69 @@ func TestMatchesTemplateClonedFrom_WithClonedFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesCertificateAuthoritiesRotation(t *testing.T) {
+	rotating := &controlplanev1.KubeadmControlPlane{
+		Status: controlplanev1.KubeadmControlPlaneStatus{
+			CertificateAuthoritiesRotation: &controlplanev1.CertificateAuthoritiesRotationStatus{
+				Trigger: "1",
+				Phase:   controlplanev1.SignWithNewCertificateAuthoritiesPhase,
+			},
+		},
+	}
+	completed := rotating.DeepCopy()
+	completed.Status.CertificateAuthoritiesRotation.Phase = controlplanev1.CertificateAuthoritiesRotationCompletedPhase
+
+	machine := func(annotationValue string) *clusterv1.Machine {
+		m := &clusterv1.Machine{}
+		if annotationValue != "" {
+			m.SetAnnotations(map[string]string{controlplanev1.CertificateAuthoritiesRotationAnnotation: annotationValue})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name    string
+		kcp     *controlplanev1.KubeadmControlPlane
+		machine *clusterv1.Machine
+		want    bool
+	}{
+		{
+			name:    "matches if no rotation has been triggered",
+			kcp:     &controlplanev1.KubeadmControlPlane{},
+			machine: machine(""),
+			want:    true,
+		},
+		{
+			name:    "matches if the rotation is completed",
+			kcp:     completed,
+			machine: machine("1/TrustNewCertificateAuthorities"),
+			want:    true,
+		},
+		{
+			name:    "matches machines created in the current phase of the rotation",
+			kcp:     rotating,
+			machine: machine("1/SignWithNewCertificateAuthorities"),
+			want:    true,
+		},
+		{
+			name:    "does not match machines created in a previous phase of the rotation",
+			kcp:     rotating,
+			machine: machine("1/TrustNewCertificateAuthorities"),
+			want:    false,
+		},
+		{
+			name:    "does not match machines created before the rotation",
+			kcp:     rotating,
+			machine: machine(""),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(MatchesCertificateAuthoritiesRotation(tt.kcp)(tt.machine)).To(Equal(tt.want))
+		})
+	}
+}
@@ -37,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -45,6 +46,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
 	containerutil "sigs.k8s.io/cluster-api/util/container"
@@ -121,8 +123,10 @@ type WorkloadCluster interface {
 	RemoveEtcdMemberForMachine(ctx context.Context, machine *clusterv1.Machine) error
 	RemoveMachineFromKubeadmConfigMap(ctx context.Context, machine *clusterv1.Machine, version semver.Version) error
 	RemoveNodeFromKubeadmConfigMap(ctx context.Context, nodeName string, version semver.Version) error
+	GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error)
 	ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error
 	AllowBootstrapTokensToGetNodes(ctx context.Context) error
+	UpdateClusterInfoCertificateAuthorityData(ctx context.Context, caData []byte) error
 
 	// State recovery tasks.
 	ReconcileEtcdMembers(ctx context.Context, nodeNames []string, version semver.Version) ([]string, error)
@@ -133,6 +137,7 @@ type Workload struct {
 	Client              ctrlclient.Client
 	CoreDNSMigrator     coreDNSMigrator
 	etcdClientGenerator etcdClientFor
+	restConfig          *rest.Config
 }
 
 var _ WorkloadCluster = &Workload{}
@@ -478,6 +483,53 @@ func newClientCert(caCert *x509.Certificate, key *rsa.PrivateKey, caKey crypto.S
 	return c, errors.WithStack(err)
 }
 
+// GetAPIServerCertificateExpiry returns the expiry date of the certificate served by the kube-apiserver
+// running on the given node.
+func (w *Workload) GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error) {
+	// NOTE: The dialer modifies the rest config, so a copy is used.
+	dialer, err := proxy.NewDialer(proxy.Proxy{
+		Kind:       "pods",
+		Namespace:  metav1.NamespaceSystem,
+		KubeConfig: rest.CopyConfig(w.restConfig),
+		Port:       calculateAPIServerPort(kubeadmConfig),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create dialer for the kube-apiserver on Node %s", nodeName)
+	}
+
+	conn, err := dialer.DialContextWithAddr(ctx, staticPodName("kube-apiserver", nodeName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to the kube-apiserver on Node %s", nodeName)
+	}
+	defer conn.Close()
+
+	// NOTE: The certificate is only inspected, so it is not required to verify it.
+	tlsConn := tls.Client(conn, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to complete the TLS handshake with the kube-apiserver on Node %s", nodeName)
+	}
+
+	for _, cert := range tlsConn.ConnectionState().PeerCertificates {
+		if cert.Subject.CommonName == "kube-apiserver" {
+			return &cert.NotAfter, nil
+		}
+	}
+	return nil, errors.Errorf("failed to find the certificate of the kube-apiserver on Node %s", nodeName)
+}
+
+// calculateAPIServerPort returns the port the kube-apiserver is listening on, as defined in the KubeadmConfig.
+func calculateAPIServerPort(config *bootstrapv1.KubeadmConfig) int {
+	if config.Spec.InitConfiguration != nil && config.Spec.InitConfiguration.LocalAPIEndpoint.BindPort != 0 {
+		return int(config.Spec.InitConfiguration.LocalAPIEndpoint.BindPort)
+	}
+	if config.Spec.JoinConfiguration != nil &&
+		config.Spec.JoinConfiguration.ControlPlane != nil &&
+		config.Spec.JoinConfiguration.ControlPlane.LocalAPIEndpoint.BindPort != 0 {
+		return int(config.Spec.JoinConfiguration.ControlPlane.LocalAPIEndpoint.BindPort)
+	}
+	return 6443
+}
+
 func staticPodName(component, nodeName string) string {
 	return fmt.Sprintf("%s-%s", component, nodeName)
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	clusterInfoConfigMapName = "cluster-info"
+	clusterInfoKubeconfigKey = "kubeconfig"
+)
+
+// UpdateClusterInfoCertificateAuthorityData sets the certificate authority data in the cluster-info ConfigMap,
+// which is used by the nodes joining the cluster to discover the certificate authorities of the cluster.
+// NOTE: The JWS signatures in the ConfigMap are updated by the bootstrap signer in kube-controller-manager.
+func (w *Workload) UpdateClusterInfoCertificateAuthorityData(ctx context.Context, caData []byte) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		key := ctrlclient.ObjectKey{Namespace: metav1.NamespacePublic, Name: clusterInfoConfigMapName}
+		configMap, err := w.getConfigMap(ctx, key)
+		if err != nil {
+			return errors.Wrap(err, "failed to get cluster-info ConfigMap")
+		}
+
+		config, err := clientcmd.Load([]byte(configMap.Data[clusterInfoKubeconfigKey]))
+		if err != nil {
+			return errors.Wrapf(err, "unable to decode %q in the cluster-info ConfigMap", clusterInfoKubeconfigKey)
+		}
+
+		changed := false
+		for _, cluster := range config.Clusters {
+			if !bytes.Equal(cluster.CertificateAuthorityData, caData) {
+				cluster.CertificateAuthorityData = caData
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		updatedData, err := clientcmd.Write(*config)
+		if err != nil {
+			return errors.Wrapf(err, "unable to encode %q in the cluster-info ConfigMap", clusterInfoKubeconfigKey)
+		}
+		configMap.Data[clusterInfoKubeconfigKey] = string(updatedData)
+		if err := w.Client.Update(ctx, configMap); err != nil {
+			return errors.Wrap(err, "failed to update the cluster-info ConfigMap")
+		}
+		return nil
+	})
+}
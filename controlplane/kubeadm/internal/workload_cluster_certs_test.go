@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateClusterInfoCertificateAuthorityData(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterInfo := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespacePublic,
+			Name:      clusterInfoConfigMapName,
+		},
+		Data: map[string]string{
+			clusterInfoKubeconfigKey: `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: b2xkLWNh
+    server: https://test.local:6443
+  name: ""
+contexts: null
+current-context: ""
+kind: Config
+preferences: {}
+users: null
+`,
+			"jws-kubeconfig-abcdef": "signature",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(clusterInfo).Build()
+	w := &Workload{
+		Client: fakeClient,
+	}
+	g.Expect(w.UpdateClusterInfoCertificateAuthorityData(ctx, []byte("new-ca"))).To(Succeed())
+
+	actual := &corev1.ConfigMap{}
+	g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKeyFromObject(clusterInfo), actual)).To(Succeed())
+	config, err := clientcmd.Load([]byte(actual.Data[clusterInfoKubeconfigKey]))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Clusters).To(HaveKey(""))
+	g.Expect(config.Clusters[""].CertificateAuthorityData).To(Equal([]byte("new-ca")))
+	g.Expect(config.Clusters[""].Server).To(Equal("https://test.local:6443"))
+	g.Expect(actual.Data).To(HaveKeyWithValue("jws-kubeconfig-abcdef", "signature"))
+
+	// Updating with the same data is a no-op.
+	g.Expect(w.UpdateClusterInfoCertificateAuthorityData(ctx, []byte("new-ca"))).To(Succeed())
+	unchanged := &corev1.ConfigMap{}
+	g.Expect(fakeClient.Get(ctx, ctrlclient.ObjectKeyFromObject(clusterInfo), unchanged)).To(Succeed())
+	g.Expect(unchanged.ResourceVersion).To(Equal(actual.ResourceVersion))
+}
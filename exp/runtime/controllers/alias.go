@@ -35,6 +35,10 @@ type ExtensionConfigReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// ReadOnly makes the reconciler only sync the registry with the ExtensionConfigs, using the handlers
+	// discovered by the controller owning them.
+	ReadOnly bool
 }
 
 func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -43,5 +47,6 @@ func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ct
 		APIReader:        r.APIReader,
 		RuntimeClient:    r.RuntimeClient,
 		WatchFilterValue: r.WatchFilterValue,
+		ReadOnly:         r.ReadOnly,
 	}).SetupWithManager(ctx, mgr, options)
 }
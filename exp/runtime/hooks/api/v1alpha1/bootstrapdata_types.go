@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// GenerateBootstrapDataRequest is the request of the GenerateBootstrapData hook.
+// +kubebuilder:object:root=true
+type GenerateBootstrapDataRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Cluster is the cluster object the machine belongs to.
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// KubeadmConfigName is the name of the KubeadmConfig the bootstrap data is generated for.
+	// The KubeadmConfig is in the same namespace as the Cluster.
+	KubeadmConfigName string `json:"kubeadmConfigName"`
+
+	// ControlPlane is true if the bootstrap data is generated for a control plane machine.
+	ControlPlane bool `json:"controlPlane"`
+
+	// KubernetesVersion is the Kubernetes version of the machine.
+	KubernetesVersion string `json:"kubernetesVersion"`
+
+	// ClusterConfiguration is the kubeadm ClusterConfiguration, marshalled to YAML in the kubeadm API version
+	// corresponding to the Kubernetes version of the machine.
+	// It is set, together with InitConfiguration, only for the first control plane machine, which runs kubeadm init.
+	// +optional
+	ClusterConfiguration string `json:"clusterConfiguration,omitempty"`
+
+	// InitConfiguration is the kubeadm InitConfiguration, marshalled to YAML in the kubeadm API version
+	// corresponding to the Kubernetes version of the machine.
+	// +optional
+	InitConfiguration string `json:"initConfiguration,omitempty"`
+
+	// JoinConfiguration is the kubeadm JoinConfiguration, marshalled to YAML in the kubeadm API version
+	// corresponding to the Kubernetes version of the machine.
+	// It is set for all the machines joining the cluster, i.e. when InitConfiguration is not set.
+	// +optional
+	JoinConfiguration string `json:"joinConfiguration,omitempty"`
+
+	// Verbosity is the number for the log level verbosity of kubeadm.
+	// +optional
+	Verbosity *int32 `json:"verbosity,omitempty"`
+
+	// Files is the list of files to create on the machine, including the certificates of control plane machines.
+	// The content of the files is resolved from their sources and templates.
+	// +optional
+	Files []BootstrapFile `json:"files,omitempty"`
+
+	// Users is the list of users to create on the machine.
+	// The password of the users is resolved from its source.
+	// +optional
+	Users []BootstrapUser `json:"users,omitempty"`
+
+	// PreKubeadmCommands is the list of commands to run before kubeadm.
+	// +optional
+	PreKubeadmCommands []string `json:"preKubeadmCommands,omitempty"`
+
+	// PostKubeadmCommands is the list of commands to run after kubeadm.
+	// +optional
+	PostKubeadmCommands []string `json:"postKubeadmCommands,omitempty"`
+}
+
+// BootstrapFile defines a file to create on the machine.
+type BootstrapFile struct {
+	// Path is the full path where the file should be created.
+	Path string `json:"path"`
+
+	// Owner specifies the ownership of the file, e.g. "root:root".
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Permissions specifies the permissions to assign to the file, e.g. "0640".
+	// +optional
+	Permissions string `json:"permissions,omitempty"`
+
+	// Encoding specifies the encoding of the content: "base64", "gzip" or "gzip+base64".
+	// If empty, the content is plain text.
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
+	// Append specifies whether to append the content to an existing file.
+	// +optional
+	Append bool `json:"append,omitempty"`
+
+	// Content is the content of the file, encoded according to Encoding.
+	// +optional
+	Content string `json:"content,omitempty"`
+}
+
+// BootstrapUser defines a user to create on the machine.
+type BootstrapUser struct {
+	// Name specifies the username.
+	Name string `json:"name"`
+
+	// Gecos specifies the gecos to use for the user.
+	// +optional
+	Gecos *string `json:"gecos,omitempty"`
+
+	// Groups specifies the additional groups for the user, as a comma-separated list.
+	// +optional
+	Groups *string `json:"groups,omitempty"`
+
+	// HomeDir specifies the home directory to use for the user.
+	// +optional
+	HomeDir *string `json:"homeDir,omitempty"`
+
+	// Inactive specifies whether to mark the user as inactive.
+	// +optional
+	Inactive *bool `json:"inactive,omitempty"`
+
+	// Shell specifies the user's shell.
+	// +optional
+	Shell *string `json:"shell,omitempty"`
+
+	// Passwd specifies a hashed password for the user.
+	// +optional
+	Passwd *string `json:"passwd,omitempty"`
+
+	// PrimaryGroup specifies the primary group for the user.
+	// +optional
+	PrimaryGroup *string `json:"primaryGroup,omitempty"`
+
+	// LockPassword specifies if password login should be disabled.
+	// +optional
+	LockPassword *bool `json:"lockPassword,omitempty"`
+
+	// Sudo specifies a sudo role for the user.
+	// +optional
+	Sudo *string `json:"sudo,omitempty"`
+
+	// SSHAuthorizedKeys specifies a list of ssh authorized keys for the user.
+	// +optional
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+}
+
+var _ ResponseObject = &GenerateBootstrapDataResponse{}
+
+// GenerateBootstrapDataResponse is the response of the GenerateBootstrapData hook.
+// +kubebuilder:object:root=true
+type GenerateBootstrapDataResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+
+	// Data is the bootstrap data to store in the bootstrap data secret of the machine.
+	Data []byte `json:"data"`
+}
+
+// GenerateBootstrapData generates the bootstrap data of a machine for KubeadmConfigs using the external format.
+func GenerateBootstrapData(*GenerateBootstrapDataRequest, *GenerateBootstrapDataResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(GenerateBootstrapData, &runtimecatalog.HookMeta{
+		Tags:    []string{"Bootstrap Hook"},
+		Summary: "Cluster API Bootstrap Provider Kubeadm will call this hook to generate the bootstrap data of a machine",
+		Description: "Cluster API Bootstrap Provider Kubeadm will call this hook to generate the bootstrap data of a machine " +
+			"when the format of its KubeadmConfig is set to external.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The extension handler to call is defined in the KubeadmConfig's spec.external.generateExtension field\n" +
+			"- The call's request contains the kubeadm configuration, files and users of the machine, with defaults applied and sources resolved\n" +
+			"- The data returned in the response is stored as it is in the bootstrap data secret of the machine",
+	})
+}
@@ -325,6 +325,86 @@ func (in *BeforeClusterUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapFile) DeepCopyInto(out *BootstrapFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapFile.
+func (in *BootstrapFile) DeepCopy() *BootstrapFile {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapUser) DeepCopyInto(out *BootstrapUser) {
+	*out = *in
+	if in.Gecos != nil {
+		in, out := &in.Gecos, &out.Gecos
+		*out = new(string)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(string)
+		**out = **in
+	}
+	if in.HomeDir != nil {
+		in, out := &in.HomeDir, &out.HomeDir
+		*out = new(string)
+		**out = **in
+	}
+	if in.Inactive != nil {
+		in, out := &in.Inactive, &out.Inactive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Shell != nil {
+		in, out := &in.Shell, &out.Shell
+		*out = new(string)
+		**out = **in
+	}
+	if in.Passwd != nil {
+		in, out := &in.Passwd, &out.Passwd
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrimaryGroup != nil {
+		in, out := &in.PrimaryGroup, &out.PrimaryGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.LockPassword != nil {
+		in, out := &in.LockPassword, &out.LockPassword
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sudo != nil {
+		in, out := &in.Sudo, &out.Sudo
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSHAuthorizedKeys != nil {
+		in, out := &in.SSHAuthorizedKeys, &out.SSHAuthorizedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapUser.
+func (in *BootstrapUser) DeepCopy() *BootstrapUser {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonResponse) DeepCopyInto(out *CommonResponse) {
 	*out = *in
@@ -438,6 +518,88 @@ func (in *ExtensionHandler) DeepCopy() *ExtensionHandler {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateBootstrapDataRequest) DeepCopyInto(out *GenerateBootstrapDataRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	if in.Verbosity != nil {
+		in, out := &in.Verbosity, &out.Verbosity
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]BootstrapFile, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]BootstrapUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreKubeadmCommands != nil {
+		in, out := &in.PreKubeadmCommands, &out.PreKubeadmCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostKubeadmCommands != nil {
+		in, out := &in.PostKubeadmCommands, &out.PostKubeadmCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateBootstrapDataRequest.
+func (in *GenerateBootstrapDataRequest) DeepCopy() *GenerateBootstrapDataRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateBootstrapDataRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GenerateBootstrapDataRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateBootstrapDataResponse) DeepCopyInto(out *GenerateBootstrapDataResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonResponse = in.CommonResponse
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateBootstrapDataResponse.
+func (in *GenerateBootstrapDataResponse) DeepCopy() *GenerateBootstrapDataResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateBootstrapDataResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GenerateBootstrapDataResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratePatchesRequest) DeepCopyInto(out *GeneratePatchesRequest) {
 	*out = *in
@@ -42,11 +42,15 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BeforeClusterDeleteResponse":          schema_runtime_hooks_api_v1alpha1_BeforeClusterDeleteResponse(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BeforeClusterUpgradeRequest":          schema_runtime_hooks_api_v1alpha1_BeforeClusterUpgradeRequest(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BeforeClusterUpgradeResponse":         schema_runtime_hooks_api_v1alpha1_BeforeClusterUpgradeResponse(ref),
+		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BootstrapFile":                        schema_runtime_hooks_api_v1alpha1_BootstrapFile(ref),
+		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BootstrapUser":                        schema_runtime_hooks_api_v1alpha1_BootstrapUser(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.CommonResponse":                       schema_runtime_hooks_api_v1alpha1_CommonResponse(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.CommonRetryResponse":                  schema_runtime_hooks_api_v1alpha1_CommonRetryResponse(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.DiscoveryRequest":                     schema_runtime_hooks_api_v1alpha1_DiscoveryRequest(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.DiscoveryResponse":                    schema_runtime_hooks_api_v1alpha1_DiscoveryResponse(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.ExtensionHandler":                     schema_runtime_hooks_api_v1alpha1_ExtensionHandler(ref),
+		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.GenerateBootstrapDataRequest":         schema_runtime_hooks_api_v1alpha1_GenerateBootstrapDataRequest(ref),
+		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.GenerateBootstrapDataResponse":        schema_runtime_hooks_api_v1alpha1_GenerateBootstrapDataResponse(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.GeneratePatchesRequest":               schema_runtime_hooks_api_v1alpha1_GeneratePatchesRequest(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.GeneratePatchesRequestItem":           schema_runtime_hooks_api_v1alpha1_GeneratePatchesRequestItem(ref),
 		"sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.GeneratePatchesResponse":              schema_runtime_hooks_api_v1alpha1_GeneratePatchesResponse(ref),
@@ -610,6 +614,163 @@ func schema_runtime_hooks_api_v1alpha1_BeforeClusterUpgradeResponse(ref common.R
 	}
 }
 
+func schema_runtime_hooks_api_v1alpha1_BootstrapFile(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootstrapFile defines a file to create on the machine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the full path where the file should be created.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"owner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Owner specifies the ownership of the file, e.g. \"root:root\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"permissions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Permissions specifies the permissions to assign to the file, e.g. \"0640\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"encoding": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Encoding specifies the encoding of the content: \"base64\", \"gzip\" or \"gzip+base64\". If empty, the content is plain text.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"append": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Append specifies whether to append the content to an existing file.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"content": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Content is the content of the file, encoded according to Encoding.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+	}
+}
+
+func schema_runtime_hooks_api_v1alpha1_BootstrapUser(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BootstrapUser defines a user to create on the machine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name specifies the username.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"gecos": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Gecos specifies the gecos to use for the user.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"groups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Groups specifies the additional groups for the user, as a comma-separated list.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"homeDir": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HomeDir specifies the home directory to use for the user.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"inactive": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Inactive specifies whether to mark the user as inactive.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"shell": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Shell specifies the user's shell.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"passwd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Passwd specifies a hashed password for the user.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"primaryGroup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PrimaryGroup specifies the primary group for the user.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lockPassword": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LockPassword specifies if password login should be disabled.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"sudo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sudo specifies a sudo role for the user.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sshAuthorizedKeys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SSHAuthorizedKeys specifies a list of ssh authorized keys for the user.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
 func schema_runtime_hooks_api_v1alpha1_CommonResponse(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -818,6 +979,204 @@ func schema_runtime_hooks_api_v1alpha1_ExtensionHandler(ref common.ReferenceCall
 	}
 }
 
+func schema_runtime_hooks_api_v1alpha1_GenerateBootstrapDataRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GenerateBootstrapDataRequest is the request of the GenerateBootstrapData hook.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is the cluster object the machine belongs to.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.Cluster"),
+						},
+					},
+					"kubeadmConfigName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KubeadmConfigName is the name of the KubeadmConfig the bootstrap data is generated for. The KubeadmConfig is in the same namespace as the Cluster.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"controlPlane": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ControlPlane is true if the bootstrap data is generated for a control plane machine.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"kubernetesVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KubernetesVersion is the Kubernetes version of the machine.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterConfiguration is the kubeadm ClusterConfiguration, marshalled to YAML in the kubeadm API version corresponding to the Kubernetes version of the machine. It is set, together with InitConfiguration, only for the first control plane machine, which runs kubeadm init.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"initConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitConfiguration is the kubeadm InitConfiguration, marshalled to YAML in the kubeadm API version corresponding to the Kubernetes version of the machine.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"joinConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "JoinConfiguration is the kubeadm JoinConfiguration, marshalled to YAML in the kubeadm API version corresponding to the Kubernetes version of the machine. It is set for all the machines joining the cluster, i.e. when InitConfiguration is not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"verbosity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verbosity is the number for the log level verbosity of kubeadm.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"files": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Files is the list of files to create on the machine, including the certificates of control plane machines. The content of the files is resolved from their sources and templates.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BootstrapFile"),
+									},
+								},
+							},
+						},
+					},
+					"users": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Users is the list of users to create on the machine. The password of the users is resolved from its source.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BootstrapUser"),
+									},
+								},
+							},
+						},
+					},
+					"preKubeadmCommands": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreKubeadmCommands is the list of commands to run before kubeadm.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"postKubeadmCommands": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PostKubeadmCommands is the list of commands to run after kubeadm.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster", "kubeadmConfigName", "controlPlane", "kubernetesVersion"},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/cluster-api/api/v1beta1.Cluster", "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BootstrapFile", "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1.BootstrapUser"},
+	}
+}
+
+func schema_runtime_hooks_api_v1alpha1_GenerateBootstrapDataResponse(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "GenerateBootstrapDataResponse is the response of the GenerateBootstrapData hook.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status of the call. One of \"Success\" or \"Failure\".\n\nPossible enum values:\n - `\"Failure\"` represents a failure response.\n - `\"Success\"` represents a success response.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+							Enum:        []interface{}{"Failure", "Success"}},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A human-readable description of the status of the call.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"data": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Data is the bootstrap data to store in the bootstrap data secret of the machine.",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+				},
+				Required: []string{"status", "message", "data"},
+			},
+		},
+	}
+}
+
 func schema_runtime_hooks_api_v1alpha1_GeneratePatchesRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
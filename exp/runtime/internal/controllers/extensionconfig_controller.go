@@ -59,16 +59,24 @@ type Reconciler struct {
 	RuntimeClient runtimeclient.Client
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+	// ReadOnly makes the reconciler only sync the registry with the ExtensionConfigs, using the handlers discovered
+	// by the controller owning them, e.g. in providers calling Runtime Extensions like the kubeadm bootstrap provider.
+	// When set, CA bundles are not injected, handlers are not discovered and ExtensionConfigs are never patched.
+	ReadOnly bool
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&runtimev1.ExtensionConfig{}).
-		Watches(
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&runtimev1.ExtensionConfig{})
+	// CA bundles are injected only by the controller owning the ExtensionConfigs.
+	if !r.ReadOnly {
+		b = b.Watches(
 			&source.Kind{Type: &corev1.Secret{}},
 			handler.EnqueueRequestsFromMapFunc(r.secretToExtensionConfig),
 			builder.OnlyMetadata,
-		).
+		)
+	}
+	err := b.
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
@@ -76,8 +84,10 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
-	if err := indexByExtensionInjectCAFromSecretName(ctx, mgr); err != nil {
-		return errors.Wrap(err, "failed setting up with a controller manager")
+	if !r.ReadOnly {
+		if err := indexByExtensionInjectCAFromSecretName(ctx, mgr); err != nil {
+			return errors.Wrap(err, "failed setting up with a controller manager")
+		}
 	}
 
 	// warmupRunnable will attempt to sync the RuntimeSDK registry with existing ExtensionConfig objects to ensure extensions
@@ -86,6 +96,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		Client:        r.Client,
 		APIReader:     r.APIReader,
 		RuntimeClient: r.RuntimeClient,
+		ReadOnly:      r.ReadOnly,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed adding warmupRunnable to controller manager")
@@ -128,6 +139,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return r.reconcileDelete(ctx, extensionConfig)
 	}
 
+	// Register the ExtensionConfig as it is when it is owned by another controller.
+	if r.ReadOnly {
+		log.Info("Registering ExtensionConfig information into registry")
+		if err := r.RuntimeClient.Register(extensionConfig); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to register ExtensionConfig %s/%s", extensionConfig.Namespace, extensionConfig.Name)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Copy to avoid modifying the original extensionConfig.
 	original := extensionConfig.DeepCopy()
 
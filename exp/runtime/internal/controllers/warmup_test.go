@@ -24,8 +24,12 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/admission/plugin/webhook/testcerts"
 	utilfeature "k8s.io/component-base/featuregate/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
@@ -181,3 +185,62 @@ func Test_warmupRunnable_Start(t *testing.T) {
 		}
 	})
 }
+
+func Test_warmupReadOnlyRegistry(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(runtimev1.AddToScheme(scheme)).To(Succeed())
+
+	cat := runtimecatalog.New()
+	g.Expect(runtimehooksv1.AddToCatalog(cat)).To(Succeed())
+
+	ext1 := fakeDiscoveredExtensionConfig("some-namespace", "ext1", "first.ext1", "second.ext1")
+	ext2 := fakeDiscoveredExtensionConfig("some-namespace", "ext2", "first.ext2")
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ext1).Build()
+
+	registry := runtimeregistry.New()
+	r := &Reconciler{
+		Client:    fakeClient,
+		APIReader: fakeClient,
+		RuntimeClient: runtimeclient.New(runtimeclient.Options{
+			Catalog:  cat,
+			Registry: registry,
+		}),
+		ReadOnly: true,
+	}
+
+	// Warm up the registry using the handlers discovered by the controller owning the ExtensionConfigs.
+	g.Expect(warmupReadOnlyRegistry(ctx, r.APIReader, r.RuntimeClient)).To(Succeed())
+	g.Expect(registry.IsReady()).To(BeTrue())
+	for _, name := range []string{"first.ext1", "second.ext1"} {
+		_, err := registry.Get(name)
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	// Register ExtensionConfigs created after warmup.
+	g.Expect(fakeClient.Create(ctx, ext2)).To(Succeed())
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ext2)})
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = registry.Get("first.ext2")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Expect the ExtensionConfigs not to be patched.
+	got := &runtimev1.ExtensionConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ext2), got)).To(Succeed())
+	g.Expect(got.GetConditions()).To(BeEmpty())
+}
+
+func fakeDiscoveredExtensionConfig(namespace, name string, handlers ...string) *runtimev1.ExtensionConfig {
+	extensionConfig := fakeExtensionConfigForURL(namespace, name, "https://extension."+name)
+	for _, handler := range handlers {
+		extensionConfig.Status.Handlers = append(extensionConfig.Status.Handlers, runtimev1.ExtensionHandler{
+			Name: handler,
+			RequestHook: runtimev1.GroupVersionHook{
+				APIVersion: runtimehooksv1.GroupVersion.String(),
+				Hook:       "GenerateBootstrapData",
+			},
+		})
+	}
+	return extensionConfig
+}
@@ -55,6 +55,12 @@ const (
 	//
 	// alpha: v1.1
 	KubeadmBootstrapFormatIgnition featuregate.Feature = "KubeadmBootstrapFormatIgnition"
+
+	// KubeadmBootstrapFormatWindows is a feature gate for the Windows bootstrap format
+	// functionality.
+	//
+	// alpha: v1.2
+	KubeadmBootstrapFormatWindows featuregate.Feature = "KubeadmBootstrapFormatWindows"
 )
 
 func init() {
@@ -69,5 +75,6 @@ var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	ClusterResourceSet:             {Default: true, PreRelease: featuregate.Beta},
 	ClusterTopology:                {Default: false, PreRelease: featuregate.Alpha},
 	KubeadmBootstrapFormatIgnition: {Default: false, PreRelease: featuregate.Alpha},
+	KubeadmBootstrapFormatWindows:  {Default: false, PreRelease: featuregate.Alpha},
 	RuntimeSDK:                     {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -29,6 +29,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
@@ -39,8 +41,10 @@ import (
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // Reconciler reconciles the ClusterClass object.
@@ -54,21 +58,47 @@ type Reconciler struct {
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects.
 	UnstructuredCachingClient client.Client
+
+	externalTracker external.ObjectTracker
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
-	err := ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.ClusterClass{}).
 		Named("topology/clusterclass").
 		WithOptions(options).
+		// Watch Clusters, so template revisions which are no longer used are deleted when Clusters are
+		// updated or deleted.
+		Watches(
+			&source.Kind{Type: &clusterv1.Cluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterClass),
+		).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
-		Complete(r)
+		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
+
+	r.externalTracker = external.ObjectTracker{
+		Controller: c,
+	}
 	return nil
 }
 
+// clusterToClusterClass is a handler.ToRequestsFunc to be used to enqueue a request for reconciliation
+// for the ClusterClass used by a Cluster.
+func (r *Reconciler) clusterToClusterClass(o client.Object) []ctrl.Request {
+	cluster, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Cluster but got a %T", o))
+	}
+
+	if cluster.Spec.Topology == nil {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: cluster.GetClassKey()}}
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -140,6 +170,7 @@ func (r *Reconciler) reconcile(ctx context.Context, clusterClass *clusterv1.Clus
 	// external object only once.
 	errs := []error{}
 	patchedRefs := sets.NewString()
+	uniqueRefs := []*corev1.ObjectReference{}
 	for i := range refs {
 		ref := refs[i]
 		uniqueKey := uniqueObjectRefKey(ref)
@@ -147,10 +178,22 @@ func (r *Reconciler) reconcile(ctx context.Context, clusterClass *clusterv1.Clus
 			errs = append(errs, err)
 			continue
 		}
+		if !patchedRefs.Has(uniqueKey) {
+			uniqueRefs = append(uniqueRefs, ref)
+		}
 		patchedRefs.Insert(uniqueKey)
 	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
+	}
+
+	// Ensure revisions exist for the current content of all the referenced templates.
+	if err := r.reconcileTemplateRevisions(ctx, clusterClass, uniqueRefs); err != nil {
+		return ctrl.Result{}, err
+	}
 
-	return ctrl.Result{}, kerrors.NewAggregate(errs)
+	clusterClass.Status.ObservedGeneration = clusterClass.Generation
+	return ctrl.Result{}, nil
 }
 
 func (r *Reconciler) reconcileExternal(ctx context.Context, clusterClass *clusterv1.ClusterClass, ref *corev1.ObjectReference, setOwnerRef bool) error {
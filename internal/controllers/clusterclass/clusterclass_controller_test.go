@@ -25,6 +25,8 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -106,6 +108,9 @@ func TestClusterClassReconciler_reconcile(t *testing.T) {
 
 		g.Expect(assertMachineDeploymentClasses(ctx, actualClusterClass, ns)).Should(Succeed())
 
+		// Assert a revision has been created for each of the templates referenced by the ClusterClass.
+		g.Expect(actualClusterClass.Status.TemplateRevisions).To(HaveLen(len(initObjs) - 1))
+
 		return nil
 	}, timeout).Should(Succeed())
 }
@@ -262,3 +267,42 @@ func isOwnerReferenceEqual(a, b metav1.OwnerReference) bool {
 	}
 	return true
 }
+
+func TestReconciler_clusterToClusterClass(t *testing.T) {
+	crossNamespaceTopology := builder.ClusterTopology().WithClass("class1").Build()
+	crossNamespaceTopology.ClassNamespace = "other"
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		want    []ctrl.Request
+	}{
+		{
+			name:    "no requests for a Cluster without topology",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").Build(),
+			want:    nil,
+		},
+		{
+			name: "request for a ClusterClass in the namespace of the Cluster",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().WithClass("class1").Build()).
+				Build(),
+			want: []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "class1"}}},
+		},
+		{
+			name: "request for a ClusterClass in another namespace",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(crossNamespaceTopology).
+				Build(),
+			want: []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: "other", Name: "class1"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{}
+			g.Expect(r.clusterToClusterClass(tt.cluster)).To(Equal(tt.want))
+		})
+	}
+}
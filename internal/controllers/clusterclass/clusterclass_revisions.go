@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclass
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/api/v1beta1/index"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	tlog "sigs.k8s.io/cluster-api/internal/log"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
+)
+
+// reconcileTemplateRevisions ensures an immutable revision exists for the current content of each template referenced
+// by the ClusterClass, and records the revisions in the ClusterClass status.
+// Revisions of a previous content of the templates are kept in the status until no Cluster is using them anymore,
+// and then deleted.
+func (r *Reconciler) reconcileTemplateRevisions(ctx context.Context, clusterClass *clusterv1.ClusterClass, refs []*corev1.ObjectReference) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	templateRevisions := []clusterv1.ClusterClassTemplateRevision{}
+	currentRevisions := sets.NewString()
+	for _, ref := range refs {
+		template, err := external.Get(ctx, r.UnstructuredCachingClient, ref, clusterClass.Namespace)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s", tlog.KRef{Ref: ref})
+		}
+
+		// Watch the templates, so new revisions are created as soon as the content of a template changes.
+		if err := r.externalTracker.Watch(log, template, &handler.EnqueueRequestForOwner{OwnerType: &clusterv1.ClusterClass{}}); err != nil {
+			return err
+		}
+
+		hash, err := revisions.ComputeHash(clusterClass, template)
+		if err != nil {
+			return err
+		}
+
+		revision, err := r.ensureTemplateRevision(ctx, clusterClass, template, hash)
+		if err != nil {
+			return err
+		}
+
+		revisionRef := contract.ObjToRef(revision)
+		templateRevisions = append(templateRevisions, clusterv1.ClusterClassTemplateRevision{
+			TemplateRef: *contract.ObjToRef(template),
+			RevisionRef: *revisionRef,
+			Hash:        hash,
+		})
+		currentRevisions.Insert(revisionKey(revisionRef))
+	}
+
+	// Keep the previous revisions until they are used by at least one Cluster, otherwise delete them.
+	for i := range clusterClass.Status.TemplateRevisions {
+		previous := clusterClass.Status.TemplateRevisions[i]
+		if currentRevisions.Has(revisionKey(&previous.RevisionRef)) {
+			continue
+		}
+
+		inUse, err := r.isTemplateRevisionInUse(ctx, clusterClass, &previous.RevisionRef)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			templateRevisions = append(templateRevisions, previous)
+			currentRevisions.Insert(revisionKey(&previous.RevisionRef))
+			continue
+		}
+
+		revision := &unstructured.Unstructured{}
+		revision.SetAPIVersion(previous.RevisionRef.APIVersion)
+		revision.SetKind(previous.RevisionRef.Kind)
+		revision.SetNamespace(previous.RevisionRef.Namespace)
+		revision.SetName(previous.RevisionRef.Name)
+		log.Info("Deleting unused template revision", previous.RevisionRef.Kind, previous.RevisionRef.Name)
+		if err := r.Client.Delete(ctx, revision); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return errors.Wrapf(err, "failed to delete template revision %s", tlog.KObj{Obj: revision})
+		}
+	}
+
+	clusterClass.Status.TemplateRevisions = templateRevisions
+	return nil
+}
+
+// ensureTemplateRevision ensures the revision of a template with the given hash exists.
+// NOTE: Revisions are immutable, so an existing revision is never updated.
+func (r *Reconciler) ensureTemplateRevision(ctx context.Context, clusterClass *clusterv1.ClusterClass, template *unstructured.Unstructured, hash string) (*unstructured.Unstructured, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	revision := &unstructured.Unstructured{}
+	revision.SetGroupVersionKind(template.GroupVersionKind())
+	key := client.ObjectKey{Namespace: template.GetNamespace(), Name: revisions.Name(template.GetName(), hash)}
+	if err := r.UnstructuredCachingClient.Get(ctx, key, revision); err == nil {
+		return revision, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get template revision %s %s", template.GetKind(), key)
+	}
+
+	// Create the revision as a copy of the template, removing all the info automatically assigned by the API server.
+	revision = template.DeepCopy()
+	revision.SetName(key.Name)
+	revision.SetResourceVersion("")
+	revision.SetUID("")
+	revision.SetSelfLink("")
+	revision.SetGeneration(0)
+	revision.SetCreationTimestamp(metav1.Time{})
+	revision.SetManagedFields(nil)
+	revision.SetFinalizers(nil)
+	revision.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(revision.Object, "status")
+
+	annotations := revision.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterv1.TemplateClonedFromNameAnnotation] = template.GetName()
+	annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] = template.GroupVersionKind().GroupKind().String()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	revision.SetAnnotations(annotations)
+
+	// Revisions are owned by the ClusterClass, so they are deleted together with the ClusterClass.
+	if err := controllerutil.SetOwnerReference(clusterClass, revision, r.Client.Scheme()); err != nil {
+		return nil, errors.Wrapf(err, "failed to set cluster class owner reference for template revision %s %s", template.GetKind(), key)
+	}
+
+	log.Info("Creating template revision", template.GetKind(), key.Name)
+	if err := r.Client.Create(ctx, revision); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, errors.Wrapf(err, "failed to create template revision %s %s", template.GetKind(), key)
+	}
+	return revision, nil
+}
+
+// isTemplateRevisionInUse returns true if an object managed by the topology controller for one of the Clusters
+// using the ClusterClass has been created from the revision.
+// NOTE: Objects created from a template have either the same kind of the template (e.g. copies of
+// InfrastructureMachineTemplates) or the kind of the template without the Template suffix (e.g. InfrastructureClusters).
+func (r *Reconciler) isTemplateRevisionInUse(ctx context.Context, clusterClass *clusterv1.ClusterClass, revisionRef *corev1.ObjectReference) (bool, error) {
+	// NOTE: Clusters can use a ClusterClass in another namespace, so Clusters are listed in all namespaces.
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusterList,
+		client.MatchingFields{index.ClusterClassRefPath: client.ObjectKeyFromObject(clusterClass).String()},
+	); err != nil {
+		return false, errors.Wrap(err, "failed to list Clusters")
+	}
+	namespaces := sets.NewString()
+	for i := range clusterList.Items {
+		if clusterList.Items[i].GetClassKey() == client.ObjectKeyFromObject(clusterClass) {
+			namespaces.Insert(clusterList.Items[i].Namespace)
+		}
+	}
+
+	gv, err := schema.ParseGroupVersion(revisionRef.APIVersion)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse apiVersion of template revision %s", tlog.KRef{Ref: revisionRef})
+	}
+	groupKind := gv.WithKind(revisionRef.Kind).GroupKind().String()
+	kinds := sets.NewString(revisionRef.Kind, strings.TrimSuffix(revisionRef.Kind, "Template"))
+
+	for _, namespace := range namespaces.List() {
+		for _, kind := range kinds.List() {
+			objList := &unstructured.UnstructuredList{}
+			objList.SetGroupVersionKind(gv.WithKind(kind + "List"))
+			if err := r.Client.List(ctx, objList, client.InNamespace(namespace), client.HasLabels{clusterv1.ClusterTopologyOwnedLabel}); err != nil {
+				if meta.IsNoMatchError(err) {
+					continue
+				}
+				return false, errors.Wrapf(err, "failed to list %s", kind)
+			}
+			for _, obj := range objList.Items {
+				annotations := obj.GetAnnotations()
+				if annotations[clusterv1.TemplateClonedFromNameAnnotation] == revisionRef.Name &&
+					annotations[clusterv1.TemplateClonedFromGroupKindAnnotation] == groupKind {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+func revisionKey(ref *corev1.ObjectReference) string {
+	return ref.GroupVersionKind().GroupKind().String() + "/" + ref.Name
+}
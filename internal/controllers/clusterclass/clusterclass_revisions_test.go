@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclass
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
+)
+
+func TestReconcileTemplateRevisions(t *testing.T) {
+	infraMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "inframachinetemplate").
+		WithSpecFields(map[string]interface{}{"spec.template.spec.foo": "bar"}).
+		Build()
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithControlPlaneInfrastructureMachineTemplate(infraMachineTemplate).
+		Build()
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+		WithTopology(builder.ClusterTopology().WithClass("class1").Build()).
+		Build()
+
+	hash, err := revisions.ComputeHash(clusterClass, infraMachineTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A revision of a previous content of the template.
+	previousRevision := infraMachineTemplate.DeepCopy()
+	previousRevision.SetName(revisions.Name(infraMachineTemplate.GetName(), "previous"))
+	previousRevision.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: clusterv1.GroupVersion.String(), Kind: "ClusterClass", Name: "class1"}})
+	previousTemplateRevision := clusterv1.ClusterClassTemplateRevision{
+		TemplateRef: *contract.ObjToRef(infraMachineTemplate),
+		RevisionRef: *contract.ObjToRef(previousRevision),
+		Hash:        "previous",
+	}
+
+	// A copy of the previous revision created by the topology controller.
+	clusterTemplate := infraMachineTemplate.DeepCopy()
+	clusterTemplate.SetName("cluster1-control-plane-abc")
+	clusterTemplate.SetLabels(map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""})
+	clusterTemplate.SetAnnotations(map[string]string{
+		clusterv1.TemplateClonedFromNameAnnotation:      previousRevision.GetName(),
+		clusterv1.TemplateClonedFromGroupKindAnnotation: previousRevision.GroupVersionKind().GroupKind().String(),
+	})
+
+	tests := []struct {
+		name              string
+		templateRevisions []clusterv1.ClusterClassTemplateRevision
+		objects           []client.Object
+		wantRevisions     []string
+		wantDeleted       []string
+	}{
+		{
+			name:          "Should create a revision for the current content of the template",
+			objects:       []client.Object{infraMachineTemplate},
+			wantRevisions: []string{revisions.Name(infraMachineTemplate.GetName(), hash)},
+		},
+		{
+			name:              "Should delete a previous revision not used by any Cluster",
+			templateRevisions: []clusterv1.ClusterClassTemplateRevision{previousTemplateRevision},
+			objects:           []client.Object{infraMachineTemplate, previousRevision, cluster},
+			wantRevisions:     []string{revisions.Name(infraMachineTemplate.GetName(), hash)},
+			wantDeleted:       []string{previousRevision.GetName()},
+		},
+		{
+			name:              "Should keep a previous revision used by a Cluster",
+			templateRevisions: []clusterv1.ClusterClassTemplateRevision{previousTemplateRevision},
+			objects:           []client.Object{infraMachineTemplate, previousRevision, cluster, clusterTemplate},
+			wantRevisions:     []string{revisions.Name(infraMachineTemplate.GetName(), hash), previousRevision.GetName()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := clusterClass.DeepCopy()
+			clusterClass.Status.TemplateRevisions = tt.templateRevisions
+
+			objs := []client.Object{clusterClass}
+			for _, obj := range tt.objects {
+				objs = append(objs, obj.DeepCopyObject().(client.Object))
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(objs...).Build()
+
+			r := &Reconciler{
+				Client:                    fakeClient,
+				UnstructuredCachingClient: fakeClient,
+			}
+			err := r.reconcileTemplateRevisions(ctx, clusterClass, []*corev1.ObjectReference{clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotRevisions := []string{}
+			for _, revision := range clusterClass.Status.TemplateRevisions {
+				gotRevisions = append(gotRevisions, revision.RevisionRef.Name)
+
+				// Revisions exist and they are owned by the ClusterClass.
+				obj := &unstructured.Unstructured{}
+				obj.SetGroupVersionKind(revision.RevisionRef.GroupVersionKind())
+				g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: revision.RevisionRef.Namespace, Name: revision.RevisionRef.Name}, obj)).To(Succeed())
+				g.Expect(obj.GetOwnerReferences()).To(HaveLen(1))
+				g.Expect(obj.GetOwnerReferences()[0].Name).To(Equal(clusterClass.Name))
+			}
+			g.Expect(gotRevisions).To(Equal(tt.wantRevisions))
+
+			for _, name := range tt.wantDeleted {
+				obj := &unstructured.Unstructured{}
+				obj.SetGroupVersionKind(infraMachineTemplate.GroupVersionKind())
+				err := fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, obj)
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}
+		})
+	}
+}
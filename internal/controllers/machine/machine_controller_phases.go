@@ -312,3 +312,34 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, cluster *clust
 	m.Spec.ProviderID = pointer.StringPtr(providerID)
 	return ctrl.Result{}, nil
 }
+
+// reconcileCertificateExpiry reports the expiry date of the certificates of a control plane Machine, as read from
+// the certificates expiry annotation on the Machine or, if not set, on the bootstrap config.
+func (r *Reconciler) reconcileCertificateExpiry(ctx context.Context, _ *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
+	// Certificates expiry is reported only for control plane machines.
+	if !util.IsControlPlaneMachine(m) {
+		m.Status.CertificatesExpiryDate = nil
+		return ctrl.Result{}, nil
+	}
+
+	// The annotation on the Machine takes precedence over the one on the bootstrap config.
+	value, ok := m.GetAnnotations()[clusterv1.MachineCertificatesExpiryDateAnnotation]
+	if !ok && m.Spec.Bootstrap.ConfigRef != nil {
+		bootstrapConfig, err := external.Get(ctx, r.Client, m.Spec.Bootstrap.ConfigRef, m.Namespace)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve bootstrap config for Machine %q in namespace %q", m.Name, m.Namespace)
+		}
+		value, ok = bootstrapConfig.GetAnnotations()[clusterv1.MachineCertificatesExpiryDateAnnotation]
+	}
+	if !ok {
+		m.Status.CertificatesExpiryDate = nil
+		return ctrl.Result{}, nil
+	}
+
+	expiryDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to parse certificates expiry date %q for Machine %q in namespace %q", value, m.Name, m.Namespace)
+	}
+	m.Status.CertificatesExpiryDate = &metav1.Time{Time: expiryDate}
+	return ctrl.Result{}, nil
+}
@@ -1100,3 +1100,120 @@ func TestReconcileInfrastructure(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileCertificateExpiry(t *testing.T) {
+	fakeTimeString := "2020-01-01T00:00:00Z"
+	fakeTime, _ := time.Parse(time.RFC3339, fakeTimeString)
+	fakeMetaTime := &metav1.Time{Time: fakeTime}
+
+	fakeTimeString2 := "2020-02-02T00:00:00Z"
+	fakeTime2, _ := time.Parse(time.RFC3339, fakeTimeString2)
+	fakeMetaTime2 := &metav1.Time{Time: fakeTime2}
+
+	bootstrapConfigWithExpiry := map[string]interface{}{
+		"kind":       "GenericBootstrapConfig",
+		"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-config-with-expiry",
+			"namespace": metav1.NamespaceDefault,
+			"annotations": map[string]interface{}{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString,
+			},
+		},
+	}
+	bootstrapConfigWithoutExpiry := map[string]interface{}{
+		"kind":       "GenericBootstrapConfig",
+		"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-config-without-expiry",
+			"namespace": metav1.NamespaceDefault,
+		},
+	}
+
+	machine := func(controlPlane bool, bootstrapConfigName string, annotations map[string]string) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "machine-test",
+				Namespace:   metav1.NamespaceDefault,
+				Labels:      map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				Annotations: annotations,
+			},
+			Spec: clusterv1.MachineSpec{
+				Bootstrap: clusterv1.Bootstrap{
+					ConfigRef: &corev1.ObjectReference{
+						APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+						Kind:       "GenericBootstrapConfig",
+						Name:       bootstrapConfigName,
+					},
+				},
+			},
+			Status: clusterv1.MachineStatus{
+				CertificatesExpiryDate: fakeMetaTime2,
+			},
+		}
+		if controlPlane {
+			m.Labels[clusterv1.MachineControlPlaneLabelName] = ""
+		}
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		machine  *clusterv1.Machine
+		expected *metav1.Time
+		wantErr  bool
+	}{
+		{
+			name:     "worker machine does not report certificates expiry",
+			machine:  machine(false, "bootstrap-config-with-expiry", nil),
+			expected: nil,
+		},
+		{
+			name:     "control plane machine reports certificates expiry from the bootstrap config",
+			machine:  machine(true, "bootstrap-config-with-expiry", nil),
+			expected: fakeMetaTime,
+		},
+		{
+			name: "control plane machine reports certificates expiry from the Machine, which takes precedence over the bootstrap config",
+			machine: machine(true, "bootstrap-config-with-expiry", map[string]string{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString2,
+			}),
+			expected: fakeMetaTime2,
+		},
+		{
+			name:     "control plane machine does not report certificates expiry if not annotated",
+			machine:  machine(true, "bootstrap-config-without-expiry", nil),
+			expected: nil,
+		},
+		{
+			name: "fails for an invalid expiry date",
+			machine: machine(true, "bootstrap-config-without-expiry", map[string]string{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: "not-a-date",
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().
+					WithObjects(
+						tt.machine,
+						&unstructured.Unstructured{Object: bootstrapConfigWithExpiry},
+						&unstructured.Unstructured{Object: bootstrapConfigWithoutExpiry},
+					).Build(),
+			}
+
+			_, err := r.reconcileCertificateExpiry(ctx, nil, tt.machine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tt.machine.Status.CertificatesExpiryDate).To(Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	utilresource "sigs.k8s.io/cluster-api/util/resource"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+// addonNotReadyRequeueAfter is the interval after which add-ons which are not yet ready are checked again;
+// this is required because changes to the objects in the workload cluster do not trigger a reconcile.
+const addonNotReadyRequeueAfter = 10 * time.Second
+
+// reconcileAddons installs and upgrades the add-ons defined in the ClusterClass into the workload cluster.
+// Add-ons are installed once the control plane is initialized and the AfterControlPlaneInitialized hook has been called;
+// the version of each add-on is selected according to the Kubernetes version of the control plane, so add-ons are
+// upgraded after the control plane. Add-ons are reconciled in order, and each add-on is reconciled only after
+// the previous ones are ready.
+// The objects applied for each add-on are tracked in the Cluster status, so objects which are no longer part of
+// an add-on, e.g. after an upgrade, or which belong to an add-on removed from the ClusterClass, are deleted.
+func (r *Reconciler) reconcileAddons(ctx context.Context, s *scope.Scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	cluster := s.Current.Cluster
+
+	if len(s.Blueprint.ClusterClass.Spec.Addons) == 0 && len(cluster.Status.Addons) == 0 {
+		conditions.Delete(cluster, clusterv1.TopologyAddonsReadyCondition)
+		return ctrl.Result{}, nil
+	}
+
+	// Add-ons are not reconciled in dry run mode, given that they are applied to the workload cluster.
+	if r.Tracker == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !isControlPlaneInitialized(cluster) ||
+		(feature.Gates.Enabled(feature.RuntimeSDK) && hooks.IsPending(runtimehooksv1.AfterControlPlaneInitialized, cluster)) {
+		conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonsWaitingForControlPlaneReason,
+			clusterv1.ConditionSeverityInfo, "Waiting for the control plane to be initialized")
+		return ctrl.Result{}, nil
+	}
+	if s.UpgradeTracker.ControlPlane.IsUpgrading {
+		conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonsWaitingForControlPlaneReason,
+			clusterv1.ConditionSeverityInfo, "Waiting for the control plane to complete the upgrade")
+		return ctrl.Result{}, nil
+	}
+
+	cpVersion, err := contract.ControlPlane().Version().Get(s.Current.ControlPlane.Object)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to get the version from control plane spec")
+	}
+	kubernetesVersion, err := semver.ParseTolerant(*cpVersion)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to parse the version %q of the control plane", *cpVersion)
+	}
+
+	remoteClient, err := r.Tracker.GetClient(ctx, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create a client to the workload cluster")
+	}
+
+	// Delete the objects of the add-ons which have been removed from the ClusterClass.
+	addonNames := sets.NewString()
+	for _, addon := range s.Blueprint.ClusterClass.Spec.Addons {
+		addonNames.Insert(addon.Name)
+	}
+	for _, addonStatus := range append([]clusterv1.ClusterAddonStatus{}, cluster.Status.Addons...) {
+		if addonNames.Has(addonStatus.Name) {
+			continue
+		}
+		log.Info("Deleting add-on removed from the ClusterClass", "addon", addonStatus.Name, "version", addonStatus.Version)
+		if err := deleteAddonObjects(ctx, remoteClient, cluster, addonStatus.Name, addonStatus.Objects); err != nil {
+			conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonDeleteFailedReason,
+				clusterv1.ConditionSeverityWarning, "Failed to delete add-on %s %s: %v", addonStatus.Name, addonStatus.Version, err)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete add-on %s %s", addonStatus.Name, addonStatus.Version)
+		}
+		removeAddonStatus(cluster, addonStatus.Name)
+	}
+
+	if len(s.Blueprint.ClusterClass.Spec.Addons) == 0 {
+		conditions.Delete(cluster, clusterv1.TopologyAddonsReadyCondition)
+		return ctrl.Result{}, nil
+	}
+
+	for _, addon := range s.Blueprint.ClusterClass.Spec.Addons {
+		version, err := getAddonVersion(addon, kubernetesVersion)
+		if err != nil {
+			conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonApplyFailedReason,
+				clusterv1.ConditionSeverityWarning, "%s", err)
+			return ctrl.Result{}, nil
+		}
+
+		objs, err := r.getAddonObjects(ctx, s.Blueprint.ClusterClass.Namespace, version)
+		if err != nil {
+			conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonApplyFailedReason,
+				clusterv1.ConditionSeverityWarning, "Failed to read add-on %s %s: %v", addon.Name, version.Version, err)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to read add-on %s %s", addon.Name, version.Version)
+		}
+
+		log.V(3).Info("Applying add-on", "addon", addon.Name, "version", version.Version)
+		for i := range objs {
+			// Label the objects with the cluster and the add-on name, so objects are deleted only by the add-on they belong to.
+			labels := objs[i].GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[clusterv1.ClusterLabelName] = cluster.Name
+			labels[clusterv1.ClusterTopologyAddonLabelName] = addon.Name
+			objs[i].SetLabels(labels)
+
+			if err := remoteClient.Patch(ctx, &objs[i], client.Apply, client.FieldOwner(structuredmerge.TopologyManagerName), client.ForceOwnership); err != nil {
+				conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonApplyFailedReason,
+					clusterv1.ConditionSeverityWarning, "Failed to apply add-on %s %s: %v", addon.Name, version.Version, err)
+				return ctrl.Result{}, errors.Wrapf(err, "failed to apply %s %s of add-on %s %s",
+					objs[i].GetKind(), client.ObjectKeyFromObject(&objs[i]), addon.Name, version.Version)
+			}
+		}
+
+		// Delete the objects applied for the previous version of the add-on which are not part of the current version.
+		if addonStatus := getAddonStatus(cluster, addon.Name); addonStatus != nil {
+			if err := deleteAddonObjects(ctx, remoteClient, cluster, addon.Name, staleAddonObjects(addonStatus.Objects, objs)); err != nil {
+				conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonDeleteFailedReason,
+					clusterv1.ConditionSeverityWarning, "Failed to delete objects of add-on %s %s: %v", addon.Name, addonStatus.Version, err)
+				return ctrl.Result{}, errors.Wrapf(err, "failed to delete objects of add-on %s %s", addon.Name, addonStatus.Version)
+			}
+		}
+		setAddonStatus(cluster, addon.Name, version.Version, objs)
+
+		// The objects returned by the apply calls contain the current status, which is used to check readiness.
+		for i := range objs {
+			if !isAddonObjectReady(&objs[i]) {
+				conditions.MarkFalse(cluster, clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonNotReadyReason,
+					clusterv1.ConditionSeverityInfo, "Add-on %s %s is not yet ready: %s %s is not ready",
+					addon.Name, version.Version, objs[i].GetKind(), client.ObjectKeyFromObject(&objs[i]))
+				return ctrl.Result{RequeueAfter: addonNotReadyRequeueAfter}, nil
+			}
+		}
+	}
+
+	conditions.MarkTrue(cluster, clusterv1.TopologyAddonsReadyCondition)
+	return ctrl.Result{}, nil
+}
+
+// getAddonVersion returns the first version of the add-on supporting the given Kubernetes version.
+func getAddonVersion(addon clusterv1.ClusterClassAddon, kubernetesVersion semver.Version) (*clusterv1.ClusterClassAddonVersion, error) {
+	// Pre-release and build metadata are ignored when matching versions, e.g. v1.24.0-rc.0 is matched by >=1.24.0.
+	kubernetesVersion = semver.Version{Major: kubernetesVersion.Major, Minor: kubernetesVersion.Minor, Patch: kubernetesVersion.Patch}
+	for i := range addon.Versions {
+		versionRange, err := semver.ParseRange(addon.Versions[i].KubernetesVersions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the Kubernetes versions range %q of add-on %s %s",
+				addon.Versions[i].KubernetesVersions, addon.Name, addon.Versions[i].Version)
+		}
+		if versionRange(kubernetesVersion) {
+			return &addon.Versions[i], nil
+		}
+	}
+	return nil, errors.Errorf("no version of add-on %s supports Kubernetes version %s", addon.Name, kubernetesVersion)
+}
+
+// getAddonObjects returns the objects defined in the ConfigMaps and Secrets of an add-on version, sorted for creation.
+func (r *Reconciler) getAddonObjects(ctx context.Context, namespace string, version *clusterv1.ClusterClassAddonVersion) ([]unstructured.Unstructured, error) {
+	objs := []unstructured.Unstructured{}
+	for _, resource := range version.Resources {
+		key := client.ObjectKey{Namespace: namespace, Name: resource.Name}
+
+		data := map[string][]byte{}
+		switch resource.Kind {
+		case "ConfigMap":
+			configMap := &corev1.ConfigMap{}
+			if err := r.Client.Get(ctx, key, configMap); err != nil {
+				return nil, errors.Wrapf(err, "failed to get ConfigMap %s", key)
+			}
+			for k, v := range configMap.Data {
+				data[k] = []byte(v)
+			}
+		case "Secret":
+			secret := &corev1.Secret{}
+			if err := r.Client.Get(ctx, key, secret); err != nil {
+				return nil, errors.Wrapf(err, "failed to get Secret %s", key)
+			}
+			data = secret.Data
+		default:
+			return nil, errors.Errorf("unsupported kind %q of resource %s", resource.Kind, resource.Name)
+		}
+
+		// Keys are processed in alphabetical order to make the result deterministic.
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			resourceObjs, err := utilyaml.ToUnstructured(data[k])
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse key %q of %s %s", k, resource.Kind, key)
+			}
+			objs = append(objs, resourceObjs...)
+		}
+	}
+	return utilresource.SortForCreate(objs), nil
+}
+
+// isAddonObjectReady returns true if an object of an add-on is ready.
+// NOTE: Readiness is checked only for Deployments and DaemonSets, which are ready when they are fully rolled out
+// and available; all the other objects, including other workloads like StatefulSets or Jobs, are considered
+// ready once applied.
+func isAddonObjectReady(obj *unstructured.Unstructured) bool {
+	if obj.GroupVersionKind().Group != "apps" {
+		return true
+	}
+
+	observedGeneration, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if observedGeneration < obj.GetGeneration() {
+		return false
+	}
+
+	switch obj.GetKind() {
+	case "Deployment":
+		replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		updatedReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+		availableReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+		return updatedReplicas == replicas && availableReplicas == replicas
+	case "DaemonSet":
+		desiredNumberScheduled, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		updatedNumberScheduled, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedNumberScheduled")
+		numberAvailable, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberAvailable")
+		return updatedNumberScheduled == desiredNumberScheduled && numberAvailable == desiredNumberScheduled
+	default:
+		return true
+	}
+}
+
+// deleteAddonObjects deletes the given objects of an add-on from the workload cluster.
+// Objects which do not exist anymore, or which are not labeled as part of the add-on, e.g. because they
+// have been moved to another add-on, are ignored.
+func deleteAddonObjects(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, addonName string, refs []clusterv1.AddonObjectReference) error {
+	log := ctrl.LoggerFrom(ctx)
+	for _, ref := range refs {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		key := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+		if err := c.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get %s %s", ref.Kind, key)
+		}
+		if obj.GetLabels()[clusterv1.ClusterLabelName] != cluster.Name || obj.GetLabels()[clusterv1.ClusterTopologyAddonLabelName] != addonName {
+			continue
+		}
+
+		log.V(3).Info("Deleting add-on object", "addon", addonName, "kind", ref.Kind, "object", key)
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete %s %s", ref.Kind, key)
+		}
+	}
+	return nil
+}
+
+// staleAddonObjects returns the objects in refs which are not included in objs.
+// NOTE: Objects are compared by group, kind, namespace and name, so objects whose API version changed are not stale.
+func staleAddonObjects(refs []clusterv1.AddonObjectReference, objs []unstructured.Unstructured) []clusterv1.AddonObjectReference {
+	current := sets.NewString()
+	for i := range objs {
+		current.Insert(addonObjectKey(objs[i].GroupVersionKind().GroupKind(), objs[i].GetNamespace(), objs[i].GetName()))
+	}
+
+	stale := []clusterv1.AddonObjectReference{}
+	for _, ref := range refs {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+		if !current.Has(addonObjectKey(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, ref.Namespace, ref.Name)) {
+			stale = append(stale, ref)
+		}
+	}
+	return stale
+}
+
+func addonObjectKey(gk schema.GroupKind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", gk, namespace, name)
+}
+
+// getAddonStatus returns the status of the add-on with the given name, if any.
+func getAddonStatus(cluster *clusterv1.Cluster, name string) *clusterv1.ClusterAddonStatus {
+	for i := range cluster.Status.Addons {
+		if cluster.Status.Addons[i].Name == name {
+			return &cluster.Status.Addons[i]
+		}
+	}
+	return nil
+}
+
+// setAddonStatus sets the version and the objects applied for an add-on in the Cluster status.
+func setAddonStatus(cluster *clusterv1.Cluster, name, version string, objs []unstructured.Unstructured) {
+	addonStatus := clusterv1.ClusterAddonStatus{
+		Name:    name,
+		Version: version,
+	}
+	for i := range objs {
+		addonStatus.Objects = append(addonStatus.Objects, clusterv1.AddonObjectReference{
+			APIVersion: objs[i].GetAPIVersion(),
+			Kind:       objs[i].GetKind(),
+			Namespace:  objs[i].GetNamespace(),
+			Name:       objs[i].GetName(),
+		})
+	}
+
+	if current := getAddonStatus(cluster, name); current != nil {
+		*current = addonStatus
+		return
+	}
+	cluster.Status.Addons = append(cluster.Status.Addons, addonStatus)
+}
+
+// removeAddonStatus removes the add-on with the given name from the Cluster status.
+func removeAddonStatus(cluster *clusterv1.Cluster, name string) {
+	addons := []clusterv1.ClusterAddonStatus{}
+	for _, addonStatus := range cluster.Status.Addons {
+		if addonStatus.Name != name {
+			addons = append(addons, addonStatus)
+		}
+	}
+	if len(addons) == 0 {
+		addons = nil
+	}
+	cluster.Status.Addons = addons
+}
@@ -0,0 +1,437 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileAddons(t *testing.T) {
+	cni := clusterv1.ClusterClassAddon{
+		Name: "cni",
+		Versions: []clusterv1.ClusterClassAddonVersion{
+			{
+				Version:            "v1.0.0",
+				KubernetesVersions: ">=1.23.0 <1.24.0",
+				Resources:          []clusterv1.AddonResourceRef{{Kind: "ConfigMap", Name: "cni-v1.0.0"}},
+			},
+		},
+	}
+	initialized := conditions.TrueCondition(clusterv1.ControlPlaneInitializedCondition)
+
+	tests := []struct {
+		name                  string
+		addons                []clusterv1.ClusterClassAddon
+		controlPlaneCondition *clusterv1.Condition
+		controlPlaneVersion   string
+		controlPlaneUpgrading bool
+		wantCondition         *clusterv1.Condition
+	}{
+		{
+			name:          "no condition if the ClusterClass has no add-ons",
+			wantCondition: nil,
+		},
+		{
+			name:          "wait if the control plane is not initialized",
+			addons:        []clusterv1.ClusterClassAddon{cni},
+			wantCondition: conditions.FalseCondition(clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonsWaitingForControlPlaneReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name:                  "wait if the control plane is upgrading",
+			addons:                []clusterv1.ClusterClassAddon{cni},
+			controlPlaneCondition: initialized,
+			controlPlaneVersion:   "v1.23.0",
+			controlPlaneUpgrading: true,
+			wantCondition:         conditions.FalseCondition(clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonsWaitingForControlPlaneReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name:                  "fail if no version of an add-on supports the Kubernetes version of the control plane",
+			addons:                []clusterv1.ClusterClassAddon{cni},
+			controlPlaneCondition: initialized,
+			controlPlaneVersion:   "v1.24.0",
+			wantCondition:         conditions.FalseCondition(clusterv1.TopologyAddonsReadyCondition, clusterv1.TopologyAddonApplyFailedReason, clusterv1.ConditionSeverityWarning, ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+			if tt.controlPlaneCondition != nil {
+				conditions.Set(cluster, tt.controlPlaneCondition)
+			}
+
+			s := scope.New(cluster)
+			s.Blueprint = &scope.ClusterBlueprint{
+				ClusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").Build(),
+			}
+			s.Blueprint.ClusterClass.Spec.Addons = tt.addons
+			s.Current.ControlPlane = &scope.ControlPlaneState{
+				Object: builder.ControlPlane(metav1.NamespaceDefault, "cp1").WithVersion(tt.controlPlaneVersion).Build(),
+			}
+			s.UpgradeTracker.ControlPlane.IsUpgrading = tt.controlPlaneUpgrading
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).Build()
+			r := &Reconciler{
+				Client:  fakeClient,
+				Tracker: remote.NewTestClusterCacheTracker(logr.Discard(), fakeClient, fakeScheme, client.ObjectKeyFromObject(cluster)),
+			}
+
+			_, err := r.reconcileAddons(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			condition := conditions.Get(cluster, clusterv1.TopologyAddonsReadyCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(condition.Severity).To(Equal(tt.wantCondition.Severity))
+		})
+	}
+}
+
+func TestReconcileAddonsDeletesRemovedAddons(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	cluster.Status.Addons = []clusterv1.ClusterAddonStatus{
+		{
+			Name:    "cni",
+			Version: "v1.0.0",
+			Objects: []clusterv1.AddonObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "cni-config"}},
+		},
+	}
+	cniConfig := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "kube-system",
+		Name:      "cni-config",
+		Labels: map[string]string{
+			clusterv1.ClusterLabelName:              cluster.Name,
+			clusterv1.ClusterTopologyAddonLabelName: "cni",
+		},
+	}}
+
+	s := scope.New(cluster)
+	s.Blueprint = &scope.ClusterBlueprint{
+		ClusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").Build(),
+	}
+	s.Current.ControlPlane = &scope.ControlPlaneState{
+		Object: builder.ControlPlane(metav1.NamespaceDefault, "cp1").WithVersion("v1.23.0").Build(),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(cniConfig).Build()
+	r := &Reconciler{
+		Client:  fakeClient,
+		Tracker: remote.NewTestClusterCacheTracker(logr.Discard(), fakeClient, fakeScheme, client.ObjectKeyFromObject(cluster)),
+	}
+
+	_, err := r.reconcileAddons(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(cniConfig), &corev1.ConfigMap{}))).To(BeTrue())
+	g.Expect(cluster.Status.Addons).To(BeNil())
+	g.Expect(conditions.Get(cluster, clusterv1.TopologyAddonsReadyCondition)).To(BeNil())
+}
+
+func TestGetAddonVersion(t *testing.T) {
+	addon := clusterv1.ClusterClassAddon{
+		Name: "cni",
+		Versions: []clusterv1.ClusterClassAddonVersion{
+			{Version: "v1.1.0", KubernetesVersions: ">=1.24.0"},
+			{Version: "v1.0.0", KubernetesVersions: ">=1.23.0 <1.24.0"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		kubernetesVersion string
+		want              string
+		wantErr           bool
+	}{
+		{
+			name:              "returns the first version supporting the Kubernetes version",
+			kubernetesVersion: "v1.24.2",
+			want:              "v1.1.0",
+		},
+		{
+			name:              "returns an older version for an older Kubernetes version",
+			kubernetesVersion: "v1.23.5",
+			want:              "v1.0.0",
+		},
+		{
+			name:              "ignores pre-release versions",
+			kubernetesVersion: "v1.24.0-rc.0",
+			want:              "v1.1.0",
+		},
+		{
+			name:              "fails if no version supports the Kubernetes version",
+			kubernetesVersion: "v1.22.0",
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := getAddonVersion(addon, semver.MustParse(tt.kubernetesVersion[1:]))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Version).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetAddonObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cni"},
+		Data: map[string]string{
+			"b-deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cni-controller
+  namespace: kube-system`,
+			"a-namespace.yaml": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cni-system
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cni
+  namespace: kube-system`,
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cni-credentials"},
+		Data: map[string][]byte{
+			"secret.yaml": []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: cni-credentials
+  namespace: kube-system`),
+		},
+	}
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(configMap, secret).Build(),
+	}
+
+	objs, err := r.getAddonObjects(ctx, metav1.NamespaceDefault, &clusterv1.ClusterClassAddonVersion{
+		Resources: []clusterv1.AddonResourceRef{
+			{Kind: "ConfigMap", Name: "cni"},
+			{Kind: "Secret", Name: "cni-credentials"},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	kinds := []string{}
+	for _, obj := range objs {
+		kinds = append(kinds, obj.GetKind())
+	}
+	// Objects are sorted for creation, e.g. Namespaces first.
+	g.Expect(kinds).To(Equal([]string{"Namespace", "Secret", "ServiceAccount", "Deployment"}))
+
+	_, err = r.getAddonObjects(ctx, metav1.NamespaceDefault, &clusterv1.ClusterClassAddonVersion{
+		Resources: []clusterv1.AddonResourceRef{{Kind: "ConfigMap", Name: "does-not-exist"}},
+	})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestIsAddonObjectReady(t *testing.T) {
+	obj := func(kind string, generation int64, spec, status map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"spec":       spec,
+			"status":     status,
+		}}
+		u.SetGeneration(generation)
+		return u
+	}
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want bool
+	}{
+		{
+			name: "objects other than workloads are ready",
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+			}},
+			want: true,
+		},
+		{
+			name: "Deployment is ready if all the replicas are updated and available",
+			obj: obj("Deployment", 2, map[string]interface{}{"replicas": int64(2)},
+				map[string]interface{}{"observedGeneration": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)}),
+			want: true,
+		},
+		{
+			name: "Deployment is not ready if the latest generation is not observed",
+			obj: obj("Deployment", 3, map[string]interface{}{"replicas": int64(2)},
+				map[string]interface{}{"observedGeneration": int64(2), "updatedReplicas": int64(2), "availableReplicas": int64(2)}),
+			want: false,
+		},
+		{
+			name: "Deployment is not ready if some replicas are not available",
+			obj: obj("Deployment", 2, map[string]interface{}{},
+				map[string]interface{}{"observedGeneration": int64(2), "updatedReplicas": int64(1), "availableReplicas": int64(0)}),
+			want: false,
+		},
+		{
+			name: "DaemonSet is ready if all the pods are updated and available",
+			obj: obj("DaemonSet", 1, map[string]interface{}{},
+				map[string]interface{}{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(3), "numberAvailable": int64(3)}),
+			want: true,
+		},
+		{
+			name: "DaemonSet is not ready if some pods are not updated",
+			obj: obj("DaemonSet", 1, map[string]interface{}{},
+				map[string]interface{}{"observedGeneration": int64(1), "desiredNumberScheduled": int64(3), "updatedNumberScheduled": int64(2), "numberAvailable": int64(3)}),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(isAddonObjectReady(tt.obj)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDeleteAddonObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+	configMap := func(name, addonName string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kube-system",
+			Name:      name,
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName:              cluster.Name,
+				clusterv1.ClusterTopologyAddonLabelName: addonName,
+			},
+		}}
+	}
+	owned := configMap("owned", "cni")
+	// moved has been moved to another add-on, so it must not be deleted.
+	moved := configMap("moved", "csi")
+	unlabeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "unlabeled"}}
+
+	c := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(owned, moved, unlabeled).Build()
+
+	refs := []clusterv1.AddonObjectReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "owned"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "moved"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "unlabeled"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "does-not-exist"},
+	}
+	g.Expect(deleteAddonObjects(ctx, c, cluster, "cni", refs)).To(Succeed())
+
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(owned), &corev1.ConfigMap{}))).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(moved), &corev1.ConfigMap{})).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(unlabeled), &corev1.ConfigMap{})).To(Succeed())
+}
+
+func TestStaleAddonObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := func(apiVersion, kind, namespace, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+
+	refs := []clusterv1.AddonObjectReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "kept"},
+		{APIVersion: "policy/v1beta1", Kind: "PodDisruptionBudget", Namespace: "kube-system", Name: "cni"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "removed"},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "kube-system", Name: "kept"},
+	}
+	objs := []unstructured.Unstructured{
+		obj("v1", "ConfigMap", "kube-system", "kept"),
+		// The API version of the PodDisruptionBudget changed, but it is still the same object.
+		obj("policy/v1", "PodDisruptionBudget", "kube-system", "cni"),
+		obj("v1", "ServiceAccount", "kube-system", "added"),
+	}
+
+	g.Expect(staleAddonObjects(refs, objs)).To(Equal([]clusterv1.AddonObjectReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "removed"},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "kube-system", Name: "kept"},
+	}))
+}
+
+func TestSetAndRemoveAddonStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+	configMap := unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace("kube-system")
+	configMap.SetName("cni-config")
+
+	setAddonStatus(cluster, "cni", "v1.0.0", []unstructured.Unstructured{configMap})
+	setAddonStatus(cluster, "csi", "v2.0.0", nil)
+	g.Expect(cluster.Status.Addons).To(HaveLen(2))
+	g.Expect(getAddonStatus(cluster, "cni")).To(Equal(&clusterv1.ClusterAddonStatus{
+		Name:    "cni",
+		Version: "v1.0.0",
+		Objects: []clusterv1.AddonObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "cni-config"}},
+	}))
+
+	// Setting the status of an existing add-on replaces it.
+	setAddonStatus(cluster, "cni", "v1.1.0", nil)
+	g.Expect(cluster.Status.Addons).To(HaveLen(2))
+	g.Expect(getAddonStatus(cluster, "cni")).To(Equal(&clusterv1.ClusterAddonStatus{Name: "cni", Version: "v1.1.0"}))
+
+	removeAddonStatus(cluster, "cni")
+	g.Expect(getAddonStatus(cluster, "cni")).To(BeNil())
+	removeAddonStatus(cluster, "csi")
+	g.Expect(cluster.Status.Addons).To(BeNil())
+}
@@ -18,13 +18,23 @@ package cluster
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/variables"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
+	"sigs.k8s.io/cluster-api/internal/topology/check"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
+	topologyvariables "sigs.k8s.io/cluster-api/internal/topology/variables"
 )
 
 // getBlueprint gets a ClusterBlueprint with the ClusterClass and the referenced templates to be used for a managed Cluster topology.
@@ -38,28 +48,53 @@ func (r *Reconciler) getBlueprint(ctx context.Context, cluster *clusterv1.Cluste
 	}
 
 	// Get ClusterClass.
-	key := client.ObjectKey{Name: cluster.Spec.Topology.Class, Namespace: cluster.Namespace}
+	// NOTE: The ClusterClass and the referenced templates can be in a different namespace than the Cluster.
+	key := cluster.GetClassKey()
 	if err := r.Client.Get(ctx, key, blueprint.ClusterClass); err != nil {
-		return nil, errors.Wrapf(err, "failed to retrieve ClusterClass/%s", cluster.Spec.Topology.Class)
+		return nil, errors.Wrapf(err, "failed to retrieve ClusterClass %s", key)
 	}
 
+	// Ensure the ClusterClass allows Clusters in the namespace of the Cluster.
+	// NOTE: This is checked by the Cluster webhook as well, but the allowed namespaces or the namespace labels
+	// could have been changed after the Cluster has been created.
+	if key.Namespace != cluster.Namespace {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: cluster.Namespace}, namespace); err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve Namespace %s", cluster.Namespace)
+		}
+		allowed, err := check.ClusterClassAllowsNamespace(blueprint.ClusterClass, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, errors.Errorf("ClusterClass %s does not allow Clusters in namespace %q", key, cluster.Namespace)
+		}
+	}
+
+	// Resolve the values of the variables sourced from ConfigMaps or Secrets.
+	// NOTE: Resolved values are stored only in the blueprint, and they are never written back to the Cluster.
 	var err error
+	blueprint.Topology, err = r.resolveTopologyVariables(ctx, cluster, blueprint.ClusterClass)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get ClusterClass.spec.infrastructure.
-	blueprint.InfrastructureClusterTemplate, err = r.getReference(ctx, blueprint.ClusterClass.Spec.Infrastructure.Ref)
+	blueprint.InfrastructureClusterTemplate, err = r.getClusterClassTemplate(ctx, blueprint.ClusterClass, blueprint.ClusterClass.Spec.Infrastructure.Ref)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get infrastructure cluster template for %s", tlog.KObj{Obj: blueprint.ClusterClass})
 	}
 
 	// Get ClusterClass.spec.controlPlane.
 	blueprint.ControlPlane = &scope.ControlPlaneBlueprint{}
-	blueprint.ControlPlane.Template, err = r.getReference(ctx, blueprint.ClusterClass.Spec.ControlPlane.Ref)
+	blueprint.ControlPlane.Template, err = r.getClusterClassTemplate(ctx, blueprint.ClusterClass, blueprint.ClusterClass.Spec.ControlPlane.Ref)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get control plane template for %s", tlog.KObj{Obj: blueprint.ClusterClass})
 	}
 
 	// If the clusterClass mandates the controlPlane has infrastructureMachines, read it.
 	if blueprint.HasControlPlaneInfrastructureMachine() {
-		blueprint.ControlPlane.InfrastructureMachineTemplate, err = r.getReference(ctx, blueprint.ClusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
+		blueprint.ControlPlane.InfrastructureMachineTemplate, err = r.getClusterClassTemplate(ctx, blueprint.ClusterClass, blueprint.ClusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get control plane's machine template for %s", tlog.KObj{Obj: blueprint.ClusterClass})
 		}
@@ -81,13 +116,13 @@ func (r *Reconciler) getBlueprint(ctx context.Context, cluster *clusterv1.Cluste
 		machineDeploymentClass.Template.Metadata.DeepCopyInto(&machineDeploymentBlueprint.Metadata)
 
 		// Get the infrastructure machine template.
-		machineDeploymentBlueprint.InfrastructureMachineTemplate, err = r.getReference(ctx, machineDeploymentClass.Template.Infrastructure.Ref)
+		machineDeploymentBlueprint.InfrastructureMachineTemplate, err = r.getClusterClassTemplate(ctx, blueprint.ClusterClass, machineDeploymentClass.Template.Infrastructure.Ref)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get infrastructure machine template for %s, MachineDeployment class %q", tlog.KObj{Obj: blueprint.ClusterClass}, machineDeploymentClass.Class)
 		}
 
 		// Get the bootstrap machine template.
-		machineDeploymentBlueprint.BootstrapTemplate, err = r.getReference(ctx, machineDeploymentClass.Template.Bootstrap.Ref)
+		machineDeploymentBlueprint.BootstrapTemplate, err = r.getClusterClassTemplate(ctx, blueprint.ClusterClass, machineDeploymentClass.Template.Bootstrap.Ref)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get bootstrap machine template for %s, MachineDeployment class %q", tlog.KObj{Obj: blueprint.ClusterClass}, machineDeploymentClass.Class)
 		}
@@ -96,8 +131,77 @@ func (r *Reconciler) getBlueprint(ctx context.Context, cluster *clusterv1.Cluste
 		if machineDeploymentClass.MachineHealthCheck != nil {
 			machineDeploymentBlueprint.MachineHealthCheck = machineDeploymentClass.MachineHealthCheck
 		}
+		machineDeploymentBlueprint.MinReadySeconds = machineDeploymentClass.MinReadySeconds
+		machineDeploymentBlueprint.Strategy = machineDeploymentClass.Strategy
 		blueprint.MachineDeployments[machineDeploymentClass.Class] = machineDeploymentBlueprint
 	}
 
 	return blueprint, nil
 }
+
+// getClusterClassTemplate gets a template referenced by the ClusterClass.
+// If the ClusterClass controller already created a revision for the current content of the template, the revision
+// is returned instead of the template, so the objects of the managed topology are created from an immutable copy
+// of the template.
+func (r *Reconciler) getClusterClassTemplate(ctx context.Context, clusterClass *clusterv1.ClusterClass, ref *corev1.ObjectReference) (*unstructured.Unstructured, error) {
+	template, err := r.getReference(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := revisions.ComputeHash(clusterClass, template)
+	if err != nil {
+		return nil, err
+	}
+	revision := revisions.Get(clusterClass, ref, hash)
+	if revision == nil {
+		return template, nil
+	}
+
+	revisionObj, err := r.getReference(ctx, revision.RevisionRef.DeepCopy())
+	if err != nil {
+		// If the revision has been deleted in the meantime, fall back to the template; a new revision is going
+		// to be created by the ClusterClass controller.
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return template, nil
+		}
+		return nil, err
+	}
+	return revisionObj, nil
+}
+
+// resolveTopologyVariables returns the Cluster topology with the values of the variables sourced from
+// ConfigMaps or Secrets resolved, after validating them against the schemas defined in the ClusterClass.
+// NOTE: The webhooks can only validate variables with an inline value, so variables sourced from
+// ConfigMaps or Secrets are validated here.
+func (r *Reconciler) resolveTopologyVariables(ctx context.Context, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) (*clusterv1.Topology, error) {
+	if !variables.HasValueFrom(cluster.Spec.Topology) {
+		return cluster.Spec.Topology, nil
+	}
+
+	topology, err := variables.ResolveValueFrom(ctx, r.Client, cluster.Namespace, cluster.Spec.Topology, clusterClass.Spec.Variables)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve variables")
+	}
+
+	fldPath := field.NewPath("spec", "topology")
+	allErrs := topologyvariables.ValidateClusterVariables(topology.Variables, clusterClass.Spec.Variables, fldPath.Child("variables"))
+	if topology.Workers != nil {
+		for i, md := range topology.Workers.MachineDeployments {
+			if md.Variables == nil || len(md.Variables.Overrides) == 0 {
+				continue
+			}
+			allErrs = append(allErrs, topologyvariables.ValidateMachineDeploymentVariables(md.Variables.Overrides, clusterClass.Spec.Variables,
+				fldPath.Child("workers", "machineDeployments").Index(i).Child("variables", "overrides"))...)
+		}
+	}
+	if len(allErrs) > 0 {
+		// Omit the invalid values from errors, given that they could have been read from Secrets.
+		msgs := make([]string, 0, len(allErrs))
+		for _, err := range allErrs {
+			msgs = append(msgs, fmt.Sprintf("%s: %s: %s", err.Field, err.Type, err.Detail))
+		}
+		return nil, errors.Errorf("invalid variables: %s", strings.Join(msgs, "; "))
+	}
+	return topology, nil
+}
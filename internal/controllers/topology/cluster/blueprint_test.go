@@ -22,14 +22,18 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	. "sigs.k8s.io/cluster-api/internal/test/matchers"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
 )
 
 func TestGetBlueprint(t *testing.T) {
@@ -341,3 +345,263 @@ func TestGetBlueprint(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlueprintWithClusterClassInAnotherNamespace(t *testing.T) {
+	crds := []client.Object{
+		builder.GenericInfrastructureClusterTemplateCRD,
+		builder.GenericControlPlaneTemplateCRD,
+	}
+
+	infraClusterTemplate := builder.InfrastructureClusterTemplate("platform", "infraclustertemplate1").
+		Build()
+	controlPlaneTemplate := builder.ControlPlaneTemplate("platform", "controlplanetemplate1").
+		Build()
+
+	tenantNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "tenant",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		allowedNamespaces *clusterv1.ClusterClassAllowedNamespaces
+		wantErr           bool
+	}{
+		{
+			name:    "Fails if the ClusterClass does not define allowed namespaces",
+			wantErr: true,
+		},
+		{
+			name: "Fails if the ClusterClass does not allow the namespace of the Cluster",
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Names:    []string{"other"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should read a ClusterClass allowing the namespace of the Cluster by name",
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Names: []string{"tenant"},
+			},
+		},
+		{
+			name: "Should read a ClusterClass allowing the namespace of the Cluster by selector",
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass("platform", "class1").
+				WithInfrastructureClusterTemplate(infraClusterTemplate).
+				WithControlPlaneTemplate(controlPlaneTemplate).
+				Build()
+			clusterClass.Spec.AllowedNamespaces = tt.allowedNamespaces
+
+			cluster := builder.Cluster("tenant", "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("class1").
+						Build()).
+				Build()
+			cluster.Spec.Topology.ClassNamespace = "platform"
+
+			objs := []client.Object{}
+			objs = append(objs, crds...)
+			objs = append(objs, tenantNamespace, clusterClass, infraClusterTemplate, controlPlaneTemplate)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(objs...).
+				Build()
+
+			r := &Reconciler{
+				Client:                    fakeClient,
+				patchHelperFactory:        dryRunPatchHelperFactory(fakeClient),
+				UnstructuredCachingClient: fakeClient,
+			}
+			got, err := r.getBlueprint(ctx, scope.New(cluster).Current.Cluster)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			// Templates are read from the namespace of the ClusterClass.
+			g.Expect(got.ClusterClass.Namespace).To(Equal("platform"))
+			g.Expect(got.InfrastructureClusterTemplate.GetNamespace()).To(Equal("platform"))
+			g.Expect(got.ControlPlane.Template.GetNamespace()).To(Equal("platform"))
+		})
+	}
+}
+
+func TestGetClusterClassTemplate(t *testing.T) {
+	infraClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraclustertemplate1").
+		WithSpecFields(map[string]interface{}{"spec.template.spec.fakeSetting": true}).
+		Build()
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithInfrastructureClusterTemplate(infraClusterTemplate).
+		Build()
+
+	hash, err := revisions.ComputeHash(clusterClass, infraClusterTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revision := infraClusterTemplate.DeepCopy()
+	revision.SetName(revisions.Name(infraClusterTemplate.GetName(), hash))
+
+	tests := []struct {
+		name              string
+		templateRevisions []clusterv1.ClusterClassTemplateRevision
+		objects           []client.Object
+		wantName          string
+	}{
+		{
+			name:     "Should return the template if there are no revisions",
+			objects:  []client.Object{infraClusterTemplate, revision},
+			wantName: infraClusterTemplate.GetName(),
+		},
+		{
+			name: "Should return the revision matching the content of the template",
+			templateRevisions: []clusterv1.ClusterClassTemplateRevision{
+				{TemplateRef: *contract.ObjToRef(infraClusterTemplate), RevisionRef: *contract.ObjToRef(revision), Hash: hash},
+			},
+			objects:  []client.Object{infraClusterTemplate, revision},
+			wantName: revision.GetName(),
+		},
+		{
+			name: "Should return the template if no revision matches the content of the template",
+			templateRevisions: []clusterv1.ClusterClassTemplateRevision{
+				{TemplateRef: *contract.ObjToRef(infraClusterTemplate), RevisionRef: *contract.ObjToRef(revision), Hash: "outdated"},
+			},
+			objects:  []client.Object{infraClusterTemplate, revision},
+			wantName: infraClusterTemplate.GetName(),
+		},
+		{
+			name: "Should return the template if the revision does not exist",
+			templateRevisions: []clusterv1.ClusterClassTemplateRevision{
+				{TemplateRef: *contract.ObjToRef(infraClusterTemplate), RevisionRef: *contract.ObjToRef(revision), Hash: hash},
+			},
+			objects:  []client.Object{infraClusterTemplate},
+			wantName: infraClusterTemplate.GetName(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := clusterClass.DeepCopy()
+			clusterClass.Status.TemplateRevisions = tt.templateRevisions
+
+			objs := []client.Object{builder.GenericInfrastructureClusterTemplateCRD}
+			for _, obj := range tt.objects {
+				objs = append(objs, obj.DeepCopyObject().(client.Object))
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(objs...).
+				Build()
+
+			r := &Reconciler{
+				Client:                    fakeClient,
+				UnstructuredCachingClient: fakeClient,
+			}
+			got, err := r.getClusterClassTemplate(ctx, clusterClass, clusterClass.Spec.Infrastructure.Ref)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got.GetName()).To(Equal(tt.wantName))
+		})
+	}
+}
+
+func TestResolveTopologyVariables(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "machine-config",
+		},
+		Data: map[string][]byte{
+			"cpu":     []byte("8"),
+			"invalid": []byte("not-a-number"),
+		},
+	}
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithVariables(clusterv1.ClusterClassVariable{
+			Name:     "cpu",
+			Required: true,
+			Schema: clusterv1.VariableSchema{
+				OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+					Type: "integer",
+				},
+			},
+		}).
+		Build()
+	cpuFromSecret := func(key string) clusterv1.ClusterVariable {
+		return clusterv1.ClusterVariable{
+			Name: "cpu",
+			ValueFrom: &clusterv1.ClusterVariableSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		variables []clusterv1.ClusterVariable
+		want      []clusterv1.ClusterVariable
+		wantErr   bool
+	}{
+		{
+			name:      "Returns variables with inline values as is",
+			variables: []clusterv1.ClusterVariable{{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`4`)}}},
+			want:      []clusterv1.ClusterVariable{{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`4`)}}},
+		},
+		{
+			name:      "Resolves variables sourced from a Secret",
+			variables: []clusterv1.ClusterVariable{cpuFromSecret("cpu")},
+			want:      []clusterv1.ClusterVariable{{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`8`)}}},
+		},
+		{
+			name:      "Fails if a resolved value is not valid",
+			variables: []clusterv1.ClusterVariable{cpuFromSecret("invalid")},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass(clusterClass.Name).
+						WithVariables(tt.variables...).
+						Build()).
+				Build()
+			original := cluster.DeepCopy()
+
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(secret).Build(),
+			}
+			got, err := r.resolveTopologyVariables(ctx, cluster, clusterClass)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				// Values read from Secrets must not be surfaced in errors.
+				g.Expect(err.Error()).ToNot(ContainSubstring("not-a-number"))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Variables).To(Equal(tt.want))
+
+			// Resolved values must never be written back to the Cluster.
+			g.Expect(cluster).To(Equal(original))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -37,6 +38,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1/index"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/feature"
@@ -58,7 +60,9 @@ import (
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinehealthchecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // Reconciler reconciles a managed topology for a Cluster object.
 type Reconciler struct {
@@ -69,6 +73,9 @@ type Reconciler struct {
 
 	RuntimeClient runtimeclient.Client
 
+	// Tracker is used to apply the add-ons defined in the ClusterClass to the workload cluster.
+	Tracker *remote.ClusterCacheTracker
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
@@ -102,6 +109,16 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			// Only trigger Cluster reconciliation if the MachineDeployment is topology owned.
 			builder.WithPredicates(predicates.ResourceIsTopologyOwned(ctrl.LoggerFrom(ctx))),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.configMapToCluster),
+			builder.OnlyMetadata,
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.secretToCluster),
+			builder.OnlyMetadata,
+		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Build(r)
@@ -185,6 +202,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		options := []patch.Option{
 			patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 				clusterv1.TopologyReconciledCondition,
+				clusterv1.TopologyAddonsReadyCondition,
 			}},
 			patch.WithForceOverwriteConditions{},
 		}
@@ -242,13 +260,19 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 		return ctrl.Result{}, errors.Wrap(err, "error reconciling the Cluster topology")
 	}
 
+	// Reconciles the add-ons defined in the ClusterClass into the workload cluster.
+	addonsResult, err := r.reconcileAddons(ctx, s)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "error reconciling the Cluster add-ons")
+	}
+
 	// requeueAfter will not be 0 if any of the runtime hooks returns a blocking response.
 	requeueAfter := s.HookResponseTracker.AggregateRetryAfter()
 	if requeueAfter != 0 {
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		return util.LowestNonZeroResult(ctrl.Result{RequeueAfter: requeueAfter}, addonsResult), nil
 	}
 
-	return ctrl.Result{}, nil
+	return addonsResult, nil
 }
 
 // setupDynamicWatches create watches for InfrastructureCluster and ControlPlane CRs when they exist.
@@ -301,12 +325,12 @@ func (r *Reconciler) clusterClassToCluster(o client.Object) []ctrl.Request {
 		panic(fmt.Sprintf("Expected a ClusterClass but got a %T", o))
 	}
 
+	// NOTE: Clusters can use a ClusterClass in another namespace, so Clusters are listed in all namespaces.
 	clusterList := &clusterv1.ClusterList{}
 	if err := r.Client.List(
 		context.TODO(),
 		clusterList,
-		client.MatchingFields{index.ClusterClassNameField: clusterClass.Name},
-		client.InNamespace(clusterClass.Namespace),
+		client.MatchingFields{index.ClusterClassRefPath: util.ObjectKey(clusterClass).String()},
 	); err != nil {
 		return nil
 	}
@@ -339,6 +363,102 @@ func (r *Reconciler) machineDeploymentToCluster(o client.Object) []ctrl.Request
 	}}
 }
 
+// configMapToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Cluster to update when a ConfigMap referenced by its variables, or by the add-ons of its ClusterClass, gets updated.
+func (r *Reconciler) configMapToCluster(o client.Object) []ctrl.Request {
+	requests := r.variableSourceToCluster(o, func(valueFrom *clusterv1.ClusterVariableSource) bool {
+		return valueFrom.ConfigMapKeyRef != nil && valueFrom.ConfigMapKeyRef.Name == o.GetName()
+	})
+	return append(requests, r.addonResourceToCluster(o, "ConfigMap")...)
+}
+
+// secretToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Cluster to update when a Secret referenced by its variables, or by the add-ons of its ClusterClass, gets updated.
+func (r *Reconciler) secretToCluster(o client.Object) []ctrl.Request {
+	requests := r.variableSourceToCluster(o, func(valueFrom *clusterv1.ClusterVariableSource) bool {
+		return valueFrom.SecretKeyRef != nil && valueFrom.SecretKeyRef.Name == o.GetName()
+	})
+	return append(requests, r.addonResourceToCluster(o, "Secret")...)
+}
+
+// addonResourceToCluster returns requests for all the Clusters using a ClusterClass with at least one add-on
+// version whose manifests are stored in the given object.
+// NOTE: The ConfigMaps and Secrets of the add-ons are in the namespace of the ClusterClass.
+func (r *Reconciler) addonResourceToCluster(o client.Object, kind string) []ctrl.Request {
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := r.Client.List(context.TODO(), clusterClassList, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+
+	requests := []ctrl.Request{}
+	for i := range clusterClassList.Items {
+		if clusterClassAddonsReference(&clusterClassList.Items[i], kind, o.GetName()) {
+			requests = append(requests, r.clusterClassToCluster(&clusterClassList.Items[i])...)
+		}
+	}
+	return requests
+}
+
+// clusterClassAddonsReference returns true if any version of the add-ons of the ClusterClass
+// references the resource with the given kind and name.
+func clusterClassAddonsReference(clusterClass *clusterv1.ClusterClass, kind, name string) bool {
+	for _, addon := range clusterClass.Spec.Addons {
+		for _, version := range addon.Versions {
+			for _, resource := range version.Resources {
+				if resource.Kind == kind && resource.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// variableSourceToCluster returns requests for all the Clusters in the namespace of the given object
+// having at least one variable, or variable override, sourced from the object.
+func (r *Reconciler) variableSourceToCluster(o client.Object, referencesObject func(*clusterv1.ClusterVariableSource) bool) []ctrl.Request {
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(context.TODO(), clusterList, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+
+	requests := []ctrl.Request{}
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.Spec.Topology == nil {
+			continue
+		}
+		if clusterVariablesReference(cluster.Spec.Topology, referencesObject) {
+			requests = append(requests, ctrl.Request{NamespacedName: util.ObjectKey(cluster)})
+		}
+	}
+	return requests
+}
+
+// clusterVariablesReference returns true if any of the variables of the topology, including the
+// MachineDeployment overrides, has a valueFrom matching the given func.
+func clusterVariablesReference(topology *clusterv1.Topology, referencesObject func(*clusterv1.ClusterVariableSource) bool) bool {
+	for _, variable := range topology.Variables {
+		if variable.ValueFrom != nil && referencesObject(variable.ValueFrom) {
+			return true
+		}
+	}
+	if topology.Workers == nil {
+		return false
+	}
+	for _, md := range topology.Workers.MachineDeployments {
+		if md.Variables == nil {
+			continue
+		}
+		for _, variable := range md.Variables.Overrides {
+			if variable.ValueFrom != nil && referencesObject(variable.ValueFrom) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Cluster) (ctrl.Result, error) {
 	// Call the BeforeClusterDelete hook if the 'ok-to-delete' annotation is not set
 	// and add the annotation to the cluster after receiving a successful non-blocking response.
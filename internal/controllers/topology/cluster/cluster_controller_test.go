@@ -686,6 +686,108 @@ func TestReconciler_callBeforeClusterCreateHook(t *testing.T) {
 	}
 }
 
+func TestReconciler_variableSourceToCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	configMapRef := clusterv1.ClusterVariable{
+		Name: "location",
+		ValueFrom: &clusterv1.ClusterVariableSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+				Key:                  "location",
+			},
+		},
+	}
+	secretRef := clusterv1.ClusterVariable{
+		Name: "token",
+		ValueFrom: &clusterv1.ClusterVariableSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	// cluster1 references the ConfigMap in the top-level variables.
+	cluster1 := builder.Cluster(metav1.NamespaceDefault, clusterName1).
+		WithTopology(builder.ClusterTopology().
+			WithClass(clusterClassName1).
+			WithVariables(configMapRef).
+			Build()).
+		Build()
+	// cluster2 references the Secret in a MachineDeployment variable override.
+	cluster2 := builder.Cluster(metav1.NamespaceDefault, clusterName2).
+		WithTopology(builder.ClusterTopology().
+			WithClass(clusterClassName1).
+			WithMachineDeployment(builder.MachineDeploymentTopology("md1").
+				WithClass("linux-worker").
+				WithVariables(secretRef).
+				Build()).
+			Build()).
+		Build()
+	// cluster3 is in another namespace.
+	cluster3 := builder.Cluster("other", clusterName1).
+		WithTopology(builder.ClusterTopology().
+			WithClass(clusterClassName1).
+			WithVariables(configMapRef, secretRef).
+			Build()).
+		Build()
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(fakeScheme).
+		WithObjects(cluster1, cluster2, cluster3).
+		Build()
+	r := &Reconciler{Client: fakeClient}
+
+	configMap := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "config"}}
+	g.Expect(r.configMapToCluster(configMap)).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster1)}))
+	g.Expect(r.secretToCluster(configMap)).To(BeEmpty())
+
+	secret := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "credentials"}}
+	g.Expect(r.secretToCluster(secret)).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster2)}))
+	g.Expect(r.configMapToCluster(secret)).To(BeEmpty())
+}
+
+func TestReconciler_addonResourceToCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, clusterClassName1).Build()
+	clusterClass.Spec.Addons = []clusterv1.ClusterClassAddon{
+		{
+			Name: "cni",
+			Versions: []clusterv1.ClusterClassAddonVersion{
+				{Version: "v1.0.0", Resources: []clusterv1.AddonResourceRef{{Kind: "ConfigMap", Name: "cni-v1.0.0"}}},
+				{Version: "v1.1.0", Resources: []clusterv1.AddonResourceRef{{Kind: "Secret", Name: "cni-v1.1.0"}}},
+			},
+		},
+	}
+	cluster1 := builder.Cluster(metav1.NamespaceDefault, clusterName1).
+		WithTopology(builder.ClusterTopology().WithClass(clusterClassName1).Build()).
+		Build()
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(fakeScheme).
+		WithObjects(clusterClass, cluster1).
+		Build()
+	r := &Reconciler{Client: fakeClient}
+
+	configMap := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cni-v1.0.0"}}
+	g.Expect(r.configMapToCluster(configMap)).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster1)}))
+	g.Expect(r.secretToCluster(configMap)).To(BeEmpty())
+
+	secret := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cni-v1.1.0"}}
+	g.Expect(r.secretToCluster(secret)).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster1)}))
+	g.Expect(r.configMapToCluster(secret)).To(BeEmpty())
+
+	// Resources not referenced by any add-on are ignored.
+	unrelated := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "unrelated"}}
+	g.Expect(r.configMapToCluster(unrelated)).To(BeEmpty())
+
+	// Add-on resources are looked up in the namespace of the ClusterClass.
+	otherNamespace := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "cni-v1.0.0"}}
+	g.Expect(r.configMapToCluster(otherNamespace)).To(BeEmpty())
+}
+
 // setupTestEnvForIntegrationTests builds and then creates in the envtest API server all objects required at init time for each of the
 // integration tests in this file. This includes:
 // - a first clusterClass with all the related templates
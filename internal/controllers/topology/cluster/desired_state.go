@@ -19,6 +19,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -108,7 +109,7 @@ func (r *Reconciler) computeDesiredState(ctx context.Context, s *scope.Scope) (*
 // corresponding template defined in the blueprint.
 func computeInfrastructureCluster(_ context.Context, s *scope.Scope) (*unstructured.Unstructured, error) {
 	template := s.Blueprint.InfrastructureClusterTemplate
-	templateClonedFromRef := s.Blueprint.ClusterClass.Spec.Infrastructure.Ref
+	templateClonedFromRef := contract.ObjToRef(template)
 	cluster := s.Current.Cluster
 	currentRef := cluster.Spec.InfrastructureRef
 
@@ -141,7 +142,7 @@ func computeInfrastructureCluster(_ context.Context, s *scope.Scope) (*unstructu
 // that should be referenced by the ControlPlane object.
 func computeControlPlaneInfrastructureMachineTemplate(_ context.Context, s *scope.Scope) (*unstructured.Unstructured, error) {
 	template := s.Blueprint.ControlPlane.InfrastructureMachineTemplate
-	templateClonedFromRef := s.Blueprint.ClusterClass.Spec.ControlPlane.MachineInfrastructure.Ref
+	templateClonedFromRef := contract.ObjToRef(template)
 	cluster := s.Current.Cluster
 
 	// Check if the current control plane object has a machineTemplate.infrastructureRef already defined.
@@ -172,7 +173,7 @@ func computeControlPlaneInfrastructureMachineTemplate(_ context.Context, s *scop
 // corresponding template defined in the blueprint.
 func (r *Reconciler) computeControlPlane(ctx context.Context, s *scope.Scope, infrastructureMachineTemplate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	template := s.Blueprint.ControlPlane.Template
-	templateClonedFromRef := s.Blueprint.ClusterClass.Spec.ControlPlane.Ref
+	templateClonedFromRef := contract.ObjToRef(template)
 	cluster := s.Current.Cluster
 	currentRef := cluster.Spec.ControlPlaneRef
 
@@ -512,6 +513,16 @@ func computeMachineDeployment(_ context.Context, s *scope.Scope, desiredControlP
 		return nil, errors.Wrapf(err, "failed to compute version for %s", machineDeploymentTopology.Name)
 	}
 
+	// Compute MinReadySeconds and Strategy; values from the Cluster topology take precedence over the ones from the ClusterClass.
+	minReadySeconds := machineDeploymentBlueprint.MinReadySeconds
+	if machineDeploymentTopology.MinReadySeconds != nil {
+		minReadySeconds = machineDeploymentTopology.MinReadySeconds
+	}
+	strategy := machineDeploymentBlueprint.Strategy
+	if machineDeploymentTopology.Strategy != nil {
+		strategy = machineDeploymentTopology.Strategy
+	}
+
 	// Compute the MachineDeployment object.
 	gv := clusterv1.GroupVersion
 	desiredMachineDeploymentObj := &clusterv1.MachineDeployment{
@@ -524,7 +535,9 @@ func computeMachineDeployment(_ context.Context, s *scope.Scope, desiredControlP
 			Namespace: s.Current.Cluster.Namespace,
 		},
 		Spec: clusterv1.MachineDeploymentSpec{
-			ClusterName: s.Current.Cluster.Name,
+			ClusterName:     s.Current.Cluster.Name,
+			MinReadySeconds: minReadySeconds,
+			Strategy:        strategy.DeepCopy(),
 			Template: clusterv1.MachineTemplateSpec{
 				ObjectMeta: clusterv1.ObjectMeta{
 					Labels:      mergeMap(machineDeploymentTopology.Metadata.Labels, machineDeploymentBlueprint.Metadata.Labels),
@@ -583,22 +596,60 @@ func computeMachineDeployment(_ context.Context, s *scope.Scope, desiredControlP
 	desiredMachineDeploymentObj.Spec.Template.Labels[clusterv1.ClusterTopologyMachineDeploymentLabelName] = machineDeploymentTopology.Name
 
 	// Set the desired replicas.
-	desiredMachineDeploymentObj.Spec.Replicas = machineDeploymentTopology.Replicas
+	desiredMachineDeploymentObj.Spec.Replicas = computeMachineDeploymentReplicas(machineDeploymentTopology, currentMachineDeployment)
+
+	// If the MachineDeployment is managed by cluster-autoscaler, set the min and max size annotations, otherwise
+	// drop them so they are removed from the MachineDeployment when autoscaling is switched off.
+	annotations := desiredMachineDeploymentObj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if machineDeploymentTopology.Autoscaling != nil {
+		annotations[clusterv1.AutoscalerMinSizeAnnotation] = strconv.Itoa(int(machineDeploymentTopology.Autoscaling.MinSize))
+		annotations[clusterv1.AutoscalerMaxSizeAnnotation] = strconv.Itoa(int(machineDeploymentTopology.Autoscaling.MaxSize))
+	} else {
+		delete(annotations, clusterv1.AutoscalerMinSizeAnnotation)
+		delete(annotations, clusterv1.AutoscalerMaxSizeAnnotation)
+	}
+	if len(annotations) > 0 {
+		desiredMachineDeploymentObj.SetAnnotations(annotations)
+	}
 
 	desiredMachineDeployment.Object = desiredMachineDeploymentObj
 
-	// If the ClusterClass defines a MachineHealthCheck for the MachineDeployment add it to the desired state.
-	if machineDeploymentBlueprint.MachineHealthCheck != nil {
+	// If a MachineHealthCheck is defined for the MachineDeployment, either in the Cluster topology or in the ClusterClass,
+	// and it is not disabled in the Cluster topology, add it to the desired state.
+	if s.Blueprint.IsMachineDeploymentMachineHealthCheckEnabled(&machineDeploymentTopology) {
 		// Note: The MHC is going to use a selector that provides a minimal set of labels which are common to all MachineSets belonging to the MachineDeployment.
 		desiredMachineDeployment.MachineHealthCheck = computeMachineHealthCheck(
 			desiredMachineDeploymentObj,
 			selectorForMachineDeploymentMHC(desiredMachineDeploymentObj),
 			s.Current.Cluster.Name,
-			machineDeploymentBlueprint.MachineHealthCheck)
+			s.Blueprint.MachineDeploymentMachineHealthCheckClass(&machineDeploymentTopology))
 	}
 	return desiredMachineDeployment, nil
 }
 
+// computeMachineDeploymentReplicas computes the desired replicas of a MachineDeployment.
+// If the number of replicas is not managed by the topology, either because replicas are not set or because
+// the MachineDeployment is managed by cluster-autoscaler, replicas are left unset for existing MachineDeployments,
+// so spec.replicas is not part of the intent applied by the topology controller and the value set by the external
+// entity is never changed; new MachineDeployments managed by cluster-autoscaler are created with MinSize replicas.
+func computeMachineDeploymentReplicas(machineDeploymentTopology clusterv1.MachineDeploymentTopology, currentMachineDeployment *scope.MachineDeploymentState) *int32 {
+	if machineDeploymentTopology.Autoscaling == nil && machineDeploymentTopology.Replicas != nil {
+		return machineDeploymentTopology.Replicas
+	}
+
+	if currentMachineDeployment != nil && currentMachineDeployment.Object != nil {
+		return nil
+	}
+
+	if machineDeploymentTopology.Autoscaling != nil {
+		return pointer.Int32(machineDeploymentTopology.Autoscaling.MinSize)
+	}
+	return nil
+}
+
 // computeMachineDeploymentVersion calculates the version of the desired machine deployment.
 // The version is calculated using the state of the current machine deployments,
 // the current control plane and the version defined in the topology.
@@ -766,6 +817,9 @@ func templateToTemplate(in templateToInput) *unstructured.Unstructured {
 	template.SetUID("")
 	template.SetSelfLink("")
 
+	// Ensure the template is cloned in the namespace of the Cluster, even if the ClusterClass is in another namespace.
+	template.SetNamespace(in.cluster.Namespace)
+
 	// Enforce the topology labels into the provided label set.
 	// NOTE: The cluster label is added at creation time so this object could be read by the ClusterTopology
 	// controller immediately after creation, even before other controllers are going to add the label (if missing).
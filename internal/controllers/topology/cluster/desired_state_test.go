@@ -1583,6 +1583,274 @@ func TestComputeMachineDeployment(t *testing.T) {
 		// Check that UnhealthyConditions are set as expected.
 		g.Expect(actual.MachineHealthCheck.Spec.UnhealthyConditions).To(Equal(unhealthyConditions))
 	})
+
+	t.Run("Should set the autoscaler annotations if autoscaling is set", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class:       "linux-worker",
+			Name:        "big-pool-of-machines",
+			Autoscaling: &clusterv1.MachineDeploymentAutoscaling{MinSize: 1, MaxSize: 5},
+		}
+
+		actual, err := computeMachineDeployment(ctx, scope, nil, mdTopology)
+		g.Expect(err).To(BeNil())
+		g.Expect(actual.Object.Annotations).To(HaveKeyWithValue(clusterv1.AutoscalerMinSizeAnnotation, "1"))
+		g.Expect(actual.Object.Annotations).To(HaveKeyWithValue(clusterv1.AutoscalerMaxSizeAnnotation, "5"))
+		g.Expect(*actual.Object.Spec.Replicas).To(Equal(int32(1)))
+	})
+
+	t.Run("Should not set replicas for an existing MachineDeployment if autoscaling is set", func(t *testing.T) {
+		g := NewWithT(t)
+		s := scope.New(cluster)
+		s.Blueprint = blueprint
+
+		// The current MachineDeployment has been scaled by cluster-autoscaler.
+		currentMd := &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "existing-deployment-1",
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: pointer.Int32(4),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: pointer.String("v1.21.2"),
+					},
+				},
+			},
+		}
+		s.Current.MachineDeployments = map[string]*scope.MachineDeploymentState{
+			"big-pool-of-machines": {
+				Object:                        currentMd,
+				BootstrapTemplate:             workerBootstrapTemplate,
+				InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+			},
+		}
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class:       "linux-worker",
+			Name:        "big-pool-of-machines",
+			Autoscaling: &clusterv1.MachineDeploymentAutoscaling{MinSize: 1, MaxSize: 5},
+		}
+
+		actual, err := computeMachineDeployment(ctx, s, nil, mdTopology)
+		g.Expect(err).To(BeNil())
+		g.Expect(actual.Object.Spec.Replicas).To(BeNil())
+		g.Expect(actual.Object.Annotations).To(HaveKeyWithValue(clusterv1.AutoscalerMinSizeAnnotation, "1"))
+		g.Expect(actual.Object.Annotations).To(HaveKeyWithValue(clusterv1.AutoscalerMaxSizeAnnotation, "5"))
+	})
+
+	t.Run("Should drop the autoscaler annotations if autoscaling is switched off", func(t *testing.T) {
+		g := NewWithT(t)
+		s := scope.New(cluster)
+		s.Blueprint = blueprint
+
+		// The current MachineDeployment has been managed by cluster-autoscaler.
+		currentMd := &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "existing-deployment-1",
+				Annotations: map[string]string{
+					clusterv1.AutoscalerMinSizeAnnotation: "1",
+					clusterv1.AutoscalerMaxSizeAnnotation: "5",
+				},
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: pointer.Int32(4),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: pointer.String("v1.21.2"),
+					},
+				},
+			},
+		}
+		s.Current.MachineDeployments = map[string]*scope.MachineDeploymentState{
+			"big-pool-of-machines": {
+				Object:                        currentMd,
+				BootstrapTemplate:             workerBootstrapTemplate,
+				InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+			},
+		}
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class:    "linux-worker",
+			Name:     "big-pool-of-machines",
+			Replicas: pointer.Int32(3),
+		}
+
+		actual, err := computeMachineDeployment(ctx, s, nil, mdTopology)
+		g.Expect(err).To(BeNil())
+		g.Expect(actual.Object.Annotations).ToNot(HaveKey(clusterv1.AutoscalerMinSizeAnnotation))
+		g.Expect(actual.Object.Annotations).ToNot(HaveKey(clusterv1.AutoscalerMaxSizeAnnotation))
+		g.Expect(*actual.Object.Spec.Replicas).To(Equal(int32(3)))
+	})
+
+	t.Run("Should use the MachineHealthCheck from the Cluster topology if defined", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+		topologyNodeTimeoutDuration := &metav1.Duration{Duration: 20 * time.Minute}
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class: "linux-worker",
+			Name:  "big-pool-of-machines",
+			MachineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				MachineHealthCheckClass: clusterv1.MachineHealthCheckClass{
+					UnhealthyConditions: unhealthyConditions[:1],
+					NodeStartupTimeout:  topologyNodeTimeoutDuration,
+				},
+			},
+		}
+
+		actual, err := computeMachineDeployment(ctx, scope, nil, mdTopology)
+		g.Expect(err).To(BeNil())
+		g.Expect(actual.MachineHealthCheck.Spec.NodeStartupTimeout).To(Equal(topologyNodeTimeoutDuration))
+		g.Expect(actual.MachineHealthCheck.Spec.UnhealthyConditions).To(Equal(unhealthyConditions[:1]))
+	})
+
+	t.Run("Should not generate a MachineHealthCheck if disabled in the Cluster topology", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class: "linux-worker",
+			Name:  "big-pool-of-machines",
+			MachineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				Enable: pointer.Bool(false),
+			},
+		}
+
+		actual, err := computeMachineDeployment(ctx, scope, nil, mdTopology)
+		g.Expect(err).To(BeNil())
+		g.Expect(actual.MachineHealthCheck).To(BeNil())
+	})
+
+	t.Run("Should set MinReadySeconds and Strategy, with values from the Cluster topology taking precedence", func(t *testing.T) {
+		classMinReadySeconds := int32(10)
+		classStrategy := &clusterv1.MachineDeploymentStrategy{
+			Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+			RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+				DeletePolicy: pointer.String(string(clusterv1.OldestMachineSetDeletePolicy)),
+			},
+		}
+		topologyMinReadySeconds := int32(30)
+		topologyStrategy := &clusterv1.MachineDeploymentStrategy{
+			Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+			RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+				DeletePolicy: pointer.String(string(clusterv1.NewestMachineSetDeletePolicy)),
+			},
+		}
+
+		tests := []struct {
+			name                string
+			topologyMinReady    *int32
+			topologyStrategy    *clusterv1.MachineDeploymentStrategy
+			wantMinReadySeconds *int32
+			wantStrategy        *clusterv1.MachineDeploymentStrategy
+		}{
+			{
+				name:                "use the values from the ClusterClass",
+				wantMinReadySeconds: &classMinReadySeconds,
+				wantStrategy:        classStrategy,
+			},
+			{
+				name:                "use the values from the Cluster topology",
+				topologyMinReady:    &topologyMinReadySeconds,
+				topologyStrategy:    topologyStrategy,
+				wantMinReadySeconds: &topologyMinReadySeconds,
+				wantStrategy:        topologyStrategy,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				s := scope.New(cluster)
+				s.Blueprint = &scope.ClusterBlueprint{
+					Topology:     cluster.Spec.Topology,
+					ClusterClass: fakeClass,
+					MachineDeployments: map[string]*scope.MachineDeploymentBlueprint{
+						"linux-worker": {
+							BootstrapTemplate:             workerBootstrapTemplate,
+							InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+							MinReadySeconds:               &classMinReadySeconds,
+							Strategy:                      classStrategy,
+						},
+					},
+				}
+				mdTopology := clusterv1.MachineDeploymentTopology{
+					Class:           "linux-worker",
+					Name:            "big-pool-of-machines",
+					MinReadySeconds: tt.topologyMinReady,
+					Strategy:        tt.topologyStrategy,
+				}
+
+				actual, err := computeMachineDeployment(ctx, s, nil, mdTopology)
+				g.Expect(err).To(BeNil())
+				g.Expect(actual.Object.Spec.MinReadySeconds).To(Equal(tt.wantMinReadySeconds))
+				g.Expect(actual.Object.Spec.Strategy).To(Equal(tt.wantStrategy))
+			})
+		}
+	})
+}
+
+func TestComputeMachineDeploymentReplicas(t *testing.T) {
+	currentMachineDeployment := &scope.MachineDeploymentState{
+		Object: builder.MachineDeployment(metav1.NamespaceDefault, "md1").WithReplicas(4).Build(),
+	}
+	autoscaling := &clusterv1.MachineDeploymentAutoscaling{MinSize: 2, MaxSize: 10}
+
+	tests := []struct {
+		name                     string
+		replicas                 *int32
+		autoscaling              *clusterv1.MachineDeploymentAutoscaling
+		currentMachineDeployment *scope.MachineDeploymentState
+		want                     *int32
+	}{
+		{
+			name:                     "use the replicas from the topology",
+			replicas:                 pointer.Int32(3),
+			currentMachineDeployment: currentMachineDeployment,
+			want:                     pointer.Int32(3),
+		},
+		{
+			name:                     "leave replicas unset for an existing MachineDeployment if replicas are not set in the topology",
+			currentMachineDeployment: currentMachineDeployment,
+			want:                     nil,
+		},
+		{
+			name: "leave replicas unset for a new MachineDeployment if replicas are not set in the topology",
+			want: nil,
+		},
+		{
+			name:                     "leave replicas unset for an existing MachineDeployment if autoscaling is set",
+			autoscaling:              autoscaling,
+			currentMachineDeployment: currentMachineDeployment,
+			want:                     nil,
+		},
+		{
+			name:                     "leave replicas unset for an existing MachineDeployment if autoscaling is set, even if replicas are set in the topology",
+			replicas:                 pointer.Int32(3),
+			autoscaling:              autoscaling,
+			currentMachineDeployment: currentMachineDeployment,
+			want:                     nil,
+		},
+		{
+			name:        "use minSize for a new MachineDeployment if autoscaling is set",
+			autoscaling: autoscaling,
+			want:        pointer.Int32(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mdTopology := clusterv1.MachineDeploymentTopology{
+				Class:       "linux-worker",
+				Name:        "big-pool-of-machines",
+				Replicas:    tt.replicas,
+				Autoscaling: tt.autoscaling,
+			}
+			g.Expect(computeMachineDeploymentReplicas(mdTopology, tt.currentMachineDeployment)).To(Equal(tt.want))
+		})
+	}
 }
 
 func TestComputeMachineDeploymentVersion(t *testing.T) {
@@ -1873,6 +2141,27 @@ func TestTemplateToTemplate(t *testing.T) {
 			obj:         obj,
 		})
 	})
+	t.Run("Generates a template in the namespace of the Cluster from a template in another namespace", func(t *testing.T) {
+		g := NewWithT(t)
+		template := builder.InfrastructureClusterTemplate("shared", "infrastructureClusterTemplate").
+			WithSpecFields(map[string]interface{}{"spec.template.spec.fakeSetting": true}).
+			Build()
+		obj := templateToTemplate(templateToInput{
+			template:              template,
+			templateClonedFromRef: fakeRef1,
+			cluster:               cluster,
+			namePrefix:            cluster.Name,
+			currentObjectRef:      nil,
+		})
+		g.Expect(obj).ToNot(BeNil())
+		assertTemplateToTemplate(g, assertTemplateInput{
+			cluster:     cluster,
+			templateRef: fakeRef1,
+			template:    template,
+			currentRef:  nil,
+			obj:         obj,
+		})
+	})
 }
 
 type assertTemplateInput struct {
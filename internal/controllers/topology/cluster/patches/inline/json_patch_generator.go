@@ -26,6 +26,7 @@ import (
 	"text/template"
 
 	sprig "github.com/Masterminds/sprig/v3"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -95,21 +96,33 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 			continue
 		}
 
+		// Keep track of the template with the patches generated so far applied, given that
+		// strategic merge patches are calculated against the current content of the template.
+		template, err := requestItemTemplate(item, matchingPatches)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to get template for item with uid %q", item.UID))
+			continue
+		}
+
 		// Loop over all PatchDefinitions.
 		for _, patch := range matchingPatches {
-			// Generate JSON patches.
-			jsonPatches, err := generateJSONPatches(patch.JSONPatches, variables)
+			items, err := generatePatches(template, patch, variables)
 			if err != nil {
-				errs = append(errs, errors.Wrapf(err, "failed to generate JSON patches for item with uid %q", item.UID))
+				errs = append(errs, errors.Wrapf(err, "failed to generate patches for item with uid %q", item.UID))
 				continue
 			}
 
-			// Add jsonPatches to the response.
-			resp.Items = append(resp.Items, runtimehooksv1.GeneratePatchesResponseItem{
-				UID:       item.UID,
-				Patch:     jsonPatches,
-				PatchType: runtimehooksv1.JSONPatchType,
-			})
+			// Add patches to the response.
+			for _, patchItem := range items {
+				patchItem.UID = item.UID
+				resp.Items = append(resp.Items, patchItem)
+			}
+			if template != nil {
+				if template, err = applyPatches(template, items); err != nil {
+					errs = append(errs, errors.Wrapf(err, "failed to apply patches for item with uid %q", item.UID))
+					break
+				}
+			}
 		}
 	}
 
@@ -120,6 +133,90 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 	return resp, nil
 }
 
+// requestItemTemplate returns the template of the GeneratePatchesRequestItem if one of the PatchDefinitions
+// defines a strategic merge patch, nil otherwise.
+func requestItemTemplate(item *runtimehooksv1.GeneratePatchesRequestItem, patches []clusterv1.PatchDefinition) ([]byte, error) {
+	for _, patch := range patches {
+		if patch.StrategicMergePatch == nil {
+			continue
+		}
+		if item.Object.Raw != nil {
+			return item.Object.Raw, nil
+		}
+		return json.Marshal(item.Object.Object)
+	}
+	return nil, nil
+}
+
+// generatePatches generates the patches for a PatchDefinition, in the order they have to be applied:
+// JSON patches first, then the merge patch and the strategic merge patch.
+// NOTE: template is required only if the PatchDefinition defines a strategic merge patch.
+func generatePatches(template []byte, patch clusterv1.PatchDefinition, variables map[string]apiextensionsv1.JSON) ([]runtimehooksv1.GeneratePatchesResponseItem, error) {
+	items := []runtimehooksv1.GeneratePatchesResponseItem{}
+
+	if len(patch.JSONPatches) > 0 {
+		jsonPatches, err := generateJSONPatches(patch.JSONPatches, variables)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate JSON patches")
+		}
+		items = append(items, runtimehooksv1.GeneratePatchesResponseItem{
+			Patch:     jsonPatches,
+			PatchType: runtimehooksv1.JSONPatchType,
+		})
+	}
+
+	if patch.MergePatch != nil {
+		mergePatch, err := generateMergePatch(patch.MergePatch, variables)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate merge patch")
+		}
+		items = append(items, runtimehooksv1.GeneratePatchesResponseItem{
+			Patch:     mergePatch,
+			PatchType: runtimehooksv1.JSONMergePatchType,
+		})
+	}
+
+	if patch.StrategicMergePatch != nil {
+		// Apply the patches generated so far, so the strategic merge patch is calculated against them.
+		patchedTemplate, err := applyPatches(template, items)
+		if err != nil {
+			return nil, err
+		}
+		mergePatch, err := generateStrategicMergePatch(patchedTemplate, patch.StrategicMergePatch, variables)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate strategic merge patch")
+		}
+		items = append(items, runtimehooksv1.GeneratePatchesResponseItem{
+			Patch:     mergePatch,
+			PatchType: runtimehooksv1.JSONMergePatchType,
+		})
+	}
+
+	return items, nil
+}
+
+// applyPatches applies patches to a template.
+func applyPatches(template []byte, items []runtimehooksv1.GeneratePatchesResponseItem) ([]byte, error) {
+	for _, item := range items {
+		var err error
+		switch item.PatchType {
+		case runtimehooksv1.JSONPatchType:
+			var jsonPatch jsonpatch.Patch
+			jsonPatch, err = jsonpatch.DecodePatch(item.Patch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode JSON patch: %s", string(item.Patch))
+			}
+			template, err = jsonPatch.Apply(template)
+		case runtimehooksv1.JSONMergePatchType:
+			template, err = jsonpatch.MergePatch(template, item.Patch)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to apply patch: %s", string(item.Patch))
+		}
+	}
+	return template, nil
+}
+
 // matchesSelector returns true if the GeneratePatchesRequestItem matches the selector.
 func matchesSelector(req *runtimehooksv1.GeneratePatchesRequestItem, templateVariables map[string]apiextensionsv1.JSON, selector clusterv1.PatchSelector) bool {
 	gvk := req.Object.Object.GetObjectKind().GroupVersionKind()
@@ -334,6 +334,109 @@ func TestGenerate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should generate JSON patches, merge patches and strategic merge patches in order",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "clusterName",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+							Kind:       "ControlPlaneTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{
+								ControlPlane: true,
+							},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:    "add",
+								Path:  "/spec/template/spec/kubeadmConfigSpec/preKubeadmCommands",
+								Value: &apiextensionsv1.JSON{Raw: []byte(`["echo hello"]`)},
+							},
+						},
+						MergePatch: &clusterv1.MergePatch{
+							Template: pointer.String(`
+spec:
+  template:
+    spec:
+      kubeadmConfigSpec:
+        clusterConfiguration:
+          controllerManager:
+            extraArgs:
+              cluster-name: {{ .builtin.cluster.name }}
+`),
+						},
+						StrategicMergePatch: &clusterv1.MergePatch{
+							Template: pointer.String(`
+spec:
+  template:
+    spec:
+      kubeadmConfigSpec:
+        files:
+        - path: /etc/a
+          owner: root:root
+        - path: /etc/b
+          $patch: delete
+        - path: /etc/c
+          content: {{ .builtin.cluster.name }}
+`),
+						},
+					},
+				},
+			},
+			req: &runtimehooksv1.GeneratePatchesRequest{
+				Variables: []runtimehooksv1.Variable{
+					{
+						Name:  "builtin",
+						Value: apiextensionsv1.JSON{Raw: []byte(`{"cluster":{"name":"cluster-name","namespace":"default","topology":{"class":"clusterClass1","version":"v1.21.1"}}}`)},
+					},
+				},
+				Items: []runtimehooksv1.GeneratePatchesRequestItem{
+					{
+						UID: "1",
+						HolderReference: runtimehooksv1.HolderReference{
+							APIVersion: clusterv1.GroupVersion.String(),
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+							Namespace:  "default",
+							FieldPath:  "spec.controlPlaneRef",
+						},
+						Object: runtime.RawExtension{
+							Raw: toJSONCompact(`{
+"apiVersion":"controlplane.cluster.x-k8s.io/v1beta1",
+"kind":"ControlPlaneTemplate",
+"spec":{"template":{"spec":{"kubeadmConfigSpec":{"files":[{"path":"/etc/a","content":"a"},{"path":"/etc/b","content":"b"}]}}}}
+}`),
+							Object: &unstructured.Unstructured{
+								Object: map[string]interface{}{
+									"apiVersion": "controlplane.cluster.x-k8s.io/v1beta1",
+									"kind":       "ControlPlaneTemplate",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &runtimehooksv1.GeneratePatchesResponse{
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{
+						UID:       "1",
+						Patch:     toJSONCompact(`[{"op":"add","path":"/spec/template/spec/kubeadmConfigSpec/preKubeadmCommands","value":["echo hello"]}]`),
+						PatchType: runtimehooksv1.JSONPatchType,
+					},
+					{
+						UID:       "1",
+						Patch:     toJSONCompact(`{"spec":{"template":{"spec":{"kubeadmConfigSpec":{"clusterConfiguration":{"controllerManager":{"extraArgs":{"cluster-name":"cluster-name"}}}}}}}}`),
+						PatchType: runtimehooksv1.JSONMergePatchType,
+					},
+					{
+						UID:       "1",
+						Patch:     toJSONCompact(`{"spec":{"template":{"spec":{"kubeadmConfigSpec":{"files":[{"content":"a","owner":"root:root","path":"/etc/a"},{"content":"cluster-name","path":"/etc/c"}]}}}}}`),
+						PatchType: runtimehooksv1.JSONMergePatchType,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
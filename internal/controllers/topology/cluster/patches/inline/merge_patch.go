@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inline
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// associativeListKeys are the keys used, in order of preference, to identify the items of
+// lists of objects when applying strategic merge patches.
+var associativeListKeys = []string{"name", "path", "key", "mountPath", "devicePath", "ip", "type", "topologyKey", "containerPort"}
+
+// calculateMergePatch calculates the patch for a MergePatch.
+// NOTE: The patch must be an object containing only the spec field, given that only
+// changes to the spec of templates are picked up.
+func calculateMergePatch(patch *clusterv1.MergePatch, variables map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
+	// Return if values are set incorrectly.
+	if patch.Value == nil && patch.Template == nil {
+		return nil, errors.Errorf("failed to calculate patch: neither .value nor .template are set")
+	}
+	if patch.Value != nil && patch.Template != nil {
+		return nil, errors.Errorf("failed to calculate patch: both .value and .template are set")
+	}
+
+	value := patch.Value
+	if patch.Template != nil {
+		var err error
+		value, err = renderValueTemplate(*patch.Template, variables)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to calculate patch for template")
+		}
+	}
+
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(value.Raw, &res); err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate patch: patch must be an object")
+	}
+	for k := range res {
+		if k != "spec" {
+			return nil, errors.Errorf("failed to calculate patch: patch must contain only the spec field, got %q", k)
+		}
+	}
+	return res, nil
+}
+
+// generateMergePatch generates a JSON merge patch (RFC 7386) based on the given MergePatch and variables.
+func generateMergePatch(mergePatch *clusterv1.MergePatch, variables map[string]apiextensionsv1.JSON) ([]byte, error) {
+	patch, err := calculateMergePatch(mergePatch, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal merge patch")
+	}
+	return patchJSON, nil
+}
+
+// generateStrategicMergePatch generates a JSON merge patch (RFC 7386) which is equivalent to applying
+// the given strategic merge patch to the template.
+// NOTE: Strategic merge patches can't be returned as is, given that they are not supported by the patch
+// engine and that they depend on the current content of the template, e.g. for deleting list items.
+func generateStrategicMergePatch(template []byte, strategicMergePatch *clusterv1.MergePatch, variables map[string]apiextensionsv1.JSON) ([]byte, error) {
+	patch, err := calculateMergePatch(strategicMergePatch, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	original := map[string]interface{}{}
+	if err := json.Unmarshal(template, &original); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal template")
+	}
+
+	// NOTE: StrategicMergeMapPatchUsingLookupPatchMeta modifies the original map, so the patch
+	// is applied to a copy of it.
+	originalCopy := map[string]interface{}{}
+	if err := json.Unmarshal(template, &originalCopy); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal template")
+	}
+	patched, err := strategicpatch.StrategicMergeMapPatchUsingLookupPatchMeta(originalCopy, patch, templatePatchMeta{values: []interface{}{original, patch}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to apply strategic merge patch")
+	}
+
+	patchedJSON, err := json.Marshal(patched)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal patched template")
+	}
+	mergePatch, err := jsonpatch.CreateMergePatch(template, patchedJSON)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create merge patch")
+	}
+	return mergePatch, nil
+}
+
+// templatePatchMeta implements strategicpatch.LookupPatchMeta for templates.
+// Given that templates are not backed by Go types defining patch strategies and merge keys,
+// lists of objects are merged using the first key of associativeListKeys which is set on
+// all the items of the list, both in the template and in the patch; all the other lists are replaced.
+type templatePatchMeta struct {
+	name string
+	// values are the values of the current field, both in the template and in the patch.
+	values []interface{}
+}
+
+var _ strategicpatch.LookupPatchMeta = templatePatchMeta{}
+
+// LookupPatchMetadataForStruct implements strategicpatch.LookupPatchMeta.
+func (m templatePatchMeta) LookupPatchMetadataForStruct(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return templatePatchMeta{name: key, values: m.fieldValues(key)}, strategicpatch.PatchMeta{}, nil
+}
+
+// LookupPatchMetadataForSlice implements strategicpatch.LookupPatchMeta.
+func (m templatePatchMeta) LookupPatchMetadataForSlice(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	items := []interface{}{}
+	for _, value := range m.fieldValues(key) {
+		if list, ok := value.([]interface{}); ok {
+			items = append(items, list...)
+		}
+	}
+
+	patchMeta := strategicpatch.PatchMeta{}
+	if mergeKey := mergeKeyForItems(items); mergeKey != "" {
+		patchMeta.SetPatchStrategies([]string{"merge"})
+		patchMeta.SetPatchMergeKey(mergeKey)
+	}
+	return templatePatchMeta{name: key, values: items}, patchMeta, nil
+}
+
+// Name implements strategicpatch.LookupPatchMeta.
+func (m templatePatchMeta) Name() string {
+	return m.name
+}
+
+// fieldValues returns the values of a field for all the objects in values.
+func (m templatePatchMeta) fieldValues(key string) []interface{} {
+	res := []interface{}{}
+	for _, value := range m.values {
+		if obj, ok := value.(map[string]interface{}); ok {
+			if fieldValue, ok := obj[key]; ok {
+				res = append(res, fieldValue)
+			}
+		}
+	}
+	return res
+}
+
+// mergeKeyForItems returns the first key of associativeListKeys which is set on all the items,
+// or an empty string if items are not objects or none of the keys is set on all of them.
+func mergeKeyForItems(items []interface{}) string {
+	objs := []map[string]interface{}{}
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		// Ignore list level directives, e.g. `$patch: replace`, which do not identify an item.
+		if len(obj) == 1 {
+			if _, ok := obj["$patch"]; ok {
+				continue
+			}
+		}
+		objs = append(objs, obj)
+	}
+	if len(objs) == 0 {
+		return ""
+	}
+
+	for _, key := range associativeListKeys {
+		found := true
+		for _, obj := range objs {
+			if _, ok := obj[key]; !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inline
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCalculateMergePatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		patch     *clusterv1.MergePatch
+		variables map[string]apiextensionsv1.JSON
+		want      map[string]interface{}
+		wantErr   bool
+	}{
+		{
+			name:    "Fails if neither .value nor .template are set",
+			patch:   &clusterv1.MergePatch{},
+			wantErr: true,
+		},
+		{
+			name: "Fails if both .value and .template are set",
+			patch: &clusterv1.MergePatch{
+				Value:    &apiextensionsv1.JSON{Raw: []byte(`{"spec":{"value":1}}`)},
+				Template: pointer.String(`{"spec":{"value":1}}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fails if the patch is not an object",
+			patch: &clusterv1.MergePatch{
+				Value: &apiextensionsv1.JSON{Raw: []byte(`["value"]`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fails if the patch contains fields other than spec",
+			patch: &clusterv1.MergePatch{
+				Value: &apiextensionsv1.JSON{Raw: []byte(`{"metadata":{"name":"foo"},"spec":{"value":1}}`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return .value if set",
+			patch: &clusterv1.MergePatch{
+				Value: &apiextensionsv1.JSON{Raw: []byte(`{"spec":{"value":1}}`)},
+			},
+			want: map[string]interface{}{"spec": map[string]interface{}{"value": float64(1)}},
+		},
+		{
+			name: "Should return rendered .template if set",
+			patch: &clusterv1.MergePatch{
+				Template: pointer.String(`
+spec:
+  name: {{ .builtin.cluster.name }}
+`),
+			},
+			variables: map[string]apiextensionsv1.JSON{
+				"builtin": {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+			},
+			want: map[string]interface{}{"spec": map[string]interface{}{"name": "cluster1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := calculateMergePatch(tt.patch, tt.variables)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGenerateStrategicMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		patch    string
+		want     string
+	}{
+		{
+			name:     "Should merge lists of objects by name",
+			template: `{"spec":{"containers":[{"name":"a","image":"a:v1"},{"name":"b","image":"b:v1"}]}}`,
+			patch:    `{"spec":{"containers":[{"name":"b","image":"b:v2"}]}}`,
+			want:     `{"spec":{"containers":[{"image":"a:v1","name":"a"},{"image":"b:v2","name":"b"}]}}`,
+		},
+		{
+			name:     "Should merge nested lists of objects",
+			template: `{"spec":{"containers":[{"name":"a","volumeMounts":[{"mountPath":"/a","name":"a"},{"mountPath":"/b","name":"b"}]}]}}`,
+			patch:    `{"spec":{"containers":[{"name":"a","volumeMounts":[{"mountPath":"/b","readOnly":true}]}]}}`,
+			want:     `{"spec":{"containers":[{"name":"a","volumeMounts":[{"mountPath":"/a","name":"a"},{"mountPath":"/b","name":"b","readOnly":true}]}]}}`,
+		},
+		{
+			name:     "Should delete list items with $patch: delete",
+			template: `{"spec":{"files":[{"path":"/a"},{"path":"/b"}]}}`,
+			patch:    `{"spec":{"files":[{"path":"/a","$patch":"delete"}]}}`,
+			want:     `{"spec":{"files":[{"path":"/b"}]}}`,
+		},
+		{
+			name:     "Should replace lists with $patch: replace",
+			template: `{"spec":{"files":[{"path":"/a"},{"path":"/b"}]}}`,
+			patch:    `{"spec":{"files":[{"$patch":"replace"},{"path":"/c"}]}}`,
+			want:     `{"spec":{"files":[{"path":"/c"}]}}`,
+		},
+		{
+			name:     "Should replace lists without a common key",
+			template: `{"spec":{"args":["a","b"],"items":[{"foo":"a"}]}}`,
+			patch:    `{"spec":{"args":["c"],"items":[{"foo":"b"}]}}`,
+			want:     `{"spec":{"args":["c"],"items":[{"foo":"b"}]}}`,
+		},
+		{
+			name:     "Should return an empty patch if the template doesn't change",
+			template: `{"spec":{"files":[{"path":"/a"}]}}`,
+			patch:    `{"spec":{"files":[{"path":"/a"}]}}`,
+			want:     `{}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := generateStrategicMergePatch([]byte(tt.template), &clusterv1.MergePatch{Value: &apiextensionsv1.JSON{Raw: []byte(tt.patch)}}, nil)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(MatchJSON(tt.want))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// HasValueFrom returns true if any of the variables of the topology, including the
+// MachineDeployment overrides, is sourced from a ConfigMap or a Secret.
+func HasValueFrom(topology *clusterv1.Topology) bool {
+	for _, variable := range topology.Variables {
+		if variable.ValueFrom != nil {
+			return true
+		}
+	}
+	if topology.Workers != nil {
+		for _, md := range topology.Workers.MachineDeployments {
+			if md.Variables == nil {
+				continue
+			}
+			for _, variable := range md.Variables.Overrides {
+				if variable.ValueFrom != nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ResolveValueFrom returns a copy of the topology where the values of the variables sourced
+// from a ConfigMap or a Secret, including the MachineDeployment overrides, are read from the referenced
+// objects in the given namespace.
+// NOTE: If the variable is of type string, the content of the referenced key is used as is, otherwise
+// it is parsed as YAML. Variables referencing an optional ConfigMap or Secret (or key) that does
+// not exist are dropped.
+func ResolveValueFrom(ctx context.Context, c client.Reader, namespace string, topology *clusterv1.Topology, clusterClassVariables []clusterv1.ClusterClassVariable) (*clusterv1.Topology, error) {
+	resolvedTopology := topology.DeepCopy()
+	if !HasValueFrom(topology) {
+		return resolvedTopology, nil
+	}
+
+	// Build a map of the variable types for easier and faster access.
+	variableTypes := map[string]string{}
+	for _, variable := range clusterClassVariables {
+		variableTypes[variable.Name] = variable.Schema.OpenAPIV3Schema.Type
+	}
+
+	var err error
+	resolvedTopology.Variables, err = resolveValueFrom(ctx, c, namespace, resolvedTopology.Variables, variableTypes)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedTopology.Workers != nil {
+		for i := range resolvedTopology.Workers.MachineDeployments {
+			md := &resolvedTopology.Workers.MachineDeployments[i]
+			if md.Variables == nil {
+				continue
+			}
+			md.Variables.Overrides, err = resolveValueFrom(ctx, c, namespace, md.Variables.Overrides, variableTypes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to resolve variable overrides for MachineDeployment topology %q", md.Name)
+			}
+		}
+	}
+	return resolvedTopology, nil
+}
+
+// resolveValueFrom resolves the value of a list of variables.
+func resolveValueFrom(ctx context.Context, c client.Reader, namespace string, clusterVariables []clusterv1.ClusterVariable, variableTypes map[string]string) ([]clusterv1.ClusterVariable, error) {
+	resolvedVariables := make([]clusterv1.ClusterVariable, 0, len(clusterVariables))
+	for _, variable := range clusterVariables {
+		if variable.ValueFrom == nil {
+			resolvedVariables = append(resolvedVariables, variable)
+			continue
+		}
+
+		data, found, err := getValueFromData(ctx, c, namespace, variable.ValueFrom)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve variable %q", variable.Name)
+		}
+		if !found {
+			continue
+		}
+
+		value, err := toJSONValue(data, variableTypes[variable.Name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve variable %q", variable.Name)
+		}
+		resolvedVariables = append(resolvedVariables, clusterv1.ClusterVariable{
+			Name:  variable.Name,
+			Value: *value,
+		})
+	}
+	return resolvedVariables, nil
+}
+
+// getValueFromData returns the content of the key referenced by a ClusterVariableSource.
+// If the referenced object or key does not exist and the reference is optional, found is false.
+func getValueFromData(ctx context.Context, c client.Reader, namespace string, valueFrom *clusterv1.ClusterVariableSource) (data []byte, found bool, err error) {
+	switch {
+	case valueFrom.ConfigMapKeyRef != nil:
+		ref := valueFrom.ConfigMapKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+			if apierrors.IsNotFound(err) && optional {
+				return nil, false, nil
+			}
+			return nil, false, errors.Wrapf(err, "failed to get ConfigMap %s/%s", namespace, ref.Name)
+		}
+		if value, ok := configMap.Data[ref.Key]; ok {
+			return []byte(value), true, nil
+		}
+		if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return value, true, nil
+		}
+		if optional {
+			return nil, false, nil
+		}
+		return nil, false, errors.Errorf("key %q does not exist in ConfigMap %s/%s", ref.Key, namespace, ref.Name)
+	case valueFrom.SecretKeyRef != nil:
+		ref := valueFrom.SecretKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			if apierrors.IsNotFound(err) && optional {
+				return nil, false, nil
+			}
+			return nil, false, errors.Wrapf(err, "failed to get Secret %s/%s", namespace, ref.Name)
+		}
+		if value, ok := secret.Data[ref.Key]; ok {
+			return value, true, nil
+		}
+		if optional {
+			return nil, false, nil
+		}
+		return nil, false, errors.Errorf("key %q does not exist in Secret %s/%s", ref.Key, namespace, ref.Name)
+	}
+	return nil, false, errors.New("either configMapKeyRef or secretKeyRef must be set")
+}
+
+// toJSONValue converts the content of a ConfigMap or Secret key to a variable value.
+func toJSONValue(data []byte, variableType string) (*apiextensionsv1.JSON, error) {
+	if variableType == "string" {
+		raw, err := json.Marshal(string(data))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal value")
+		}
+		return &apiextensionsv1.JSON{Raw: raw}, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse value")
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestResolveValueFrom(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Data: map[string]string{
+			"location": "us-central",
+			"network":  "cidr: 10.0.0.0/16\nnatGateway: true",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credentials",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Data: map[string][]byte{
+			"token": []byte("s3cr3t"),
+			"cpu":   []byte("8"),
+		},
+	}
+	clusterClassVariables := []clusterv1.ClusterClassVariable{
+		{Name: "location", Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}},
+		{Name: "network", Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "object"}}},
+		{Name: "token", Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}},
+		{Name: "cpu", Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "integer"}}},
+	}
+
+	tests := []struct {
+		name     string
+		topology *clusterv1.Topology
+		want     *clusterv1.Topology
+		wantErr  bool
+	}{
+		{
+			name: "Should return a copy of the topology if there are no variables sourced from ConfigMaps or Secrets",
+			topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "location", Value: toJSON("\"us-east\"")},
+				},
+			},
+			want: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "location", Value: toJSON("\"us-east\"")},
+				},
+			},
+		},
+		{
+			name: "Should resolve variables and overrides sourced from ConfigMaps and Secrets",
+			topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "location", ValueFrom: configMapKeyRef("config", "location", false)},
+					{Name: "network", ValueFrom: configMapKeyRef("config", "network", false)},
+					{Name: "token", ValueFrom: secretKeyRef("credentials", "token", false)},
+					{Name: "cpu", Value: toJSON("4")},
+				},
+				Workers: &clusterv1.WorkersTopology{
+					MachineDeployments: []clusterv1.MachineDeploymentTopology{
+						{
+							Name: "md1",
+							Variables: &clusterv1.MachineDeploymentVariables{
+								Overrides: []clusterv1.ClusterVariable{
+									{Name: "cpu", ValueFrom: secretKeyRef("credentials", "cpu", false)},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "location", Value: toJSON("\"us-central\"")},
+					{Name: "network", Value: toJSON("{\"cidr\":\"10.0.0.0/16\",\"natGateway\":true}")},
+					{Name: "token", Value: toJSON("\"s3cr3t\"")},
+					{Name: "cpu", Value: toJSON("4")},
+				},
+				Workers: &clusterv1.WorkersTopology{
+					MachineDeployments: []clusterv1.MachineDeploymentTopology{
+						{
+							Name: "md1",
+							Variables: &clusterv1.MachineDeploymentVariables{
+								Overrides: []clusterv1.ClusterVariable{
+									{Name: "cpu", Value: toJSON("8")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Should drop variables referencing optional objects or keys that do not exist",
+			topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "location", ValueFrom: configMapKeyRef("config", "does-not-exist", true)},
+					{Name: "token", ValueFrom: secretKeyRef("does-not-exist", "token", true)},
+				},
+			},
+			want: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{},
+			},
+		},
+		{
+			name: "Should fail for variables referencing keys that do not exist",
+			topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "location", ValueFrom: configMapKeyRef("config", "does-not-exist", false)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail for variables referencing objects that do not exist",
+			topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "token", ValueFrom: secretKeyRef("does-not-exist", "token", false)},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(configMap, secret).Build()
+
+			got, err := ResolveValueFrom(context.Background(), c, metav1.NamespaceDefault, tt.topology, clusterClassVariables)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func configMapKeyRef(name, key string, optional bool) *clusterv1.ClusterVariableSource {
+	return &clusterv1.ClusterVariableSource{
+		ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             pointer.Bool(optional),
+		},
+	}
+}
+
+func secretKeyRef(name, key string, optional bool) *clusterv1.ClusterVariableSource {
+	return &clusterv1.ClusterVariableSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             pointer.Bool(optional),
+		},
+	}
+}
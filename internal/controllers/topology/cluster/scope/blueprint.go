@@ -71,6 +71,14 @@ type MachineDeploymentBlueprint struct {
 	// MachineHealthCheck holds the MachineHealthCheckClass for this MachineDeployment.
 	// +optional
 	MachineHealthCheck *clusterv1.MachineHealthCheckClass
+
+	// MinReadySeconds holds the MinReadySeconds for this MachineDeployment as defined in the ClusterClass.
+	// +optional
+	MinReadySeconds *int32
+
+	// Strategy holds the MachineDeploymentStrategy for this MachineDeployment as defined in the ClusterClass.
+	// +optional
+	Strategy *clusterv1.MachineDeploymentStrategy
 }
 
 // HasControlPlaneInfrastructureMachine checks whether the clusterClass mandates the controlPlane has infrastructureMachines.
@@ -87,3 +95,38 @@ func (b *ClusterBlueprint) HasControlPlaneMachineHealthCheck() bool {
 func (b *ClusterBlueprint) HasMachineDeployments() bool {
 	return b.Topology.Workers != nil && len(b.Topology.Workers.MachineDeployments) > 0
 }
+
+// HasMachineDeploymentMachineHealthCheck returns true if a MachineHealthCheck is defined for the MachineDeployment,
+// either in the Cluster topology or in the MachineDeploymentClass.
+func (b *ClusterBlueprint) HasMachineDeploymentMachineHealthCheck(md *clusterv1.MachineDeploymentTopology) bool {
+	if md.MachineHealthCheck != nil && !md.MachineHealthCheck.MachineHealthCheckClass.IsZero() {
+		return true
+	}
+	mdBlueprint, ok := b.MachineDeployments[md.Class]
+	return ok && mdBlueprint.MachineHealthCheck != nil
+}
+
+// IsMachineDeploymentMachineHealthCheckEnabled returns true if a MachineHealthCheck should be created for the MachineDeployment.
+func (b *ClusterBlueprint) IsMachineDeploymentMachineHealthCheckEnabled(md *clusterv1.MachineDeploymentTopology) bool {
+	// If no MachineHealthCheck is defined in the Cluster topology or in the ClusterClass there is nothing to create.
+	if !b.HasMachineDeploymentMachineHealthCheck(md) {
+		return false
+	}
+	// If enable is not set, a MachineHealthCheck is created if it is defined.
+	if md.MachineHealthCheck == nil || md.MachineHealthCheck.Enable == nil {
+		return true
+	}
+	return *md.MachineHealthCheck.Enable
+}
+
+// MachineDeploymentMachineHealthCheckClass returns the MachineHealthCheckClass to be used for the MachineDeployment.
+// The MachineHealthCheckClass defined in the Cluster topology, if any, entirely overrides the one defined in the ClusterClass.
+func (b *ClusterBlueprint) MachineDeploymentMachineHealthCheckClass(md *clusterv1.MachineDeploymentTopology) *clusterv1.MachineHealthCheckClass {
+	if md.MachineHealthCheck != nil && !md.MachineHealthCheck.MachineHealthCheckClass.IsZero() {
+		return &md.MachineHealthCheck.MachineHealthCheckClass
+	}
+	if mdBlueprint, ok := b.MachineDeployments[md.Class]; ok {
+		return mdBlueprint.MachineHealthCheck
+	}
+	return nil
+}
@@ -87,6 +87,13 @@ func ObjectsAreInTheSameNamespace(current, desired client.Object) field.ErrorLis
 // LocalObjectTemplatesAreCompatible checks if two referenced objects are compatible, meaning that
 // they are of the same GroupKind and in the same namespace.
 func LocalObjectTemplatesAreCompatible(current, desired clusterv1.LocalObjectTemplate, pathPrefix *field.Path) field.ErrorList {
+	allErrs := localObjectTemplatesHaveSameGroupKind(current, desired, pathPrefix)
+	allErrs = append(allErrs, LocalObjectTemplatesAreInSameNamespace(current, desired, pathPrefix)...)
+	return allErrs
+}
+
+// localObjectTemplatesHaveSameGroupKind checks if two referenced objects are of the same GroupKind.
+func localObjectTemplatesHaveSameGroupKind(current, desired clusterv1.LocalObjectTemplate, pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	currentGK := current.Ref.GetObjectKind().GroupVersionKind().GroupKind()
@@ -106,10 +113,19 @@ func LocalObjectTemplatesAreCompatible(current, desired clusterv1.LocalObjectTem
 				currentGK.Kind, desiredGK.Kind),
 		))
 	}
-	allErrs = append(allErrs, LocalObjectTemplatesAreInSameNamespace(current, desired, pathPrefix)...)
 	return allErrs
 }
 
+// localObjectTemplatesCompatibilityCheck returns the function to be used to check if the templates of two ClusterClasses are compatible.
+// NOTE: Templates are always in the same namespace as their ClusterClass, so when comparing ClusterClasses in different
+// namespaces, e.g. when rebasing a Cluster to a ClusterClass in another namespace, the namespace of the templates is not checked.
+func localObjectTemplatesCompatibilityCheck(current, desired *clusterv1.ClusterClass) func(current, desired clusterv1.LocalObjectTemplate, pathPrefix *field.Path) field.ErrorList {
+	if current.Namespace != desired.Namespace {
+		return localObjectTemplatesHaveSameGroupKind
+	}
+	return LocalObjectTemplatesAreCompatible
+}
+
 // LocalObjectTemplatesAreInSameNamespace checks if two referenced objects are in the same namespace.
 func LocalObjectTemplatesAreInSameNamespace(current, desired clusterv1.LocalObjectTemplate, pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -202,15 +218,17 @@ func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field
 		return nil
 	}
 
+	templatesAreCompatible := localObjectTemplatesCompatibilityCheck(current, desired)
+
 	// Validate InfrastructureClusterTemplate changes desired a compatible way.
-	allErrs = append(allErrs, LocalObjectTemplatesAreCompatible(current.Spec.Infrastructure, desired.Spec.Infrastructure,
+	allErrs = append(allErrs, templatesAreCompatible(current.Spec.Infrastructure, desired.Spec.Infrastructure,
 		field.NewPath("spec", "infrastructure"))...)
 
 	// Validate control plane changes desired a compatible way.
-	allErrs = append(allErrs, LocalObjectTemplatesAreCompatible(current.Spec.ControlPlane.LocalObjectTemplate, desired.Spec.ControlPlane.LocalObjectTemplate,
+	allErrs = append(allErrs, templatesAreCompatible(current.Spec.ControlPlane.LocalObjectTemplate, desired.Spec.ControlPlane.LocalObjectTemplate,
 		field.NewPath("spec", "controlPlane"))...)
 	if desired.Spec.ControlPlane.MachineInfrastructure != nil && current.Spec.ControlPlane.MachineInfrastructure != nil {
-		allErrs = append(allErrs, LocalObjectTemplatesAreCompatible(*current.Spec.ControlPlane.MachineInfrastructure, *desired.Spec.ControlPlane.MachineInfrastructure,
+		allErrs = append(allErrs, templatesAreCompatible(*current.Spec.ControlPlane.MachineInfrastructure, *desired.Spec.ControlPlane.MachineInfrastructure,
 			field.NewPath("spec", "controlPlane", "machineInfrastructure"))...)
 	}
 
@@ -227,6 +245,8 @@ func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field
 func MachineDeploymentClassesAreCompatible(current, desired *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 
+	templatesAreCompatible := localObjectTemplatesCompatibilityCheck(current, desired)
+
 	// Ensure previous MachineDeployment class was modified in a compatible way.
 	for _, class := range desired.Spec.Workers.MachineDeployments {
 		for i, oldClass := range current.Spec.Workers.MachineDeployments {
@@ -236,7 +256,7 @@ func MachineDeploymentClassesAreCompatible(current, desired *clusterv1.ClusterCl
 				// class.Template.Bootstrap is ensured syntactically correct by LocalObjectTemplateIsValid.
 
 				// Validates class.Template.Infrastructure template changes in a compatible way
-				allErrs = append(allErrs, LocalObjectTemplatesAreCompatible(oldClass.Template.Infrastructure, class.Template.Infrastructure,
+				allErrs = append(allErrs, templatesAreCompatible(oldClass.Template.Infrastructure, class.Template.Infrastructure,
 					field.NewPath("spec", "workers", "machineDeployments").Index(i))...)
 			}
 		}
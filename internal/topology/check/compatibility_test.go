@@ -561,6 +561,57 @@ func TestClusterClassesAreCompatible(t *testing.T) {
 				Build(),
 			wantErr: false,
 		},
+		{
+			name: "pass for compatible clusterClasses in different namespaces",
+			current: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build()).
+				Build(),
+			desired: builder.ClusterClass("platform", "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate("platform", "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate("platform", "cp1").Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate("platform", "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate("platform", "bootstrap1").Build()).
+						Build()).
+				Build(),
+			wantErr: false,
+		},
+		{
+			name: "error for clusterClasses in different namespaces with incompatible ControlPlane ref",
+			current: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					refToUnstructured(ref)).
+				Build(),
+			desired: builder.ClusterClass("platform", "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate("platform", "infra1").Build()).
+				WithControlPlaneTemplate(
+					refToUnstructured(&corev1.ObjectReference{
+						APIVersion: incompatibleRef.APIVersion,
+						Kind:       incompatibleRef.Kind,
+						Name:       incompatibleRef.Name,
+						Namespace:  "platform",
+					})).
+				Build(),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		g := NewWithT(t)
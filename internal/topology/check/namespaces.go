@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ClusterClassAllowsNamespace checks if Clusters in the given namespace are allowed to use the ClusterClass.
+// Clusters in the same namespace of the ClusterClass are always allowed; Clusters in other namespaces are allowed
+// only if their namespace is listed in spec.allowedNamespaces.names or matches spec.allowedNamespaces.selector.
+func ClusterClassAllowsNamespace(clusterClass *clusterv1.ClusterClass, namespace *corev1.Namespace) (bool, error) {
+	if clusterClass.Namespace == namespace.Name {
+		return true, nil
+	}
+
+	allowedNamespaces := clusterClass.Spec.AllowedNamespaces
+	if allowedNamespaces == nil {
+		return false, nil
+	}
+
+	for _, name := range allowedNamespaces.Names {
+		if name == namespace.Name {
+			return true, nil
+		}
+	}
+
+	if allowedNamespaces.Selector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(allowedNamespaces.Selector)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse spec.allowedNamespaces.selector of ClusterClass %s/%s", clusterClass.Namespace, clusterClass.Name)
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+)
+
+func TestClusterClassAllowsNamespace(t *testing.T) {
+	tenantNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "tenant",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		namespace         *corev1.Namespace
+		allowedNamespaces *clusterv1.ClusterClassAllowedNamespaces
+		want              bool
+		wantErr           bool
+	}{
+		{
+			name:      "pass if the namespace is the namespace of the ClusterClass",
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}},
+			want:      true,
+		},
+		{
+			name:      "fail if allowedNamespaces is not set",
+			namespace: tenantNamespace,
+			want:      false,
+		},
+		{
+			name:      "pass if the namespace is in allowedNamespaces.names",
+			namespace: tenantNamespace,
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Names: []string{"other", "tenant"},
+			},
+			want: true,
+		},
+		{
+			name:      "fail if the namespace is not in allowedNamespaces.names",
+			namespace: tenantNamespace,
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Names: []string{"other"},
+			},
+			want: false,
+		},
+		{
+			name:      "pass if the namespace matches allowedNamespaces.selector",
+			namespace: tenantNamespace,
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+			want: true,
+		},
+		{
+			name:      "pass if allowedNamespaces.selector is empty",
+			namespace: tenantNamespace,
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{},
+			},
+			want: true,
+		},
+		{
+			name:      "fail if the namespace does not match allowedNamespaces.selector",
+			namespace: tenantNamespace,
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			},
+			want: false,
+		},
+		{
+			name:      "error if allowedNamespaces.selector is invalid",
+			namespace: tenantNamespace,
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Invalid"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").Build()
+			clusterClass.Spec.AllowedNamespaces = tt.allowedNamespaces
+
+			got, err := ClusterClassAllowsNamespace(clusterClass, tt.namespace)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
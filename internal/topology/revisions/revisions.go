@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package revisions implements utils for ClusterClass template revisions.
+package revisions
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ComputeHash computes the hash of the content of a template referenced by a ClusterClass.
+// The hash includes the namespaced name of the ClusterClass, so revisions are never shared across ClusterClasses,
+// the spec of the template and its labels and annotations, except for the kubectl last-applied-configuration annotation.
+func ComputeHash(clusterClass *clusterv1.ClusterClass, template *unstructured.Unstructured) (string, error) {
+	annotations := map[string]string{}
+	for k, v := range template.GetAnnotations() {
+		if k == corev1.LastAppliedConfigAnnotation {
+			continue
+		}
+		annotations[k] = v
+	}
+
+	content, err := json.Marshal(map[string]interface{}{
+		"clusterClass": fmt.Sprintf("%s/%s", clusterClass.Namespace, clusterClass.Name),
+		"spec":         template.Object["spec"],
+		"labels":       template.GetLabels(),
+		"annotations":  annotations,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to compute the hash of %s %s", template.GetKind(), template.GetName())
+	}
+
+	hasher := fnv.New32a()
+	if _, err := hasher.Write(content); err != nil {
+		return "", errors.Wrapf(err, "failed to compute the hash of %s %s", template.GetKind(), template.GetName())
+	}
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())), nil
+}
+
+// Name returns the name of the revision of a template with the given hash.
+// NOTE: The name of the template is truncated if required to keep the name of the revision a valid object name.
+func Name(templateName, hash string) string {
+	if maxLength := validation.DNS1123SubdomainMaxLength - len(hash) - 1; len(templateName) > maxLength {
+		templateName = templateName[:maxLength]
+	}
+	return fmt.Sprintf("%s-%s", templateName, hash)
+}
+
+// Get returns the revision of the template referenced by templateRef with the given hash from the ClusterClass status,
+// or nil if the revision does not exist.
+func Get(clusterClass *clusterv1.ClusterClass, templateRef *corev1.ObjectReference, hash string) *clusterv1.ClusterClassTemplateRevision {
+	for i := range clusterClass.Status.TemplateRevisions {
+		revision := &clusterClass.Status.TemplateRevisions[i]
+		if revision.Hash == hash &&
+			revision.TemplateRef.Name == templateRef.Name &&
+			revision.TemplateRef.GroupVersionKind().GroupKind() == templateRef.GroupVersionKind().GroupKind() {
+			return revision
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revisions
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+)
+
+func TestComputeHash(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").Build()
+	template := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "template1").
+		WithSpecFields(map[string]interface{}{"spec.template.spec.foo": "bar"}).
+		Build()
+
+	hash, err := ComputeHash(clusterClass, template)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hash).ToNot(BeEmpty())
+
+	// The hash does not depend on server side fields and on the last-applied-configuration annotation.
+	sameTemplate := template.DeepCopy()
+	sameTemplate.SetResourceVersion("42")
+	sameTemplate.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ClusterClass", Name: "class1"}})
+	sameTemplate.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: "{}"})
+	sameHash, err := ComputeHash(clusterClass, sameTemplate)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sameHash).To(Equal(hash))
+
+	// The hash changes if the spec of the template changes.
+	changedTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "template1").
+		WithSpecFields(map[string]interface{}{"spec.template.spec.foo": "baz"}).
+		Build()
+	changedHash, err := ComputeHash(clusterClass, changedTemplate)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changedHash).ToNot(Equal(hash))
+
+	// The hash changes if the template is referenced by another ClusterClass.
+	otherClassHash, err := ComputeHash(builder.ClusterClass(metav1.NamespaceDefault, "class2").Build(), template)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(otherClassHash).ToNot(Equal(hash))
+}
+
+func TestName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Name("template1", "abc")).To(Equal("template1-abc"))
+
+	name := Name(strings.Repeat("a", validation.DNS1123SubdomainMaxLength), "abc")
+	g.Expect(name).To(HaveLen(validation.DNS1123SubdomainMaxLength))
+	g.Expect(name).To(HaveSuffix("-abc"))
+}
+
+func TestGet(t *testing.T) {
+	g := NewWithT(t)
+
+	template := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "template1").Build()
+	otherTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "template1").Build()
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").Build()
+	clusterClass.Status.TemplateRevisions = []clusterv1.ClusterClassTemplateRevision{
+		{
+			TemplateRef: *contract.ObjToRef(template),
+			RevisionRef: corev1.ObjectReference{Name: "template1-abc"},
+			Hash:        "abc",
+		},
+		{
+			TemplateRef: *contract.ObjToRef(otherTemplate),
+			RevisionRef: corev1.ObjectReference{Name: "template1-def"},
+			Hash:        "def",
+		},
+	}
+
+	g.Expect(Get(clusterClass, contract.ObjToRef(template), "abc")).To(Equal(&clusterClass.Status.TemplateRevisions[0]))
+	g.Expect(Get(clusterClass, contract.ObjToRef(template), "def")).To(BeNil())
+	g.Expect(Get(clusterClass, contract.ObjToRef(otherTemplate), "def")).To(Equal(&clusterClass.Status.TemplateRevisions[1]))
+}
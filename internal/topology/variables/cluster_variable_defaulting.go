@@ -102,6 +102,12 @@ func defaultClusterVariables(clusterVariables []clusterv1.ClusterVariable, clust
 
 // defaultClusterVariable defaults a clusterVariable based on the default value in the clusterClassVariable.
 func defaultClusterVariable(clusterVariable *clusterv1.ClusterVariable, clusterClassVariable *clusterv1.ClusterClassVariable, fldPath *field.Path, createVariable bool) (*clusterv1.ClusterVariable, field.ErrorList) {
+	// Return the variable as is if it is sourced from a ConfigMap or a Secret, given that
+	// the value is only resolved by the topology controller.
+	if clusterVariable != nil && clusterVariable.ValueFrom != nil {
+		return clusterVariable.DeepCopy(), nil
+	}
+
 	if clusterVariable == nil {
 		// Return if the variable does not exist yet and createVariable is false.
 		if !createVariable {
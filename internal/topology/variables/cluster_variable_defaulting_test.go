@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -313,6 +314,38 @@ func Test_DefaultClusterVariable(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Don't default existing integer variable sourced from a ConfigMap",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name:     "cpu",
+				Required: true,
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type:    "integer",
+						Default: &apiextensionsv1.JSON{Raw: []byte(`1`)},
+					},
+				},
+			},
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "cpu",
+				ValueFrom: &clusterv1.ClusterVariableSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "machine-config"},
+						Key:                  "cpu",
+					},
+				},
+			},
+			createVariable: true,
+			want: &clusterv1.ClusterVariable{
+				Name: "cpu",
+				ValueFrom: &clusterv1.ClusterVariableSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "machine-config"},
+						Key:                  "cpu",
+					},
+				},
+			},
+		},
 		{
 			name: "Default new string variable",
 			clusterClassVariable: &clusterv1.ClusterClassVariable{
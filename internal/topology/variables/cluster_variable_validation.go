@@ -17,11 +17,15 @@ limitations under the License.
 package variables
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -120,7 +124,13 @@ func ValidateTopLevelClusterVariablesExist(clusterVariablesOverrides []clusterv1
 }
 
 // ValidateClusterVariable validates a clusterVariable.
+// NOTE: Variables sourced from a ConfigMap or a Secret are validated against the schema only after
+// the topology controller resolves them.
 func ValidateClusterVariable(clusterVariable *clusterv1.ClusterVariable, clusterClassVariable *clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
+	if clusterVariable.ValueFrom != nil {
+		return validateClusterVariableValueFrom(clusterVariable, fldPath)
+	}
+
 	// Parse JSON value.
 	var variableValue interface{}
 	// Only try to unmarshal the clusterVariable if it is not nil, otherwise the variableValue is nil.
@@ -150,7 +160,80 @@ func ValidateClusterVariable(clusterVariable *clusterv1.ClusterVariable, cluster
 
 	// Validate variable against the schema.
 	// NOTE: We're reusing a library func used in CRD validation.
-	return validation.ValidateCustomResource(fldPath, variableValue, validator)
+	if errs := validation.ValidateCustomResource(fldPath, variableValue, validator); len(errs) > 0 {
+		return errs
+	}
+
+	// Validate variable against the CEL validation rules defined in the schema.
+	return validateClusterVariableCELRules(clusterVariable, clusterClassVariable, apiExtensionsSchema, fldPath)
+}
+
+// validateClusterVariableValueFrom validates the valueFrom of a clusterVariable.
+func validateClusterVariableValueFrom(clusterVariable *clusterv1.ClusterVariable, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(clusterVariable.Value.Raw) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("value"),
+			fmt.Sprintf("variable %q must not set value when valueFrom is set", clusterVariable.Name)))
+	}
+
+	valueFrom := clusterVariable.ValueFrom
+	valueFromPath := fldPath.Child("valueFrom")
+	switch {
+	case valueFrom.ConfigMapKeyRef != nil && valueFrom.SecretKeyRef != nil:
+		allErrs = append(allErrs, field.Invalid(valueFromPath, "",
+			fmt.Sprintf("variable %q must set only one of configMapKeyRef or secretKeyRef", clusterVariable.Name)))
+	case valueFrom.ConfigMapKeyRef != nil:
+		allErrs = append(allErrs, validateKeyRef(valueFrom.ConfigMapKeyRef.Name, valueFrom.ConfigMapKeyRef.Key, valueFromPath.Child("configMapKeyRef"))...)
+	case valueFrom.SecretKeyRef != nil:
+		allErrs = append(allErrs, validateKeyRef(valueFrom.SecretKeyRef.Name, valueFrom.SecretKeyRef.Key, valueFromPath.Child("secretKeyRef"))...)
+	default:
+		allErrs = append(allErrs, field.Required(valueFromPath,
+			fmt.Sprintf("variable %q must set one of configMapKeyRef or secretKeyRef", clusterVariable.Name)))
+	}
+
+	return allErrs
+}
+
+// validateKeyRef validates the name and the key of a reference to a ConfigMap or a Secret key.
+func validateKeyRef(name, key string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name must be set"))
+	}
+	if key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key must be set"))
+	}
+	return allErrs
+}
+
+// validateClusterVariableCELRules validates a clusterVariable value against the CEL validation rules defined in the schema.
+// NOTE: We're reusing a library func used in CRD validation.
+func validateClusterVariableCELRules(clusterVariable *clusterv1.ClusterVariable, clusterClassVariable *clusterv1.ClusterClassVariable, apiExtensionsSchema *apiextensions.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	// Parse JSON value preserving integers, given that CEL is strict about types.
+	// Note: this is consistent with how the API server parses custom resources.
+	var variableValue interface{}
+	if clusterVariable.Value.Raw != nil {
+		if err := utiljson.Unmarshal(clusterVariable.Value.Raw, &variableValue); err != nil {
+			return field.ErrorList{field.Invalid(fldPath.Child("value"), string(clusterVariable.Value.Raw),
+				fmt.Sprintf("variable %q could not be parsed: %v", clusterVariable.Name, err))}
+		}
+	}
+
+	ss, err := structuralschema.NewStructural(apiExtensionsSchema)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath,
+			fmt.Errorf("failed to create structural schema for variable %q; ClusterClass should be checked: %v", clusterClassVariable.Name, err))}
+	}
+
+	celValidator := cel.NewValidator(ss, cel.PerCallLimit)
+	if celValidator == nil {
+		return nil
+	}
+
+	// NOTE: Errors are returned also if the validation rules exceed the cost budget.
+	errs, _ := celValidator.Validate(context.TODO(), fldPath, ss, variableValue, nil, cel.RuntimeCELCostBudget)
+	return errs
 }
 
 func getClusterVariablesMap(clusterVariables []clusterv1.ClusterVariable) map[string]*clusterv1.ClusterVariable {
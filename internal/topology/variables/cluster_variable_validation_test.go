@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -1029,6 +1030,108 @@ func Test_ValidateClusterVariable(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:                 "Valid object satisfying validation rules",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				Value: apiextensionsv1.JSON{
+					Raw: []byte(`{"enabled":true,"instanceType":"t3.small"}`),
+				},
+			},
+		},
+		{
+			name:                 "Fails, object does not satisfy validation rules across fields",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				Value: apiextensionsv1.JSON{
+					Raw: []byte(`{"enabled":true}`),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:                 "Fails, nested field does not satisfy validation rules",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				Value: apiextensionsv1.JSON{
+					Raw: []byte(`{"enabled":true,"instanceType":"m5.large"}`),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:                 "Valid variable sourced from a Secret",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				ValueFrom: &clusterv1.ClusterVariableSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "bastion"},
+						Key:                  "config",
+					},
+				},
+			},
+		},
+		{
+			name:                 "Fails, both value and valueFrom are set",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				Value: apiextensionsv1.JSON{
+					Raw: []byte(`{"enabled":false}`),
+				},
+				ValueFrom: &clusterv1.ClusterVariableSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "bastion"},
+						Key:                  "config",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:                 "Fails, both configMapKeyRef and secretKeyRef are set",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				ValueFrom: &clusterv1.ClusterVariableSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "bastion"},
+						Key:                  "config",
+					},
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "bastion"},
+						Key:                  "config",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:                 "Fails, valueFrom does not set the key",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name: "bastion",
+				ValueFrom: &clusterv1.ClusterVariableSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "bastion"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:                 "Fails, valueFrom is empty",
+			clusterClassVariable: bastionClusterClassVariable(),
+			clusterVariable: &clusterv1.ClusterVariable{
+				Name:      "bastion",
+				ValueFrom: &clusterv1.ClusterVariableSource{},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1045,3 +1148,75 @@ func Test_ValidateClusterVariable(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateClusterVariable_ValidationRulesFieldPaths(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterVariables := []clusterv1.ClusterVariable{
+		{
+			Name: "bastion",
+			Value: apiextensionsv1.JSON{
+				Raw: []byte(`{"enabled":true,"instanceType":"m5.large"}`),
+			},
+		},
+		{
+			Name: "workerCount",
+			Value: apiextensionsv1.JSON{
+				Raw: []byte(`{"controlPlane":3,"workers":1}`),
+			},
+		},
+	}
+	clusterClassVariables := []clusterv1.ClusterClassVariable{
+		*bastionClusterClassVariable(),
+		{
+			Name: "workerCount",
+			Schema: clusterv1.VariableSchema{
+				OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]clusterv1.JSONSchemaProps{
+						"controlPlane": {Type: "integer"},
+						"workers":      {Type: "integer"},
+					},
+					XValidations: []clusterv1.ValidationRule{{
+						Rule: "self.workers >= self.controlPlane",
+					}},
+				},
+			},
+		},
+	}
+
+	errList := ValidateClusterVariables(clusterVariables, clusterClassVariables, field.NewPath("spec", "topology", "variables"))
+	g.Expect(errList).To(HaveLen(2))
+	g.Expect(errList[0].Field).To(Equal("spec.topology.variables[0].instanceType"))
+	g.Expect(errList[0].Detail).To(Equal("instanceType must be a t3 instance type"))
+	g.Expect(errList[1].Field).To(Equal("spec.topology.variables[1]"))
+	g.Expect(errList[1].Detail).To(Equal("failed rule: self.workers >= self.controlPlane"))
+}
+
+func bastionClusterClassVariable() *clusterv1.ClusterClassVariable {
+	return &clusterv1.ClusterClassVariable{
+		Name:     "bastion",
+		Required: true,
+		Schema: clusterv1.VariableSchema{
+			OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]clusterv1.JSONSchemaProps{
+					"enabled": {
+						Type: "boolean",
+					},
+					"instanceType": {
+						Type: "string",
+						XValidations: []clusterv1.ValidationRule{{
+							Rule:    "self.startsWith('t3.')",
+							Message: "instanceType must be a t3 instance type",
+						}},
+					},
+				},
+				XValidations: []clusterv1.ValidationRule{{
+					Rule:    "!self.enabled || has(self.instanceType)",
+					Message: "instanceType is required if bastion is enabled",
+				}},
+			},
+		},
+	}
+}
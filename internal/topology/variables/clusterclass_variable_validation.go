@@ -23,6 +23,7 @@ import (
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
 	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -153,6 +154,55 @@ func validateRootSchema(ctx context.Context, clusterClassVariable *clusterv1.Clu
 	}
 
 	allErrs = append(allErrs, validateSchema(apiExtensionsSchema, fldPath)...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	// Validate the CEL validation rules can be compiled.
+	// Note: the CEL validation rules are compiled using the schema of the variable, without wrapping it,
+	// so the type of self matches the type of the variable.
+	variableStructuralSchema, err := structuralschema.NewStructural(apiExtensionsSchema)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, "", err.Error()))
+	}
+	allErrs = append(allErrs, validateCELRules(variableStructuralSchema, fldPath)...)
+	return allErrs
+}
+
+// validateCELRules validates the CEL validation rules defined in a structural schema and in all its nested schemas.
+func validateCELRules(s *structuralschema.Structural, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	compilationResults, err := cel.Compile(s, false, cel.PerCallLimit)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("x-kubernetes-validations"), s.XValidations, fmt.Sprintf("failed to compile validation rules: %v", err)))
+	}
+	for i, result := range compilationResults {
+		rule := s.XValidations[i]
+		rulePath := fldPath.Child("x-kubernetes-validations").Index(i).Child("rule")
+		switch {
+		case result.Error != nil:
+			allErrs = append(allErrs, field.Invalid(rulePath, rule.Rule, fmt.Sprintf("failed to compile rule: %s", result.Error.Detail)))
+		case result.Program == nil:
+			allErrs = append(allErrs, field.Required(rulePath, "rule cannot be empty"))
+		case result.TransitionRule:
+			allErrs = append(allErrs, field.Invalid(rulePath, rule.Rule, "transition rules using oldSelf are not supported for variables"))
+		}
+	}
+
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Structural != nil {
+		allErrs = append(allErrs, validateCELRules(s.AdditionalProperties.Structural, fldPath.Child("additionalProperties"))...)
+	}
+
+	for propertyName := range s.Properties {
+		p := s.Properties[propertyName]
+		allErrs = append(allErrs, validateCELRules(&p, fldPath.Child("properties").Key(propertyName))...)
+	}
+
+	if s.Items != nil {
+		allErrs = append(allErrs, validateCELRules(s.Items, fldPath.Child("items"))...)
+	}
+
 	return allErrs
 }
 
@@ -904,6 +904,87 @@ func Test_ValidateClusterClassVariable(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Valid object schema with validation rules",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "bastion",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]clusterv1.JSONSchemaProps{
+							"enabled": {
+								Type: "boolean",
+							},
+							"instanceType": {
+								Type: "string",
+								XValidations: []clusterv1.ValidationRule{{
+									Rule: "self.startsWith('t3.')",
+								}},
+							},
+						},
+						XValidations: []clusterv1.ValidationRule{{
+							Rule:    "!self.enabled || has(self.instanceType)",
+							Message: "instanceType is required if bastion is enabled",
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "fail on validation rules that do not compile",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "bastion",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]clusterv1.JSONSchemaProps{
+							"enabled": {
+								Type: "boolean",
+							},
+						},
+						XValidations: []clusterv1.ValidationRule{{
+							Rule: "self.enabled > 1",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail on validation rules referring to fields not defined in the schema",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "bastion",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]clusterv1.JSONSchemaProps{
+							"enabled": {
+								Type: "boolean",
+								XValidations: []clusterv1.ValidationRule{{
+									Rule: "self.instanceType != ''",
+								}},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail on transition rules",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "cpu",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "integer",
+						XValidations: []clusterv1.ValidationRule{{
+							Rule: "self >= oldSelf",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
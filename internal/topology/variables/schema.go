@@ -114,6 +114,13 @@ func convertToAPIExtensionsJSONSchemaProps(schema *clusterv1.JSONSchemaProps, fl
 		props.Minimum = &f
 	}
 
+	for _, rule := range schema.XValidations {
+		props.XValidations = append(props.XValidations, apiextensions.ValidationRule{
+			Rule:    rule.Rule,
+			Message: rule.Message,
+		})
+	}
+
 	if schema.AdditionalProperties != nil {
 		apiExtensionsSchema, err := convertToAPIExtensionsJSONSchemaProps(schema.AdditionalProperties, fldPath.Child("additionalProperties"))
 		if err != nil {
@@ -23,8 +23,10 @@ import (
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,7 +83,7 @@ func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 		clusterClass, err := webhook.getClusterClassForCluster(ctx, cluster)
 		if err != nil {
 			// Return early with errors if the ClusterClass can't be retrieved.
-			return apierrors.NewInternalError(errors.Wrapf(err, "Cluster %s can't be validated. ClusterClass %s can not be retrieved", cluster.Name, cluster.Spec.Topology.Class))
+			return apierrors.NewInternalError(errors.Wrapf(err, "Cluster %s can't be validated. ClusterClass %s can not be retrieved", cluster.Name, cluster.GetClassKey()))
 		}
 
 		// We gather all defaulting errors and return them together.
@@ -231,18 +233,47 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 		)
 	}
 
+	// classNamespace should be a valid namespace name, if set.
+	if newCluster.Spec.Topology.ClassNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(newCluster.Spec.Topology.ClassNamespace) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					fldPath.Child("classNamespace"),
+					newCluster.Spec.Topology.ClassNamespace,
+					msg,
+				),
+			)
+		}
+	}
+
+	// replicas and autoscaling of MachineDeployments should be valid.
+	allErrs = append(allErrs, validateMachineDeploymentsAutoscaling(newCluster)...)
+
+	// variables must set a value or a valueFrom.
+	allErrs = append(allErrs, validateClusterVariablesHaveValue(newCluster)...)
+
 	// clusterClass must exist.
 	clusterClass := &clusterv1.ClusterClass{}
 	// Check to see if the ClusterClass referenced in the Cluster currently exists.
-	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: newCluster.Namespace, Name: newCluster.Spec.Topology.Class}, clusterClass); err != nil {
+	classKey := newCluster.GetClassKey()
+	if err := webhook.Client.Get(ctx, classKey, clusterClass); err != nil {
 		allErrs = append(
 			allErrs, field.Invalid(
 				fldPath.Child("class"),
 				newCluster.Name,
-				fmt.Sprintf("ClusterClass with name %q could not be found", newCluster.Spec.Topology.Class)))
+				fmt.Sprintf("ClusterClass with name %q could not be found in namespace %q", classKey.Name, classKey.Namespace)))
 		return allErrs
 	}
 
+	// clusterClass must allow the namespace of the Cluster.
+	if classKey.Namespace != newCluster.Namespace {
+		if errs := webhook.validateClusterClassAllowsNamespace(ctx, clusterClass, newCluster.Namespace, fldPath.Child("classNamespace")); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			return allErrs
+		}
+	}
+
 	allErrs = append(allErrs, check.MachineDeploymentTopologiesAreValidAndDefinedInClusterClass(newCluster, clusterClass)...)
 
 	// Check if the variables defined in the ClusterClass are valid.
@@ -264,6 +295,9 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 		}
 	}
 
+	// Check if the MachineHealthCheck overrides defined in the Cluster topology are valid.
+	allErrs = append(allErrs, validateMachineHealthChecks(newCluster, clusterClass)...)
+
 	if oldCluster != nil { // On update
 		// Topology or Class can not be added on update unless unsafe cluster topology update annotation is set
 		if oldCluster.Spec.Topology == nil || oldCluster.Spec.Topology.Class == "" {
@@ -333,7 +367,7 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 		}
 
 		// If the ClusterClass referenced in the Topology has changed compatibility checks are needed.
-		if oldCluster.Spec.Topology.Class != newCluster.Spec.Topology.Class {
+		if oldCluster.GetClassKey() != newCluster.GetClassKey() {
 			// Check to see if the ClusterClass referenced in the old version of the Cluster exists.
 			oldClusterClass, err := webhook.getClusterClassForCluster(ctx, oldCluster)
 			if err != nil {
@@ -354,11 +388,142 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 	return allErrs
 }
 
+// validateMachineDeploymentsAutoscaling validates the autoscaling settings of the MachineDeployments in the Cluster topology.
+func validateMachineDeploymentsAutoscaling(cluster *clusterv1.Cluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cluster.Spec.Topology.Workers == nil {
+		return allErrs
+	}
+
+	for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		if md.Autoscaling == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i)
+
+		if md.Replicas != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath.Child("replicas"),
+				"cannot be set if autoscaling is set",
+			))
+		}
+		if md.Autoscaling.MinSize > md.Autoscaling.MaxSize {
+			allErrs = append(allErrs, field.Invalid(
+				fldPath.Child("autoscaling", "minSize"),
+				md.Autoscaling.MinSize,
+				fmt.Sprintf("must be less than or equal to maxSize (%d)", md.Autoscaling.MaxSize),
+			))
+		}
+	}
+
+	return allErrs
+}
+
+// validateClusterVariablesHaveValue validates that the variables in the Cluster topology set either a value or a valueFrom;
+// variables setting both are rejected when validating valueFrom.
+// NOTE: An explicit null value is a value, and it is validated against the variable schema like any other value.
+// NOTE: value can't be required in the CRD, because it must not be set when valueFrom is set.
+func validateClusterVariablesHaveValue(cluster *clusterv1.Cluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	validate := func(vars []clusterv1.ClusterVariable, fldPath *field.Path) {
+		for i, v := range vars {
+			if v.ValueFrom == nil && len(v.Value.Raw) == 0 {
+				allErrs = append(allErrs, field.Required(
+					fldPath.Index(i).Child("value"),
+					fmt.Sprintf("variable %q must set one of value or valueFrom", v.Name),
+				))
+			}
+		}
+	}
+
+	fldPath := field.NewPath("spec", "topology")
+	validate(cluster.Spec.Topology.Variables, fldPath.Child("variables"))
+	if cluster.Spec.Topology.Workers != nil {
+		for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+			if md.Variables == nil {
+				continue
+			}
+			validate(md.Variables.Overrides, fldPath.Child("workers", "machineDeployments").Index(i).Child("variables", "overrides"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateMachineHealthChecks validates the MachineHealthCheck overrides defined for the MachineDeployments
+// in the Cluster topology.
+func validateMachineHealthChecks(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cluster.Spec.Topology.Workers == nil {
+		return allErrs
+	}
+
+	for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		if md.MachineHealthCheck == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i).Child("machineHealthCheck")
+
+		// Ensure the MachineHealthCheck override defines UnhealthyConditions.
+		if !md.MachineHealthCheck.MachineHealthCheckClass.IsZero() && len(md.MachineHealthCheck.UnhealthyConditions) == 0 {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath.Child("unhealthyConditions"),
+				"must be defined and have at least one value",
+			))
+		}
+
+		// If the MachineHealthCheck is explicitly enabled a MachineHealthCheck definition must be available,
+		// either in the Cluster topology or in the ClusterClass.
+		if md.MachineHealthCheck.Enable != nil && *md.MachineHealthCheck.Enable &&
+			md.MachineHealthCheck.MachineHealthCheckClass.IsZero() && !machineDeploymentClassHasMachineHealthCheck(clusterClass, md.Class) {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath.Child("enable"),
+				fmt.Sprintf("cannot be set to %t as MachineHealthCheck definition is not available in the Cluster topology or the ClusterClass", *md.MachineHealthCheck.Enable),
+			))
+		}
+	}
+
+	return allErrs
+}
+
+// machineDeploymentClassHasMachineHealthCheck returns true if the MachineDeploymentClass with the given name
+// defines a MachineHealthCheck.
+func machineDeploymentClassHasMachineHealthCheck(clusterClass *clusterv1.ClusterClass, class string) bool {
+	for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+		if mdClass.Class == class {
+			return mdClass.MachineHealthCheck != nil
+		}
+	}
+	return false
+}
+
 func (webhook *Cluster) getClusterClassForCluster(ctx context.Context, cluster *clusterv1.Cluster) (*clusterv1.ClusterClass, error) {
 	clusterClass := &clusterv1.ClusterClass{}
 	// Check to see if the ClusterClass referenced in the old version of the Cluster exists.
-	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.Topology.Class}, clusterClass); err != nil {
+	if err := webhook.Client.Get(ctx, cluster.GetClassKey(), clusterClass); err != nil {
 		return nil, err
 	}
 	return clusterClass, nil
 }
+
+// validateClusterClassAllowsNamespace checks that the ClusterClass allows Clusters in the given namespace.
+func (webhook *Cluster) validateClusterClassAllowsNamespace(ctx context.Context, clusterClass *clusterv1.ClusterClass, namespace string, fldPath *field.Path) field.ErrorList {
+	ns := &corev1.Namespace{}
+	if err := webhook.Client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return field.ErrorList{field.InternalError(fldPath,
+			errors.Wrapf(err, "Namespace %q can not be retrieved", namespace))}
+	}
+
+	allowed, err := check.ClusterClassAllowsNamespace(clusterClass, ns)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	if !allowed {
+		return field.ErrorList{field.Forbidden(fldPath,
+			fmt.Sprintf("ClusterClass %q in namespace %q does not allow Clusters in namespace %q", clusterClass.Name, clusterClass.Namespace, namespace))}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -25,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -692,6 +694,96 @@ func TestClusterTopologyValidation(t *testing.T) {
 				Build(),
 			expectErr: true,
 		},
+		{
+			name: "should fail when top-level variable does not satisfy validation rules",
+			clusterClassVariables: []clusterv1.ClusterClassVariable{
+				{
+					Name:     "cpu",
+					Required: true,
+					Schema: clusterv1.VariableSchema{
+						OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+							Type: "integer",
+							XValidations: []clusterv1.ValidationRule{{
+								Rule:    "self % 2 == 0",
+								Message: "cpu must be even",
+							}},
+						},
+					},
+				},
+			},
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithVariables(clusterv1.ClusterVariable{
+						Name:  "cpu",
+						Value: apiextensionsv1.JSON{Raw: []byte(`3`)},
+					}).
+					Build()).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "should pass when top-level variable is sourced from a Secret",
+			clusterClassVariables: []clusterv1.ClusterClassVariable{
+				{
+					Name:     "cpu",
+					Required: true,
+					Schema: clusterv1.VariableSchema{
+						OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+							Type: "integer",
+						},
+					},
+				},
+			},
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithVariables(clusterv1.ClusterVariable{
+						Name: "cpu",
+						ValueFrom: &clusterv1.ClusterVariableSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "machine-config"},
+								Key:                  "cpu",
+							},
+						},
+					}).
+					Build()).
+				Build(),
+			expectErr: false,
+		},
+		{
+			name: "should fail when top-level variable sets both value and valueFrom",
+			clusterClassVariables: []clusterv1.ClusterClassVariable{
+				{
+					Name:     "cpu",
+					Required: true,
+					Schema: clusterv1.VariableSchema{
+						OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+							Type: "integer",
+						},
+					},
+				},
+			},
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithVariables(clusterv1.ClusterVariable{
+						Name:  "cpu",
+						Value: apiextensionsv1.JSON{Raw: []byte(`2`)},
+						ValueFrom: &clusterv1.ClusterVariableSource{
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "machine-config"},
+								Key:                  "cpu",
+							},
+						},
+					}).
+					Build()).
+				Build(),
+			expectErr: true,
+		},
 		{
 			name: "should pass when top-level variable and override are valid",
 			clusterClassVariables: []clusterv1.ClusterClassVariable{
@@ -821,11 +913,13 @@ func TestClusterTopologyValidationWithClient(t *testing.T) {
 	g := NewWithT(t)
 
 	tests := []struct {
-		name    string
-		cluster *clusterv1.Cluster
-		class   *clusterv1.ClusterClass
-		objects []client.Object
-		wantErr bool
+		name              string
+		cluster           *clusterv1.Cluster
+		class             *clusterv1.ClusterClass
+		classNamespace    string
+		allowedNamespaces *clusterv1.ClusterClassAllowedNamespaces
+		objects           []client.Object
+		wantErr           bool
 	}{
 		{
 			name: "Accept a cluster with an existing clusterclass named in cluster.spec.topology.class",
@@ -855,12 +949,111 @@ func TestClusterTopologyValidationWithClient(t *testing.T) {
 				Build(),
 			wantErr: true,
 		},
+		{
+			name: "Accept a cluster using a clusterclass in another namespace which allows the namespace of the cluster",
+			cluster: builder.Cluster("tenant", "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						Build()).
+				Build(),
+			class: builder.ClusterClass("platform", "clusterclass").
+				Build(),
+			classNamespace:    "platform",
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{Names: []string{"tenant"}},
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Accept a cluster using a clusterclass in another namespace which allows the namespace of the cluster by selector",
+			cluster: builder.Cluster("tenant", "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						Build()).
+				Build(),
+			class: builder.ClusterClass("platform", "clusterclass").
+				Build(),
+			classNamespace: "platform",
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"team": "a"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Reject a cluster using a clusterclass in another namespace which does not allow the namespace of the cluster",
+			cluster: builder.Cluster("tenant", "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						Build()).
+				Build(),
+			class: builder.ClusterClass("platform", "clusterclass").
+				Build(),
+			classNamespace: "platform",
+			allowedNamespaces: &clusterv1.ClusterClassAllowedNamespaces{
+				Names:    []string{"other"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			},
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"team": "a"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Reject a cluster using a clusterclass in another namespace which does not define allowed namespaces",
+			cluster: builder.Cluster("tenant", "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						Build()).
+				Build(),
+			class: builder.ClusterClass("platform", "clusterclass").
+				Build(),
+			classNamespace: "platform",
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Reject a cluster with an invalid cluster.spec.topology.classNamespace",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						Build()).
+				Build(),
+			class: builder.ClusterClass(metav1.NamespaceDefault, "clusterclass").
+				Build(),
+			classNamespace: "Invalid_Namespace",
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tt.cluster.Spec.Topology.ClassNamespace = tt.classNamespace
+			tt.class.Spec.AllowedNamespaces = tt.allowedNamespaces
+
 			// Sets up the fakeClient for the test case.
 			fakeClient := fake.NewClientBuilder().
 				WithObjects(tt.class).
+				WithObjects(tt.objects...).
 				WithScheme(fakeScheme).
 				Build()
 
@@ -877,6 +1070,253 @@ func TestClusterTopologyValidationWithClient(t *testing.T) {
 	}
 }
 
+func TestClusterTopologyValidationWithAutoscaling(t *testing.T) {
+	tests := []struct {
+		name        string
+		replicas    *int32
+		autoscaling *clusterv1.MachineDeploymentAutoscaling
+		wantErr     bool
+	}{
+		{
+			name:     "Accept a MachineDeployment with replicas",
+			replicas: pointer.Int32(3),
+			wantErr:  false,
+		},
+		{
+			name:        "Accept a MachineDeployment with autoscaling",
+			autoscaling: &clusterv1.MachineDeploymentAutoscaling{MinSize: 1, MaxSize: 5},
+			wantErr:     false,
+		},
+		{
+			name:        "Reject a MachineDeployment with both replicas and autoscaling",
+			replicas:    pointer.Int32(3),
+			autoscaling: &clusterv1.MachineDeploymentAutoscaling{MinSize: 1, MaxSize: 5},
+			wantErr:     true,
+		},
+		{
+			name:        "Reject a MachineDeployment with minSize greater than maxSize",
+			autoscaling: &clusterv1.MachineDeploymentAutoscaling{MinSize: 6, MaxSize: 5},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := builder.MachineDeploymentTopology("workers1").WithClass("aa").Build()
+			md.Replicas = tt.replicas
+			md.Autoscaling = tt.autoscaling
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithMachineDeployment(md).
+						Build()).
+				Build()
+
+			errs := validateMachineDeploymentsAutoscaling(cluster)
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestClusterTopologyValidationWithVariableValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables []clusterv1.ClusterVariable
+		overrides []clusterv1.ClusterVariable
+		wantErr   bool
+	}{
+		{
+			name: "Accept variables with a value",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`2`)}},
+			},
+			overrides: []clusterv1.ClusterVariable{
+				{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`4`)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Accept a variable with a valueFrom",
+			variables: []clusterv1.ClusterVariable{
+				{
+					Name: "cpu",
+					ValueFrom: &clusterv1.ClusterVariableSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "machine-config"},
+							Key:                  "cpu",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Accept a variable with an explicit null value",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`null`)}},
+			},
+			overrides: []clusterv1.ClusterVariable{
+				{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`null`)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Reject a variable without value and valueFrom",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "cpu"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Reject a variable with an empty value and without valueFrom",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte{}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Reject a MachineDeployment variable override without value and valueFrom",
+			variables: []clusterv1.ClusterVariable{
+				{Name: "cpu", Value: apiextensionsv1.JSON{Raw: []byte(`2`)}},
+			},
+			overrides: []clusterv1.ClusterVariable{
+				{Name: "cpu"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := builder.MachineDeploymentTopology("workers1").WithClass("aa").Build()
+			if tt.overrides != nil {
+				md.Variables = &clusterv1.MachineDeploymentVariables{Overrides: tt.overrides}
+			}
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithVariables(tt.variables...).
+						WithMachineDeployment(md).
+						Build()).
+				Build()
+
+			errs := validateClusterVariablesHaveValue(cluster)
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestClusterTopologyValidationWithMachineHealthChecks(t *testing.T) {
+	mhcClass := &clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{
+				Type:    corev1.NodeReady,
+				Status:  corev1.ConditionUnknown,
+				Timeout: metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+	class := builder.ClusterClass(metav1.NamespaceDefault, "clusterclass").
+		WithWorkerMachineDeploymentClasses(
+			*builder.MachineDeploymentClass("with-mhc").WithMachineHealthCheckClass(mhcClass).Build(),
+			*builder.MachineDeploymentClass("without-mhc").Build(),
+		).
+		Build()
+
+	tests := []struct {
+		name               string
+		class              string
+		machineHealthCheck *clusterv1.MachineHealthCheckTopology
+		wantErr            bool
+	}{
+		{
+			name:    "Accept a MachineDeployment without MachineHealthCheck overrides",
+			class:   "without-mhc",
+			wantErr: false,
+		},
+		{
+			name:  "Accept a MachineDeployment disabling the MachineHealthCheck defined in the ClusterClass",
+			class: "with-mhc",
+			machineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				Enable: pointer.Bool(false),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Accept a MachineDeployment enabling the MachineHealthCheck defined in the ClusterClass",
+			class: "with-mhc",
+			machineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				Enable: pointer.Bool(true),
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Accept a MachineDeployment enabling a MachineHealthCheck defined in the Cluster topology",
+			class: "without-mhc",
+			machineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				Enable:                  pointer.Bool(true),
+				MachineHealthCheckClass: *mhcClass,
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Reject a MachineDeployment enabling the MachineHealthCheck if no definition is available",
+			class: "without-mhc",
+			machineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				Enable: pointer.Bool(true),
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Reject a MachineDeployment overriding the MachineHealthCheck without UnhealthyConditions",
+			class: "with-mhc",
+			machineHealthCheck: &clusterv1.MachineHealthCheckTopology{
+				MachineHealthCheckClass: clusterv1.MachineHealthCheckClass{
+					NodeStartupTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := builder.MachineDeploymentTopology("workers1").WithClass(tt.class).Build()
+			md.MachineHealthCheck = tt.machineHealthCheck
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithMachineDeployment(md).
+						Build()).
+				Build()
+
+			errs := validateMachineHealthChecks(cluster, class)
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 // TestClusterTopologyValidationForTopologyClassChange cases where cluster.spec.topology.class is altered.
 func TestClusterTopologyValidationForTopologyClassChange(t *testing.T) {
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
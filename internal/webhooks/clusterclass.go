@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -154,6 +157,12 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	// Validate patches.
 	allErrs = append(allErrs, validatePatches(newClusterClass)...)
 
+	// Validate allowed namespaces.
+	allErrs = append(allErrs, validateAllowedNamespaces(newClusterClass.Spec.AllowedNamespaces, field.NewPath("spec", "allowedNamespaces"))...)
+
+	// Validate add-ons.
+	allErrs = append(allErrs, validateAddons(newClusterClass.Spec.Addons, field.NewPath("spec", "addons"))...)
+
 	// If this is an update run additional validation.
 	if oldClusterClass != nil {
 		// Ensure spec changes are compatible.
@@ -174,6 +183,10 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 		// Ensure no Variable would be invalidated by the update in spec
 		allErrs = append(allErrs,
 			validateVariableUpdates(clusters, oldClusterClass, newClusterClass, field.NewPath("spec", "variables"))...)
+
+		// Ensure Clusters in other namespaces using the ClusterClass are still allowed to use it.
+		allErrs = append(allErrs,
+			webhook.validateClustersAreInAllowedNamespaces(ctx, clusters, newClusterClass, field.NewPath("spec", "allowedNamespaces"))...)
 	}
 
 	if len(allErrs) > 0 {
@@ -440,10 +453,10 @@ func (webhook *ClusterClass) classNamesFromWorkerClass(w clusterv1.WorkersClass)
 }
 
 func (webhook *ClusterClass) getClustersUsingClusterClass(ctx context.Context, clusterClass *clusterv1.ClusterClass) ([]clusterv1.Cluster, error) {
+	// NOTE: Clusters can use a ClusterClass in another namespace, so Clusters are listed in all namespaces.
 	clusters := &clusterv1.ClusterList{}
 	err := webhook.Client.List(ctx, clusters,
-		client.MatchingFields{index.ClusterClassNameField: clusterClass.Name},
-		client.InNamespace(clusterClass.Namespace),
+		client.MatchingFields{index.ClusterClassRefPath: client.ObjectKeyFromObject(clusterClass).String()},
 	)
 	if err != nil {
 		return nil, err
@@ -451,6 +464,103 @@ func (webhook *ClusterClass) getClustersUsingClusterClass(ctx context.Context, c
 	return clusters.Items, nil
 }
 
+// validateAllowedNamespaces validates the namespaces allowed to use a ClusterClass.
+func validateAllowedNamespaces(allowedNamespaces *clusterv1.ClusterClassAllowedNamespaces, fldPath *field.Path) field.ErrorList {
+	if allowedNamespaces == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for i, name := range allowedNamespaces.Names {
+		for _, msg := range validation.IsDNS1123Label(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("names").Index(i), name, msg))
+		}
+	}
+	if allowedNamespaces.Selector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(allowedNamespaces.Selector, fldPath.Child("selector"))...)
+	}
+	return allErrs
+}
+
+// validateAddons validates the add-ons of a ClusterClass.
+func validateAddons(addons []clusterv1.ClusterClassAddon, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i, addon := range addons {
+		addonPath := fldPath.Index(i)
+		for _, msg := range validation.IsDNS1123Label(addon.Name) {
+			allErrs = append(allErrs, field.Invalid(addonPath.Child("name"), addon.Name, msg))
+		}
+		if names.Has(addon.Name) {
+			allErrs = append(allErrs, field.Duplicate(addonPath.Child("name"), addon.Name))
+		}
+		names.Insert(addon.Name)
+
+		if len(addon.Versions) == 0 {
+			allErrs = append(allErrs, field.Required(addonPath.Child("versions"), "at least one version must be defined"))
+		}
+		for j, version := range addon.Versions {
+			versionPath := addonPath.Child("versions").Index(j)
+			if version.Version == "" {
+				allErrs = append(allErrs, field.Required(versionPath.Child("version"), "version must be set"))
+			}
+			if _, err := semver.ParseRange(version.KubernetesVersions); err != nil {
+				allErrs = append(allErrs, field.Invalid(versionPath.Child("kubernetesVersions"), version.KubernetesVersions,
+					fmt.Sprintf("must be a valid semantic version range: %v", err)))
+			}
+			if len(version.Resources) == 0 {
+				allErrs = append(allErrs, field.Required(versionPath.Child("resources"), "at least one resource must be defined"))
+			}
+			for k, resource := range version.Resources {
+				resourcePath := versionPath.Child("resources").Index(k)
+				if resource.Kind != "ConfigMap" && resource.Kind != "Secret" {
+					allErrs = append(allErrs, field.NotSupported(resourcePath.Child("kind"), resource.Kind, []string{"ConfigMap", "Secret"}))
+				}
+				if resource.Name == "" {
+					allErrs = append(allErrs, field.Required(resourcePath.Child("name"), "name must be set"))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateClustersAreInAllowedNamespaces checks that the Clusters using the ClusterClass from other namespaces
+// are still allowed to use it.
+func (webhook *ClusterClass) validateClustersAreInAllowedNamespaces(ctx context.Context, clusters []clusterv1.Cluster, clusterClass *clusterv1.ClusterClass, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	checkedNamespaces := map[string]bool{}
+	for _, cluster := range clusters {
+		if cluster.Namespace == clusterClass.Namespace {
+			continue
+		}
+
+		allowed, ok := checkedNamespaces[cluster.Namespace]
+		if !ok {
+			namespace := &corev1.Namespace{}
+			if err := webhook.Client.Get(ctx, client.ObjectKey{Name: cluster.Namespace}, namespace); err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath,
+					errors.Wrapf(err, "Namespace %q can not be retrieved", cluster.Namespace)))
+				continue
+			}
+			var err error
+			allowed, err = check.ClusterClassAllowsNamespace(clusterClass, namespace)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath, err))
+				continue
+			}
+			checkedNamespaces[cluster.Namespace] = allowed
+		}
+
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(fldPath,
+				fmt.Sprintf("namespace %q cannot be disallowed because it is used by Cluster %q", cluster.Namespace, cluster.Name)))
+		}
+	}
+	return allErrs
+}
+
 func getClusterClassVariablesMapWithReverseIndex(clusterClassVariables []clusterv1.ClusterClassVariable) (map[string]*clusterv1.ClusterClassVariable, map[string]int) {
 	variablesMap := map[string]*clusterv1.ClusterClassVariable{}
 	variablesIndexMap := map[string]int{}
@@ -44,6 +44,7 @@ var (
 
 func init() {
 	_ = clusterv1.AddToScheme(fakeScheme)
+	_ = corev1.AddToScheme(fakeScheme)
 }
 
 func TestClusterClassDefaultNamespaces(t *testing.T) {
@@ -1361,6 +1362,190 @@ func TestClusterClassValidationWithClusterAwareChecks(t *testing.T) {
 	}
 }
 
+func TestClusterClassValidationWithAllowedNamespaces(t *testing.T) {
+	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to create or update ClusterClasses.
+	// Enabling the feature flag temporarily for this test.
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	clusterClass := func(allowedNamespaces *clusterv1.ClusterClassAllowedNamespaces) *clusterv1.ClusterClass {
+		clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+			WithInfrastructureClusterTemplate(
+				builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "inf").Build()).
+			WithControlPlaneTemplate(
+				builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+					Build()).
+			Build()
+		clusterClass.Spec.AllowedNamespaces = allowedNamespaces
+		return clusterClass
+	}
+	tenantObjects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"team": "a"}}},
+		builder.Cluster("tenant", "cluster1").
+			WithTopology(
+				builder.ClusterTopology().
+					WithClass("class1").
+					Build()).
+			Build(),
+	}
+	tenantObjects[1].(*clusterv1.Cluster).Spec.Topology.ClassNamespace = metav1.NamespaceDefault
+
+	tests := []struct {
+		name            string
+		oldClusterClass *clusterv1.ClusterClass
+		newClusterClass *clusterv1.ClusterClass
+		objects         []client.Object
+		expectErr       bool
+	}{
+		{
+			name: "pass with valid allowed namespaces",
+			newClusterClass: clusterClass(&clusterv1.ClusterClassAllowedNamespaces{
+				Names:    []string{"tenant"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			}),
+		},
+		{
+			name: "error if allowed namespaces contain an invalid name",
+			newClusterClass: clusterClass(&clusterv1.ClusterClassAllowedNamespaces{
+				Names: []string{"Invalid_Namespace"},
+			}),
+			expectErr: true,
+		},
+		{
+			name: "error if the allowed namespaces selector is invalid",
+			newClusterClass: clusterClass(&clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Invalid"}},
+				},
+			}),
+			expectErr: true,
+		},
+		{
+			name: "pass if a namespace used by a Cluster is still allowed",
+			oldClusterClass: clusterClass(&clusterv1.ClusterClassAllowedNamespaces{
+				Names: []string{"tenant"},
+			}),
+			newClusterClass: clusterClass(&clusterv1.ClusterClassAllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			}),
+			objects: tenantObjects,
+		},
+		{
+			name: "error if a namespace used by a Cluster is not allowed anymore",
+			oldClusterClass: clusterClass(&clusterv1.ClusterClassAllowedNamespaces{
+				Names: []string{"tenant"},
+			}),
+			newClusterClass: clusterClass(nil),
+			objects:         tenantObjects,
+			expectErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Sets up the fakeClient for the test case.
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			// Create the webhook and add the fakeClient as its client.
+			webhook := &ClusterClass{Client: fakeClient}
+			err := webhook.validate(ctx, tt.oldClusterClass, tt.newClusterClass)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestClusterClassValidationWithAddons(t *testing.T) {
+	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to create or update ClusterClasses.
+	// Enabling the feature flag temporarily for this test.
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	cni := func(kubernetesVersions string, resources ...clusterv1.AddonResourceRef) clusterv1.ClusterClassAddon {
+		return clusterv1.ClusterClassAddon{
+			Name: "cni",
+			Versions: []clusterv1.ClusterClassAddonVersion{
+				{
+					Version:            "v1.0.0",
+					KubernetesVersions: kubernetesVersions,
+					Resources:          resources,
+				},
+			},
+		}
+	}
+	cniResources := clusterv1.AddonResourceRef{Kind: "ConfigMap", Name: "cni-v1.0.0"}
+
+	tests := []struct {
+		name      string
+		addons    []clusterv1.ClusterClassAddon
+		expectErr bool
+	}{
+		{
+			name:   "pass with valid add-ons",
+			addons: []clusterv1.ClusterClassAddon{cni(">=1.23.0 <1.25.0", cniResources)},
+		},
+		{
+			name: "error if add-on names are duplicated",
+			addons: []clusterv1.ClusterClassAddon{
+				cni(">=1.23.0", cniResources),
+				cni("<1.23.0", cniResources),
+			},
+			expectErr: true,
+		},
+		{
+			name: "error if an add-on has no versions",
+			addons: []clusterv1.ClusterClassAddon{
+				{Name: "cni"},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "error if the Kubernetes versions range is invalid",
+			addons:    []clusterv1.ClusterClassAddon{cni("latest", cniResources)},
+			expectErr: true,
+		},
+		{
+			name:      "error if an add-on version has no resources",
+			addons:    []clusterv1.ClusterClassAddon{cni(">=1.23.0")},
+			expectErr: true,
+		},
+		{
+			name:      "error if a resource has an unsupported kind",
+			addons:    []clusterv1.ClusterClassAddon{cni(">=1.23.0", clusterv1.AddonResourceRef{Kind: "Deployment", Name: "cni"})},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "inf").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				Build()
+			clusterClass.Spec.Addons = tt.addons
+
+			webhook := &ClusterClass{Client: fake.NewClientBuilder().WithScheme(fakeScheme).Build()}
+			err := webhook.validate(ctx, nil, clusterClass)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 func TestClusterClassValidationWithVariableChecks(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to create or update ClusterClasses.
 	// Enabling the feature flag temporarily for this test.
@@ -105,8 +105,19 @@ func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *c
 
 	if patch.Definitions != nil {
 		for i, definition := range patch.Definitions {
+			if len(definition.JSONPatches) == 0 && definition.MergePatch == nil && definition.StrategicMergePatch == nil {
+				allErrs = append(allErrs,
+					field.Required(
+						path.Child("definitions").Index(i),
+						"one of jsonPatches, mergePatch or strategicMergePatch must be defined",
+					))
+			}
 			allErrs = append(allErrs,
 				validateJSONPatches(definition.JSONPatches, clusterClass.Spec.Variables, path.Child("definitions").Index(i).Child("jsonPatches"))...)
+			allErrs = append(allErrs,
+				validateMergePatch(definition.MergePatch, path.Child("definitions").Index(i).Child("mergePatch"))...)
+			allErrs = append(allErrs,
+				validateMergePatch(definition.StrategicMergePatch, path.Child("definitions").Index(i).Child("strategicMergePatch"))...)
 			allErrs = append(allErrs,
 				validateSelectors(definition.Selector, clusterClass, path.Child("definitions").Index(i).Child("selector"))...)
 		}
@@ -352,6 +363,73 @@ func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[stri
 	return allErrs
 }
 
+// validateMergePatch validates a merge patch or a strategic merge patch.
+func validateMergePatch(mergePatch *clusterv1.MergePatch, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if mergePatch == nil {
+		return allErrs
+	}
+
+	if mergePatch.Value == nil && mergePatch.Template == nil {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path,
+				prettyPrint(mergePatch),
+				"patch must define one of value or template",
+			))
+	}
+
+	if mergePatch.Value != nil && mergePatch.Template != nil {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path,
+				prettyPrint(mergePatch),
+				"patch can not define both value and template",
+			))
+	}
+
+	// Validate that the value is a JSON object which only contains the spec field, given
+	// that only changes to the spec of templates are picked up.
+	if mergePatch.Value != nil {
+		v := map[string]interface{}{}
+		if err := json.Unmarshal(mergePatch.Value.Raw, &v); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					path.Child("value"),
+					string(mergePatch.Value.Raw),
+					"patch value must be a valid JSON object",
+				))
+		} else {
+			for k := range v {
+				if k != "spec" {
+					allErrs = append(allErrs,
+						field.Invalid(
+							path.Child("value"),
+							string(mergePatch.Value.Raw),
+							"patch value must contain only the spec field",
+						))
+					break
+				}
+			}
+		}
+	}
+
+	if mergePatch.Template != nil {
+		// Error if template can not be parsed.
+		_, err := template.New("template").Funcs(sprig.HermeticTxtFuncMap()).Parse(*mergePatch.Template)
+		if err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					path.Child("template"),
+					*mergePatch.Template,
+					fmt.Sprintf("template can not be parsed: %v", err),
+				))
+		}
+	}
+	return allErrs
+}
+
 func getVariableName(variable string) string {
 	return strings.FieldsFunc(variable, func(r rune) bool {
 		return r == '[' || r == '.'
@@ -17,6 +17,8 @@ limitations under the License.
 package collections
 
 import (
+	"time"
+
 	"github.com/blang/semver"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -177,6 +179,21 @@ func ShouldRolloutAfter(reconciliationTime, rolloutAfter *metav1.Time) Func {
 	}
 }
 
+// ShouldRolloutBefore returns a filter to find all machines whose
+// certificates will expire within the specified days from reconciliationTime.
+func ShouldRolloutBefore(reconciliationTime *metav1.Time, rolloutBefore *controlplanev1.RolloutBefore) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if rolloutBefore == nil || rolloutBefore.CertificatesExpiryDays == nil {
+			return false
+		}
+		if machine == nil || machine.Status.CertificatesExpiryDate == nil || reconciliationTime == nil {
+			return false
+		}
+		rolloutDeadline := reconciliationTime.Add(time.Duration(*rolloutBefore.CertificatesExpiryDays) * 24 * time.Hour)
+		return machine.Status.CertificatesExpiryDate.Time.Before(rolloutDeadline)
+	}
+}
+
 // HasAnnotationKey returns a filter to find all machines that have the
 // specified Annotation key present.
 func HasAnnotationKey(key string) Func {
@@ -165,6 +165,40 @@ func TestShouldRolloutAfter(t *testing.T) {
 	})
 }
 
+func TestShouldRolloutBefore(t *testing.T) {
+	reconciliationTime := metav1.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	rolloutBefore := &controlplanev1.RolloutBefore{CertificatesExpiryDays: pointer.Int32(10)}
+	t.Run("if the machine is nil it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(nil)).To(BeFalse())
+	})
+	t.Run("if the rolloutBefore is nil it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		m.Status.CertificatesExpiryDate = &reconciliationTime
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, nil)(m)).To(BeFalse())
+	})
+	t.Run("if the certificates expiry date of the machine is not set it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(m)).To(BeFalse())
+	})
+	t.Run("if the certificates expire after the rollout deadline it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		expiryDate := metav1.NewTime(reconciliationTime.Add(11 * 24 * time.Hour))
+		m.Status.CertificatesExpiryDate = &expiryDate
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(m)).To(BeFalse())
+	})
+	t.Run("if the certificates expire before the rollout deadline it returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		expiryDate := metav1.NewTime(reconciliationTime.Add(9 * 24 * time.Hour))
+		m.Status.CertificatesExpiryDate = &expiryDate
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(m)).To(BeTrue())
+	})
+}
+
 func TestHashAnnotationKey(t *testing.T) {
 	t.Run("machine with specified annotation returns true", func(t *testing.T) {
 		g := NewWithT(t)
@@ -30,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/cert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -176,6 +177,53 @@ func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duratio
 	return false, nil
 }
 
+// NeedsCertificateAuthorityUpdate returns whether the Kubeconfig secret must be regenerated because it does not trust
+// all the certificates in the cluster CA secret, or because its client certificates are not signed by the certificate
+// authority currently used for signing, e.g. during a rotation of the certificate authorities.
+func NeedsCertificateAuthorityUpdate(configSecret, caSecret *corev1.Secret) (bool, error) {
+	data, err := toKubeconfigBytes(configSecret)
+	if err != nil {
+		return false, err
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+	}
+
+	caCerts, err := cert.ParseCertsPEM(caSecret.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return false, errors.Wrap(err, "failed to decode CA Cert")
+	}
+
+	for _, cluster := range config.Clusters {
+		trustedCerts, err := cert.ParseCertsPEM(cluster.CertificateAuthorityData)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to decode kubeconfig CA Cert")
+		}
+		if len(trustedCerts) != len(caCerts) {
+			return true, nil
+		}
+		for i := range caCerts {
+			if !caCerts[i].Equal(trustedCerts[i]) {
+				return true, nil
+			}
+		}
+	}
+
+	for _, authInfo := range config.AuthInfos {
+		clientCert, err := certs.DecodeCertPEM(authInfo.ClientCertificateData)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to decode kubeconfig client certificate")
+		}
+		if clientCert.CheckSignatureFrom(caCerts[0]) != nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
 func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
@@ -228,6 +276,9 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
+	// Trust all the certificates in the CA secret, which contains more than one certificate while
+	// a rotation of the certificate authorities is in progress.
+	cfg.Clusters[clusterName.Name].CertificateAuthorityData = clusterCA.Data[secret.TLSCrtDataName]
 
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
@@ -417,3 +417,45 @@ func TestRegenerateClientCerts(t *testing.T) {
 
 	g.Expect(newCert.NotAfter).To(BeTemporally(">", oldCert.NotAfter))
 }
+
+func TestNeedsCertificateAuthorityUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	clusterName := client.ObjectKey{Namespace: "test", Name: "test1"}
+	caSecret := ca.AsSecret(clusterName, metav1.OwnerReference{})
+
+	c := fake.NewClientBuilder().WithObjects(caSecret).Build()
+	g.Expect(CreateSecretWithOwner(ctx, c, clusterName, "https://test-cluster-api:6443", metav1.OwnerReference{})).To(Succeed())
+	configSecret, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.Kubeconfig)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	needsUpdate, err := NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(needsUpdate).To(BeFalse())
+
+	// The kubeconfig must trust the new certificate authority.
+	g.Expect(secret.AddNextCertificateAuthority(caSecret, secret.ClusterCA)).To(Succeed())
+	g.Expect(c.Update(ctx, caSecret)).To(Succeed())
+	needsUpdate, err = NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(needsUpdate).To(BeTrue())
+
+	g.Expect(RegenerateSecret(ctx, c, configSecret)).To(Succeed())
+	needsUpdate, err = NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(needsUpdate).To(BeFalse())
+
+	// The client certificate must be signed by the new certificate authority.
+	g.Expect(secret.SwitchToNextCertificateAuthority(caSecret)).To(Succeed())
+	g.Expect(c.Update(ctx, caSecret)).To(Succeed())
+	needsUpdate, err = NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(needsUpdate).To(BeTrue())
+
+	g.Expect(RegenerateSecret(ctx, c, configSecret)).To(Succeed())
+	needsUpdate, err = NeedsCertificateAuthorityUpdate(configSecret, caSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(needsUpdate).To(BeFalse())
+}
@@ -29,6 +29,10 @@ const (
 	// TLSCrtDataName is the key used to store a TLS certificate in the secret's data field.
 	TLSCrtDataName = "tls.crt"
 
+	// TLSNextKeyDataName is the key used to store the private key of the next certificate authority in the secret's
+	// data field while a rotation of the certificate authorities is in progress.
+	TLSNextKeyDataName = "tls-next.key"
+
 	// Kubeconfig is the secret name suffix storing the Cluster Kubeconfig.
 	Kubeconfig = Purpose("kubeconfig")
 
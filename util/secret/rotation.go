@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/cluster-api/util/certs"
+)
+
+// The funcs in this file implement the steps of the rotation of a certificate authority (or of the service account
+// keys) stored in a secret. All the funcs are idempotent, so they can be safely called at every reconcile.
+//
+// NOTE: During the rotation tls.crt contains a bundle with more than one certificate (or public key); the first
+// entry of the bundle always matches tls.key, which is the key used for signing.
+
+// AddNextCertificateAuthority generates a new certificate authority (or new service account keys) and appends it
+// to the bundle stored in the secret, so it is trusted together with the current one.
+// The private key of the new certificate authority is stored in the secret, but it is not used for signing until
+// SwitchToNextCertificateAuthority is called.
+func AddNextCertificateAuthority(s *corev1.Secret, purpose Purpose) error {
+	if _, ok := s.Data[TLSNextKeyDataName]; ok {
+		return nil
+	}
+
+	generator := generateCACert
+	if purpose == ServiceAccount {
+		generator = generateServiceAccountKeys
+	}
+	kp, err := generator()
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate the next %s certificate authority", purpose)
+	}
+
+	blocks, err := decodePEMBlocks(s.Data[TLSCrtDataName])
+	if err != nil {
+		return err
+	}
+	next, _ := pem.Decode(kp.Cert)
+	s.Data[TLSCrtDataName] = encodePEMBlocks(append(blocks, next))
+	s.Data[TLSNextKeyDataName] = kp.Key
+	return nil
+}
+
+// SwitchToNextCertificateAuthority starts using the private key of the certificate authority added by
+// AddNextCertificateAuthority for signing; the previous certificate authority is still trusted.
+func SwitchToNextCertificateAuthority(s *corev1.Secret) error {
+	nextKey, ok := s.Data[TLSNextKeyDataName]
+	if !ok {
+		return nil
+	}
+
+	key, err := certs.DecodePrivateKeyPEM(nextKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode the private key of the next certificate authority")
+	}
+
+	blocks, err := decodePEMBlocks(s.Data[TLSCrtDataName])
+	if err != nil {
+		return err
+	}
+
+	// Move the entry matching the next key at the beginning of the bundle.
+	for i, block := range blocks {
+		publicKey, err := publicKeyFromPEMBlock(block)
+		if err != nil {
+			return err
+		}
+		if !publicKey.Equal(key.Public()) {
+			continue
+		}
+
+		sorted := append([]*pem.Block{block}, blocks[:i]...)
+		sorted = append(sorted, blocks[i+1:]...)
+		s.Data[TLSCrtDataName] = encodePEMBlocks(sorted)
+		s.Data[TLSKeyDataName] = nextKey
+		delete(s.Data, TLSNextKeyDataName)
+		return nil
+	}
+	return errors.New("failed to find the certificate of the next certificate authority")
+}
+
+// RemovePreviousCertificateAuthorities removes from the bundle stored in the secret all the certificate authorities
+// except the one currently used for signing.
+func RemovePreviousCertificateAuthorities(s *corev1.Secret) error {
+	blocks, err := decodePEMBlocks(s.Data[TLSCrtDataName])
+	if err != nil {
+		return err
+	}
+	if len(blocks) <= 1 {
+		return nil
+	}
+	s.Data[TLSCrtDataName] = encodePEMBlocks(blocks[:1])
+	return nil
+}
+
+func decodePEMBlocks(data []byte) ([]*pem.Block, error) {
+	blocks := []*pem.Block{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return nil, ErrMissingCrt
+	}
+	return blocks, nil
+}
+
+func encodePEMBlocks(blocks []*pem.Block) []byte {
+	var buf bytes.Buffer
+	for _, block := range blocks {
+		buf.Write(pem.EncodeToMemory(block))
+	}
+	return buf.Bytes()
+}
+
+// publicKeyFromPEMBlock returns the public key of a certificate or the public key in a PEM block.
+func publicKeyFromPEMBlock(block *pem.Block) (interface{ Equal(crypto.PublicKey) bool }, error) {
+	var publicKey crypto.PublicKey
+	switch block.Type {
+	case "CERTIFICATE":
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse certificate")
+		}
+		publicKey = c.PublicKey
+	case "PUBLIC KEY":
+		k, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse public key")
+		}
+		publicKey = k
+	default:
+		return nil, errors.Errorf("unexpected PEM block type %q", block.Type)
+	}
+
+	k, ok := publicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return nil, errors.Errorf("unsupported public key type %T", publicKey)
+	}
+	return k, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestCertificateAuthorityRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	s := ca.AsSecret(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "cluster1"}, metav1.OwnerReference{})
+	oldCrt := s.Data[secret.TLSCrtDataName]
+	oldKey := s.Data[secret.TLSKeyDataName]
+
+	// Trust the new certificate authority, still signing with the old one.
+	g.Expect(secret.AddNextCertificateAuthority(s, secret.ClusterCA)).To(Succeed())
+	g.Expect(pemBlocks(s.Data[secret.TLSCrtDataName])).To(HaveLen(2))
+	g.Expect(s.Data[secret.TLSCrtDataName]).To(HavePrefix(string(oldCrt)))
+	g.Expect(s.Data[secret.TLSKeyDataName]).To(Equal(oldKey))
+	nextKey := s.Data[secret.TLSNextKeyDataName]
+	g.Expect(nextKey).ToNot(BeEmpty())
+
+	// Adding the next certificate authority again is a no-op.
+	trusted := s.DeepCopy()
+	g.Expect(secret.AddNextCertificateAuthority(s, secret.ClusterCA)).To(Succeed())
+	g.Expect(s).To(Equal(trusted))
+
+	// Sign with the new certificate authority, still trusting the old one.
+	g.Expect(secret.SwitchToNextCertificateAuthority(s)).To(Succeed())
+	g.Expect(pemBlocks(s.Data[secret.TLSCrtDataName])).To(HaveLen(2))
+	g.Expect(s.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+	g.Expect(s.Data).ToNot(HaveKey(secret.TLSNextKeyDataName))
+	_, err := tls.X509KeyPair(s.Data[secret.TLSCrtDataName], s.Data[secret.TLSKeyDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Switching again is a no-op.
+	switched := s.DeepCopy()
+	g.Expect(secret.SwitchToNextCertificateAuthority(s)).To(Succeed())
+	g.Expect(s).To(Equal(switched))
+
+	// Remove the old certificate authority.
+	g.Expect(secret.RemovePreviousCertificateAuthorities(s)).To(Succeed())
+	g.Expect(pemBlocks(s.Data[secret.TLSCrtDataName])).To(HaveLen(1))
+	g.Expect(s.Data[secret.TLSCrtDataName]).ToNot(Equal(oldCrt))
+	_, err = tls.X509KeyPair(s.Data[secret.TLSCrtDataName], s.Data[secret.TLSKeyDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestServiceAccountKeysRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	sa := &secret.Certificate{Purpose: secret.ServiceAccount}
+	g.Expect(sa.Generate()).To(Succeed())
+	s := sa.AsSecret(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "cluster1"}, metav1.OwnerReference{})
+
+	g.Expect(secret.AddNextCertificateAuthority(s, secret.ServiceAccount)).To(Succeed())
+	blocks := pemBlocks(s.Data[secret.TLSCrtDataName])
+	g.Expect(blocks).To(HaveLen(2))
+	g.Expect(blocks[1].Type).To(Equal("PUBLIC KEY"))
+
+	g.Expect(secret.SwitchToNextCertificateAuthority(s)).To(Succeed())
+	g.Expect(secret.RemovePreviousCertificateAuthorities(s)).To(Succeed())
+	blocks = pemBlocks(s.Data[secret.TLSCrtDataName])
+	g.Expect(blocks).To(HaveLen(1))
+
+	// The remaining public key matches the private key used for signing.
+	key, err := certs.DecodePrivateKeyPEM(s.Data[secret.TLSKeyDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	publicKey, err := x509.ParsePKIXPublicKey(blocks[0].Bytes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(key.Public()).To(Equal(publicKey))
+}
+
+func pemBlocks(data []byte) []*pem.Block {
+	blocks := []*pem.Block{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return blocks
+		}
+		blocks = append(blocks, block)
+	}
+}